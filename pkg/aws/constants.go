@@ -4,12 +4,32 @@ package aws
 const (
 	// IAMPolicyVersion is the standard IAM policy language version
 	IAMPolicyVersion = "2012-10-17"
+	// IAMPolicyVersionLegacy is the previous policy language version, still accepted by AWS
+	IAMPolicyVersionLegacy = "2008-10-17"
 
 	// Policy effects
 	EffectAllow = "Allow"
 	EffectDeny  = "Deny"
 )
 
+// Policy principal types
+const (
+	PrincipalTypeAWS           = "AWS"
+	PrincipalTypeService       = "Service"
+	PrincipalTypeFederated     = "Federated"
+	PrincipalTypeCanonicalUser = "CanonicalUser"
+	// PrincipalTypeAll matches every principal and renders as "Principal": "*"
+	PrincipalTypeAll = "*"
+)
+
+// Policy condition operators
+const (
+	ConditionStringEquals = "StringEquals"
+	ConditionStringLike   = "StringLike"
+	ConditionArnLike      = "ArnLike"
+	ConditionBool         = "Bool"
+)
+
 // AWS Services
 const (
 	// EKS pod identity service
@@ -22,22 +42,227 @@ const (
 const (
 	STSAssumeRoleAction = "sts:AssumeRole"
 	STSTagSessionAction = "sts:TagSession"
+
+	STSAssumeRoleWithWebIdentityAction = "sts:AssumeRoleWithWebIdentity"
+	// STSAudience is the audience OIDC tokens are issued for when assuming roles
+	STSAudience = "sts.amazonaws.com"
 )
 
+// GithubOIDCProviderHost is the issuer host of GitHub Actions OIDC tokens
+const GithubOIDCProviderHost = "token.actions.githubusercontent.com"
+
 // KMS Actions
 const (
 	KMSSignAction         = "kms:Sign"
 	KMSGetPublicKeyAction = "kms:GetPublicKey"
 )
 
+// KMS key settings for Ethereum signing keys
+const (
+	KMSKeySpecSecp256k1            = "ECC_SECG_P256K1"
+	KMSKeyUsageSignVerify          = "SIGN_VERIFY"
+	KMSAliasPrefix                 = "alias/"
+	KMSAllActions                  = "kms:*"
+	DefaultKeyDeletionWindowInDays = 30
+)
+
 // IAM statement identifiers
 const (
 	EKSAssumeRoleStatementSid = "AllowEksAuthToAssumeRoleForPodIdentity"
+	KeyAdminStatementSid      = "EnableRootAccountPermissions"
+	KeySignerStatementSid     = "AllowSigning"
 )
 
 // Resource name suffixes
 const (
-	RoleSuffix                 = "-role"
-	PolicySuffix               = "-policy"
-	RolePolicyAttachmentSuffix = "-role-policy-attachment"
+	RoleSuffix                   = "-role"
+	PolicySuffix                 = "-policy"
+	RolePolicyAttachmentSuffix   = "-role-policy-attachment"
+	PodIdentityAssociationSuffix = "-pod-identity"
+	SigningKeySuffix             = "-signing-key"
+	KeyAliasSuffix               = "-alias"
+)
+
+// Aurora PostgreSQL defaults
+const (
+	DefaultPostgresEngineVersion       = "16.6"
+	DefaultPostgresMinCapacity         = 1.0
+	DefaultPostgresMaxCapacity         = 2.0
+	DefaultPostgresBackupRetentionDays = 1
+	PostgresServerlessInstanceClass    = "db.serverless"
+	PostgresEngineFamilyPrefix         = "aurora-postgresql"
+
+	// Serverless v2 capacity bounds in ACUs
+	PostgresMinCapacityLimit = 0.0
+	PostgresMaxCapacityLimit = 256.0
+	// Aurora supports up to 15 readers per cluster
+	PostgresMaxReaderCount = 15
+	// Automated backups can be kept for at most 35 days
+	PostgresMaxBackupRetentionDays = 35
+)
+
+// Postgres resource name suffixes
+const (
+	DbSubnetGroupSuffix           = "-subnet-group"
+	DbClusterSuffix               = "-cluster"
+	DbClusterInstanceSuffix       = "-instance"
+	DbClusterParameterGroupSuffix = "-cluster-params"
+	DbPasswordSecretSuffix        = "-master-password"
+	DbConnectionSecretSuffix      = "-connection"
+)
+
+// Postgres credentials and connection details
+const (
+	// PostgresUrlScheme is the scheme of generated connection URLs
+	PostgresUrlScheme = "postgres"
+	// GeneratedPasswordLength is the length of generated master passwords, which are alphanumeric
+	// to avoid the characters RDS rejects and any that need URL escaping
+	GeneratedPasswordLength = 32
+)
+
+// Keys of the Kubernetes connection Secret
+const (
+	DbConnectionUrlKey      = "url"
+	DbConnectionHostKey     = "host"
+	DbConnectionPortKey     = "port"
+	DbConnectionUsernameKey = "username"
+	DbConnectionPasswordKey = "password"
+	DbConnectionDatabaseKey = "database"
+)
+
+// Templates rendering the connection Secret from an RDS managed master user secret,
+// which holds the "username" and "password" properties
+const (
+	DbManagedUsernameTemplate = "{{ .username }}"
+	DbManagedPasswordTemplate = "{{ .password }}"
+	// DbManagedUrlTemplateFormat is formatted with the host:port and database name
+	DbManagedUrlTemplateFormat = PostgresUrlScheme + "://{{ .username | urlquery }}:{{ .password | urlquery }}@%s/%s"
+)
+
+// S3 bucket settings
+const (
+	S3SseAlgorithmKMS         = "aws:kms"
+	S3VersioningEnabled       = "Enabled"
+	S3RuleEnabled             = "Enabled"
+	S3StorageClassStandardIA  = "STANDARD_IA"
+	S3StorageClassGlacier     = "GLACIER"
+	S3LifecycleRuleId         = "archive"
+	S3ReplicationRuleId       = "replicate-all"
+	S3Service                 = "s3.amazonaws.com"
+	S3SecureTransportKey      = "aws:SecureTransport"
+	S3DenyInsecureTransportId = "DenyInsecureTransport"
+	KMSViaServiceKey          = "kms:ViaService"
+	S3ViaServicePattern       = "s3.*.amazonaws.com"
+	S3ObjectArnFormat         = "arn:aws:s3:::%s/%s"
+
+	// Objects must stay in S3 Standard for 30 days before moving to Standard-IA
+	S3MinInfrequentAccessDays = 30
+)
+
+// S3 Actions
+const (
+	S3ListBucketAction       = "s3:ListBucket"
+	S3GetObjectAction        = "s3:GetObject"
+	S3PutObjectAction        = "s3:PutObject"
+	S3AllActions             = "s3:*"
+	KMSDecryptAction         = "kms:Decrypt"
+	KMSEncryptAction         = "kms:Encrypt"
+	KMSGenerateDataKeyAction = "kms:GenerateDataKey"
+
+	S3GetReplicationConfigurationAction    = "s3:GetReplicationConfiguration"
+	S3GetObjectVersionForReplicationAction = "s3:GetObjectVersionForReplication"
+	S3GetObjectVersionAclAction            = "s3:GetObjectVersionAcl"
+	S3GetObjectVersionTaggingAction        = "s3:GetObjectVersionTagging"
+	S3ReplicateObjectAction                = "s3:ReplicateObject"
+	S3ReplicateDeleteAction                = "s3:ReplicateDelete"
+	S3ReplicateTagsAction                  = "s3:ReplicateTags"
+)
+
+// S3 resource name suffixes
+const (
+	S3EncryptionSuffix        = "-encryption"
+	S3PublicAccessBlockSuffix = "-public-access-block"
+	S3VersioningSuffix        = "-versioning"
+	S3LifecycleSuffix         = "-lifecycle"
+	S3BucketPolicySuffix      = "-bucket-policy"
+	S3ReplicationSuffix       = "-replication"
+	S3ReplicationRoleSuffix   = "-replication-role"
+	S3ReplicationPolicySuffix = "-replication-policy"
+	S3AccessPolicySuffix      = "-s3-policy"
+	S3AccessAttachmentSuffix  = "-s3-role-policy-attachment"
+	S3ReadPolicySuffix        = "-s3-read-policy"
+	S3ReadAttachmentSuffix    = "-s3-read-role-policy-attachment"
+)
+
+// VPC defaults and subnet layout
+const (
+	DefaultVpcCidrBlock = "10.0.0.0/16"
+	// Subnets are carved from the VPC CIDR with this many extra prefix bits, e.g. /20s in a /16
+	VpcSubnetNewBits = 4
+	// Private subnets start at this index so public and private ranges never overlap
+	VpcPrivateSubnetOffset = 8
+	// Subnet groups and EKS clusters need subnets in at least two availability zones
+	VpcMinAvailabilityZones = 2
+	VpcMaxAvailabilityZones = VpcPrivateSubnetOffset
+	VpcMinPrefixLength      = 16
+	VpcMaxPrefixLength      = 24
+	AllIPv4CidrBlock        = "0.0.0.0/0"
+	EipDomainVpc            = "vpc"
+
+	// Subnet tags used by the AWS load balancer controller
+	ElbRoleTag         = "kubernetes.io/role/elb"
+	InternalElbRoleTag = "kubernetes.io/role/internal-elb"
+	ClusterTagPrefix   = "kubernetes.io/cluster/"
+	ClusterTagShared   = "shared"
+)
+
+// VPC resource name suffixes
+const (
+	VpcSuffix                   = "-vpc"
+	InternetGatewaySuffix       = "-igw"
+	PublicSubnetSuffix          = "-public"
+	PrivateSubnetSuffix         = "-private"
+	NatEipSuffix                = "-nat-eip"
+	NatGatewaySuffix            = "-nat"
+	PublicRouteTableSuffix      = "-public-rt"
+	PrivateRouteTableSuffix     = "-private-rt"
+	RouteTableAssociationSuffix = "-rta"
+	VpcDbSubnetGroupSuffix      = "-db"
+)
+
+// EKS defaults
+const (
+	DefaultKubernetesVersion = "1.33"
+	EKSAuthenticationMode    = "API_AND_CONFIG_MAP"
+	EKSService               = "eks.amazonaws.com"
+	EKSCapacityTypeOnDemand  = "ON_DEMAND"
+	EKSCapacityTypeSpot      = "SPOT"
+	EKSTaintNoSchedule       = "NO_SCHEDULE"
+	EKSTaintNoExecute        = "NO_EXECUTE"
+	EKSTaintPreferNoSchedule = "PREFER_NO_SCHEDULE"
+	DefaultNodeGroupDiskSize = 50
+	EKSPodIdentityAgentAddon = "eks-pod-identity-agent"
+	EKSEbsCsiDriverAddon     = "aws-ebs-csi-driver"
+	EbsCsiServiceAccountName = "ebs-csi-controller-sa"
+	EKSAddonResolveOverwrite = "OVERWRITE"
+)
+
+// AWS managed policies used by EKS
+const (
+	EKSClusterPolicyArn    = "arn:aws:iam::aws:policy/AmazonEKSClusterPolicy"
+	EKSWorkerNodePolicyArn = "arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy"
+	EKSCniPolicyArn        = "arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy"
+	ECRReadOnlyPolicyArn   = "arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly"
+	EbsCsiDriverPolicyArn  = "arn:aws:iam::aws:policy/service-role/AmazonEBSCSIDriverPolicy"
+)
+
+// EKS resource name suffixes
+const (
+	EKSClusterRoleSuffix  = "-cluster-role"
+	EKSNodeRoleSuffix     = "-node-role"
+	EKSNodeGroupSuffix    = "-ng-"
+	EKSOidcProviderSuffix = "-oidc"
+	EKSAddonSuffix        = "-addon-"
+	EKSEbsCsiSuffix       = "-ebs-csi"
+	EKSProviderSuffix     = "-k8s"
 )
@@ -0,0 +1,261 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/eks"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// NewEksClusterComponent creates an EKS cluster with managed node groups, the Pod Identity
+// agent and EBS CSI driver add-ons, and an IAM OIDC provider for the cluster's issuer.
+//
+// The component exposes a Kubernetes provider for the cluster; pass it to other components
+// with pulumi.Provider(cluster.Provider) to deploy into the new cluster.
+func NewEksClusterComponent(ctx *pulumi.Context, args *EksClusterArgs, opts ...pulumi.ResourceOption) (*EksClusterComponent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid eks cluster args: %w", err)
+	}
+
+	internalArgs := args.toInternal()
+	component := &EksClusterComponent{}
+
+	err := ctx.RegisterComponentResource("signet:index:EksCluster", args.Name, component, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterRole, err := createServiceRole(ctx, fmt.Sprintf("%s%s", args.Name, EKSClusterRoleSuffix), EKSService,
+		[]string{EKSClusterPolicyArn}, component)
+	if err != nil {
+		return nil, err
+	}
+	component.ClusterRole = clusterRole
+
+	cluster, err := eks.NewCluster(ctx, args.Name, &eks.ClusterArgs{
+		Name:    pulumi.String(args.Name),
+		Version: internalArgs.Version,
+		RoleArn: clusterRole.Arn,
+		VpcConfig: &eks.ClusterVpcConfigArgs{
+			SubnetIds:             internalArgs.SubnetIds,
+			EndpointPrivateAccess: pulumi.Bool(true),
+			EndpointPublicAccess:  pulumi.Bool(true),
+			PublicAccessCidrs:     internalArgs.PublicAccessCidrs,
+		},
+		AccessConfig: &eks.ClusterAccessConfigArgs{
+			AuthenticationMode:                      pulumi.String(EKSAuthenticationMode),
+			BootstrapClusterCreatorAdminPermissions: pulumi.Bool(true),
+		},
+		EnabledClusterLogTypes: internalArgs.EnabledClusterLogTypes,
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create eks cluster: %w", err)
+	}
+	component.Cluster = cluster
+
+	nodeRole, err := createServiceRole(ctx, fmt.Sprintf("%s%s", args.Name, EKSNodeRoleSuffix), EC2Service,
+		[]string{EKSWorkerNodePolicyArn, EKSCniPolicyArn, ECRReadOnlyPolicyArn}, component)
+	if err != nil {
+		return nil, err
+	}
+	component.NodeRole = nodeRole
+
+	var nodeGroupResources []pulumi.Resource
+	for _, nodeGroupArgs := range internalArgs.NodeGroups {
+		nodeGroupName := fmt.Sprintf("%s%s%s", args.Name, EKSNodeGroupSuffix, nodeGroupArgs.Name)
+		nodeGroup, err := eks.NewNodeGroup(ctx, nodeGroupName, &eks.NodeGroupArgs{
+			ClusterName:   cluster.Name,
+			NodeGroupName: pulumi.String(nodeGroupName),
+			NodeRoleArn:   nodeRole.Arn,
+			SubnetIds:     internalArgs.SubnetIds,
+			InstanceTypes: nodeGroupArgs.InstanceTypes,
+			CapacityType:  nodeGroupArgs.CapacityType,
+			DiskSize:      nodeGroupArgs.DiskSize,
+			Labels:        nodeGroupArgs.Labels,
+			Taints:        nodeGroupArgs.Taints,
+			ScalingConfig: &eks.NodeGroupScalingConfigArgs{
+				MinSize:     nodeGroupArgs.MinSize,
+				MaxSize:     nodeGroupArgs.MaxSize,
+				DesiredSize: nodeGroupArgs.DesiredSize,
+			},
+		}, pulumi.Parent(component), pulumi.IgnoreChanges([]string{"scalingConfig.desiredSize"}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create node group %s: %w", nodeGroupArgs.Name, err)
+		}
+		component.NodeGroups = append(component.NodeGroups, nodeGroup)
+		nodeGroupResources = append(nodeGroupResources, nodeGroup)
+	}
+
+	// Add-ons run as pods, so they are installed once nodes exist
+	podIdentityAddon, err := eks.NewAddon(ctx, fmt.Sprintf("%s%s%s", args.Name, EKSAddonSuffix, EKSPodIdentityAgentAddon), &eks.AddonArgs{
+		ClusterName:              cluster.Name,
+		AddonName:                pulumi.String(EKSPodIdentityAgentAddon),
+		ResolveConflictsOnCreate: pulumi.String(EKSAddonResolveOverwrite),
+		ResolveConflictsOnUpdate: pulumi.String(EKSAddonResolveOverwrite),
+	}, pulumi.Parent(component), pulumi.DependsOn(nodeGroupResources))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod identity agent add-on: %w", err)
+	}
+	component.Addons = append(component.Addons, podIdentityAddon)
+
+	// The EBS CSI controller gets its permissions through pod identity
+	ebsCsiRole, err := CreatePodIdentityRole(ctx, fmt.Sprintf("%s%s", args.Name, EKSEbsCsiSuffix), EKSEbsCsiDriverAddon, component)
+	if err != nil {
+		return nil, err
+	}
+	_, err = iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s%s%s", args.Name, EKSEbsCsiSuffix, RolePolicyAttachmentSuffix), &iam.RolePolicyAttachmentArgs{
+		Role:      ebsCsiRole.Name,
+		PolicyArn: pulumi.String(EbsCsiDriverPolicyArn),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach EBS CSI driver policy: %w", err)
+	}
+	component.EbsCsiRole = ebsCsiRole
+
+	ebsCsiAddon, err := eks.NewAddon(ctx, fmt.Sprintf("%s%s%s", args.Name, EKSAddonSuffix, EKSEbsCsiDriverAddon), &eks.AddonArgs{
+		ClusterName:              cluster.Name,
+		AddonName:                pulumi.String(EKSEbsCsiDriverAddon),
+		ResolveConflictsOnCreate: pulumi.String(EKSAddonResolveOverwrite),
+		ResolveConflictsOnUpdate: pulumi.String(EKSAddonResolveOverwrite),
+		PodIdentityAssociations: eks.AddonPodIdentityAssociationArray{
+			eks.AddonPodIdentityAssociationArgs{
+				RoleArn:        ebsCsiRole.Arn,
+				ServiceAccount: pulumi.String(EbsCsiServiceAccountName),
+			},
+		},
+	}, pulumi.Parent(component), pulumi.DependsOn(append(nodeGroupResources, podIdentityAddon)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EBS CSI driver add-on: %w", err)
+	}
+	component.Addons = append(component.Addons, ebsCsiAddon)
+
+	// The OIDC provider lets workloads that still use IRSA assume roles
+	issuer := cluster.Identities.Index(pulumi.Int(0)).Oidcs().Index(pulumi.Int(0)).Issuer().Elem()
+	oidcProvider, err := iam.NewOpenIdConnectProvider(ctx, fmt.Sprintf("%s%s", args.Name, EKSOidcProviderSuffix), &iam.OpenIdConnectProviderArgs{
+		Url:           issuer,
+		ClientIdLists: pulumi.StringArray{pulumi.String(STSAudience)},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OIDC provider: %w", err)
+	}
+	component.OidcProvider = oidcProvider
+
+	kubeconfig := pulumi.ToSecret(pulumi.All(cluster.Name, cluster.Endpoint, cluster.CertificateAuthority.Data().Elem(), cluster.Arn).ApplyT(
+		func(values []interface{}) (string, error) {
+			return createKubeconfig(values[0].(string), values[1].(string), values[2].(string), values[3].(string))
+		},
+	)).(pulumi.StringOutput)
+	component.Kubeconfig = kubeconfig
+
+	provider, err := kubernetes.NewProvider(ctx, fmt.Sprintf("%s%s", args.Name, EKSProviderSuffix), &kubernetes.ProviderArgs{
+		Kubeconfig: kubeconfig,
+	}, pulumi.Parent(component), pulumi.DependsOn(nodeGroupResources))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes provider: %w", err)
+	}
+	component.Provider = provider
+
+	component.ClusterName = cluster.Name
+	component.Endpoint = cluster.Endpoint
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"clusterName":     cluster.Name,
+		"endpoint":        cluster.Endpoint,
+		"oidcProviderArn": oidcProvider.Arn,
+		"kubeconfig":      kubeconfig,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+// createServiceRole creates a role assumable by an AWS service with managed policies attached
+func createServiceRole(ctx *pulumi.Context, name string, service string, policyArns []string, parent pulumi.Resource) (*iam.Role, error) {
+	assumeRolePolicy := NewPolicyDocument(PolicyStatement{
+		Effect: EffectAllow,
+		Principal: []PolicyPrincipal{
+			{Type: PrincipalTypeService, Identifiers: pulumi.StringArray{pulumi.String(service)}},
+		},
+		Action: []string{STSAssumeRoleAction},
+	})
+
+	role, err := iam.NewRole(ctx, name, &iam.RoleArgs{
+		AssumeRolePolicy: assumeRolePolicy.ToJSON(),
+		Tags: pulumi.StringMap{
+			"Name": pulumi.String(name),
+		},
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role %s: %w", name, err)
+	}
+
+	for _, policyArn := range policyArns {
+		policyName := policyArn[strings.LastIndex(policyArn, "/")+1:]
+		_, err := iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s-%s", name, policyName), &iam.RolePolicyAttachmentArgs{
+			Role:      role.Name,
+			PolicyArn: pulumi.String(policyArn),
+		}, pulumi.Parent(parent))
+		if err != nil {
+			return nil, fmt.Errorf("failed to attach %s to role %s: %w", policyName, name, err)
+		}
+	}
+
+	return role, nil
+}
+
+// createKubeconfig renders a kubeconfig for an EKS cluster that authenticates with
+// `aws eks get-token`. The region is taken from the cluster ARN.
+func createKubeconfig(clusterName string, endpoint string, certificateAuthority string, clusterArn string) (string, error) {
+	arnParts := strings.Split(clusterArn, ":")
+	if len(arnParts) < 4 || arnParts[3] == "" {
+		return "", fmt.Errorf("invalid cluster arn %q", clusterArn)
+	}
+	region := arnParts[3]
+
+	kubeconfig := map[string]interface{}{
+		"apiVersion":      "v1",
+		"kind":            "Config",
+		"current-context": clusterName,
+		"clusters": []map[string]interface{}{
+			{
+				"name": clusterName,
+				"cluster": map[string]interface{}{
+					"server":                     endpoint,
+					"certificate-authority-data": certificateAuthority,
+				},
+			},
+		},
+		"contexts": []map[string]interface{}{
+			{
+				"name": clusterName,
+				"context": map[string]interface{}{
+					"cluster": clusterName,
+					"user":    clusterName,
+				},
+			},
+		},
+		"users": []map[string]interface{}{
+			{
+				"name": clusterName,
+				"user": map[string]interface{}{
+					"exec": map[string]interface{}{
+						"apiVersion": "client.authentication.k8s.io/v1beta1",
+						"command":    "aws",
+						"args":       []string{"eks", "get-token", "--cluster-name", clusterName, "--region", region, "--output", "json"},
+					},
+				},
+			},
+		},
+	}
+
+	jsonBytes, err := json.Marshal(kubeconfig)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateKubeconfig(t *testing.T) {
+	kubeconfig, err := createKubeconfig(
+		"signet",
+		"https://ABCDEF.gr7.us-east-1.eks.amazonaws.com",
+		"Y2VydGlmaWNhdGU=",
+		"arn:aws:eks:us-east-1:123456789012:cluster/signet",
+	)
+	require.NoError(t, err)
+
+	var config struct {
+		CurrentContext string `json:"current-context"`
+		Clusters       []struct {
+			Cluster struct {
+				Server                   string `json:"server"`
+				CertificateAuthorityData string `json:"certificate-authority-data"`
+			} `json:"cluster"`
+		} `json:"clusters"`
+		Users []struct {
+			User struct {
+				Exec struct {
+					Command string   `json:"command"`
+					Args    []string `json:"args"`
+				} `json:"exec"`
+			} `json:"user"`
+		} `json:"users"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(kubeconfig), &config))
+
+	assert.Equal(t, "signet", config.CurrentContext)
+	require.Len(t, config.Clusters, 1)
+	assert.Equal(t, "https://ABCDEF.gr7.us-east-1.eks.amazonaws.com", config.Clusters[0].Cluster.Server)
+	assert.Equal(t, "Y2VydGlmaWNhdGU=", config.Clusters[0].Cluster.CertificateAuthorityData)
+	require.Len(t, config.Users, 1)
+	assert.Equal(t, "aws", config.Users[0].User.Exec.Command)
+	assert.Equal(t, []string{"eks", "get-token", "--cluster-name", "signet", "--region", "us-east-1", "--output", "json"}, config.Users[0].User.Exec.Args)
+
+	_, err = createKubeconfig("signet", "https://example", "ca", "not-an-arn")
+	assert.Error(t, err)
+}
+
+func TestEksNodeGroupArgsToInternalDefaults(t *testing.T) {
+	nodeGroup := EksNodeGroupArgs{
+		Name:          "general",
+		InstanceTypes: []string{"m6i.xlarge"},
+		MinSize:       2,
+		MaxSize:       4,
+		Taints:        []EksNodeGroupTaint{{Key: "dedicated", Effect: EKSTaintNoSchedule}},
+	}
+	internal := nodeGroup.toInternal()
+
+	assert.Equal(t, pulumi.Int(2), internal.DesiredSize)
+	assert.Equal(t, pulumi.IntPtr(DefaultNodeGroupDiskSize), internal.DiskSize)
+	assert.Equal(t, pulumi.StringPtr(EKSCapacityTypeOnDemand), internal.CapacityType)
+	require.Len(t, internal.Taints, 1)
+}
+
+func TestEksClusterArgsToInternalDefaults(t *testing.T) {
+	args := EksClusterArgs{
+		Name:      "signet",
+		SubnetIds: []string{"subnet-a", "subnet-b"},
+	}
+	internal := args.toInternal()
+
+	assert.Equal(t, pulumi.String(DefaultKubernetesVersion), internal.Version)
+	assert.Equal(t, pulumi.StringArray{pulumi.String(AllIPv4CidrBlock)}, internal.PublicAccessCidrs)
+	assert.Equal(t, pulumi.ToStringArray(args.SubnetIds), internal.SubnetIds)
+}
@@ -8,7 +8,6 @@
 package aws
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
@@ -54,43 +53,9 @@ func CreateIAMResources(
 	keyArn pulumi.StringInput,
 	parent pulumi.Resource,
 ) (*IAMResources, error) {
-	// Create IAM role with assume role policy for EKS pod identity
-	assumeRolePolicy := IAMPolicy{
-		Version: IAMPolicyVersion,
-		Statement: []IAMStatement{
-			{
-				Sid:    EKSAssumeRoleStatementSid,
-				Effect: EffectAllow,
-				Principal: struct {
-					Service []string `json:"Service"`
-				}{
-					Service: []string{
-						EKSPodsService,
-						EC2Service,
-					},
-				},
-				Action: []string{
-					STSAssumeRoleAction,
-					STSTagSessionAction,
-				},
-			},
-		},
-	}
-
-	assumeRolePolicyJSON, err := json.Marshal(assumeRolePolicy)
+	role, err := CreatePodIdentityRole(ctx, name, serviceName, parent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal assume role policy: %w", err)
-	}
-
-	role, err := iam.NewRole(ctx, fmt.Sprintf("%s%s", name, RoleSuffix), &iam.RoleArgs{
-		AssumeRolePolicy: pulumi.String(assumeRolePolicyJSON),
-		Description:      pulumi.String(fmt.Sprintf("Role for %s pod to assume", serviceName)),
-		Tags: pulumi.StringMap{
-			"Name": pulumi.String(fmt.Sprintf("%s%s", name, RoleSuffix)),
-		},
-	}, pulumi.Parent(parent))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create IAM role: %w", err)
+		return nil, err
 	}
 
 	// Create KMS policy for the specified key
@@ -132,29 +97,14 @@ func CreateIAMResources(
 //   - kms:Sign: Allows signing messages using the KMS key
 //   - kms:GetPublicKey: Allows retrieving the public key associated with the KMS key
 func CreateKMSPolicy(key pulumi.StringInput) pulumi.StringOutput {
-	// Create internal policy with Pulumi types
-	policy := kmsPolicyInternal{
-		Version: IAMPolicyVersion,
-		Statement: []kmsStatementInternal{
-			{
-				Effect: EffectAllow,
-				Action: []string{
-					KMSSignAction,
-					KMSGetPublicKeyAction,
-				},
-				Resource: key,
-			},
+	return NewPolicyDocument(PolicyStatement{
+		Effect: EffectAllow,
+		Action: []string{
+			KMSSignAction,
+			KMSGetPublicKeyAction,
 		},
-	}
-
-	// Convert to JSON string output
-	return pulumi.All(key).ApplyT(func(_ []interface{}) (string, error) {
-		jsonBytes, err := json.Marshal(policy)
-		if err != nil {
-			return "", err
-		}
-		return string(jsonBytes), nil
-	}).(pulumi.StringOutput)
+		Resource: pulumi.StringArray{key},
+	}).ToJSON()
 }
 
 // CreateKMSPolicyFromPublic creates a KMS policy document from public types
@@ -170,123 +120,106 @@ func CreateKMSPolicyFromPublic(policy KMSPolicy) (pulumi.StringOutput, error) {
 		return pulumi.StringOutput{}, fmt.Errorf("invalid KMS policy: %w", err)
 	}
 
-	// Convert to internal format
-	internalPolicy := policy.toInternal()
-
-	// Convert to JSON string output
-	// Since we're working with internal types that have Pulumi inputs,
-	// we need to handle the conversion carefully
-	statements := internalPolicy.Statement
-	if len(statements) == 0 {
-		return pulumi.StringOutput{}, fmt.Errorf("policy must have at least one statement")
+	// Convert to internal format, keeping every statement
+	document := policy.toInternal()
+	if err := document.Validate(); err != nil {
+		return pulumi.StringOutput{}, fmt.Errorf("invalid KMS policy: %w", err)
 	}
 
-	// For now, we'll create a simple policy with the first statement
-	// In a more complex scenario, you might need to handle multiple statements differently
-	firstStmt := statements[0]
+	return document.ToJSON(), nil
+}
 
-	return pulumi.All(firstStmt.Resource).ApplyT(func(values []interface{}) (string, error) {
-		resource := values[0].(string)
-		// Create a temporary struct for marshaling
-		tempPolicy := struct {
-			Version   string `json:"Version"`
-			Statement []struct {
-				Effect   string   `json:"Effect"`
-				Action   []string `json:"Action"`
-				Resource string   `json:"Resource"`
-			} `json:"Statement"`
-		}{
-			Version: internalPolicy.Version,
-			Statement: []struct {
-				Effect   string   `json:"Effect"`
-				Action   []string `json:"Action"`
-				Resource string   `json:"Resource"`
-			}{
-				{
-					Effect:   firstStmt.Effect,
-					Action:   firstStmt.Action,
-					Resource: resource,
-				},
+// CreateEcrDeployPolicy creates an IAM policy document that allows pushing images to
+// an ECR repository in the given region and account.
+func CreateEcrDeployPolicy(awsRegion string, awsAccountId string, repositoryName string) pulumi.StringOutput {
+	return NewPolicyDocument(
+		PolicyStatement{
+			Effect: EffectAllow,
+			Action: []string{
+				"ecr:GetDownloadUrlForLayer",
+				"ecr:BatchGetImage",
+				"ecr:BatchCheckLayerAvailability",
+				"ecr:PutImage",
+				"ecr:InitiateLayerUpload",
+				"ecr:UploadLayerPart",
+				"ecr:CompleteLayerUpload",
 			},
-		}
-
-		jsonBytes, err := json.Marshal(tempPolicy)
-		if err != nil {
-			return "", err
-		}
-		return string(jsonBytes), nil
-	}).(pulumi.StringOutput), nil
+			Resource: pulumi.StringArray{
+				pulumi.Sprintf("arn:aws:ecr:%s:%s:repository/%s", awsRegion, awsAccountId, repositoryName),
+			},
+		},
+		PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{"ecr:GetAuthorizationToken"},
+			Resource: pulumi.StringArray{pulumi.String("*")},
+		},
+	).ToJSON()
 }
 
-func CreateEcrDeployPolicy(ctx *pulumi.Context, awsAccountId string, repositoryName string) (*iam.GetPolicyDocumentResult, error) {
-	policyDocument, err := iam.GetPolicyDocument(ctx, &iam.GetPolicyDocumentArgs{
-		Version: pulumi.StringRef("2012-10-17"),
-		Statements: []iam.GetPolicyDocumentStatement{
+// CreateGithubAssumeRolePolicy creates a trust policy that lets GitHub Actions workflows in
+// the given repository assume a role through the account's GitHub OIDC provider.
+func CreateGithubAssumeRolePolicy(awsAccountId string, githubOrganization string, githubRepository string) pulumi.StringOutput {
+	return NewPolicyDocument(PolicyStatement{
+		Effect: EffectAllow,
+		Principal: []PolicyPrincipal{
 			{
-				Effect: pulumi.StringRef("Allow"),
-				Actions: []string{
-					"ecr:GetDownloadUrlForLayer",
-					"ecr:BatchGetImage",
-					"ecr:BatchCheckLayerAvailability",
-					"ecr:PutImage",
-					"ecr:InitiateLayerUpload",
-					"ecr:UploadLayerPart",
-					"ecr:CompleteLayerUpload",
-				},
-				Resources: []string{
-					fmt.Sprintf("arn:aws:ecr:us-east-1:%s:repository/%s", awsAccountId, repositoryName),
-				},
+				Type:        PrincipalTypeFederated,
+				Identifiers: pulumi.StringArray{pulumi.Sprintf("arn:aws:iam::%s:oidc-provider/%s", awsAccountId, GithubOIDCProviderHost)},
+			},
+		},
+		Action: []string{STSAssumeRoleWithWebIdentityAction},
+		Condition: []PolicyCondition{
+			{
+				Operator: ConditionStringEquals,
+				Key:      fmt.Sprintf("%s:aud", GithubOIDCProviderHost),
+				Values:   pulumi.StringArray{pulumi.String(STSAudience)},
 			},
 			{
-				Effect: pulumi.StringRef("Allow"),
-				Actions: []string{
-					"ecr:GetAuthorizationToken",
-				},
-				Resources: []string{
-					"*",
-				},
+				Operator: ConditionStringLike,
+				Key:      fmt.Sprintf("%s:sub", GithubOIDCProviderHost),
+				Values:   pulumi.StringArray{pulumi.Sprintf("repo:%s/%s:*", githubOrganization, githubRepository)},
 			},
 		},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return policyDocument, nil
-
+	}).ToJSON()
 }
 
-func CreateGithubAssumeRolePolicy(ctx *pulumi.Context, awsAccountId string, githubOrganization string, githubRepository string) (*iam.GetPolicyDocumentResult, error) {
-	policyDocument, err := iam.GetPolicyDocument(ctx, &iam.GetPolicyDocumentArgs{
-		Version: pulumi.StringRef("2012-10-17"),
-		Statements: []iam.GetPolicyDocumentStatement{
+// CreatePodIdentityRole creates an IAM role that can be assumed by EKS pod identity.
+// The role has no permissions attached; callers attach policies as needed.
+func CreatePodIdentityRole(
+	ctx *pulumi.Context,
+	name string,
+	serviceName string,
+	parent pulumi.Resource,
+) (*iam.Role, error) {
+	// Create IAM role with assume role policy for EKS pod identity
+	assumeRolePolicy := NewPolicyDocument(PolicyStatement{
+		Sid:    EKSAssumeRoleStatementSid,
+		Effect: EffectAllow,
+		Principal: []PolicyPrincipal{
 			{
-				Effect: pulumi.StringRef("Allow"),
-				Principals: []iam.GetPolicyDocumentStatementPrincipal{
-					{
-						Type:        "Federated",
-						Identifiers: []string{fmt.Sprintf("arn:aws:iam::%s:oidc-provider/token.actions.githubusercontent.com", awsAccountId)},
-					},
-				},
-				Actions: []string{"sts:AssumeRoleWithWebIdentity"},
-				Conditions: []iam.GetPolicyDocumentStatementCondition{
-					{
-						Test:     "StringEquals",
-						Variable: "token.actions.githubusercontent.com:aud",
-						Values:   []string{"sts.amazonaws.com"},
-					},
-					{
-						Test:     "StringLike",
-						Variable: "token.actions.githubusercontent.com:sub",
-						Values:   []string{fmt.Sprintf("repo:%s/%s:*", githubOrganization, githubRepository)},
-					},
+				Type: PrincipalTypeService,
+				Identifiers: pulumi.StringArray{
+					pulumi.String(EKSPodsService),
+					pulumi.String(EC2Service),
 				},
 			},
 		},
+		Action: []string{
+			STSAssumeRoleAction,
+			STSTagSessionAction,
+		},
 	})
+
+	role, err := iam.NewRole(ctx, fmt.Sprintf("%s%s", name, RoleSuffix), &iam.RoleArgs{
+		AssumeRolePolicy: assumeRolePolicy.ToJSON(),
+		Description:      pulumi.String(fmt.Sprintf("Role for %s pod to assume", serviceName)),
+		Tags: pulumi.StringMap{
+			"Name": pulumi.String(fmt.Sprintf("%s%s", name, RoleSuffix)),
+		},
+	}, pulumi.Parent(parent))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create IAM role: %w", err)
 	}
 
-	return policyDocument, nil
+	return role, nil
 }
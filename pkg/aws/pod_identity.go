@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/eks"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// PodIdentityConfig enables EKS Pod Identity for a component. When set, the component
+// creates an IAM role trusted by pods.eks.amazonaws.com and associates it with its
+// ServiceAccount, so static AWS access keys are no longer required.
+type PodIdentityConfig struct {
+	// ClusterName is the name of the EKS cluster the association is created in
+	ClusterName string
+	// KmsKeyArn is an optional KMS key the role is allowed to sign with
+	KmsKeyArn string
+}
+
+// PodIdentityResources contains the AWS resources created for EKS Pod Identity.
+type PodIdentityResources struct {
+	// Role is the IAM role assumed by the component's pods
+	Role *iam.Role
+	// IAMResources holds the KMS policy and attachment, set only when a KMS key is configured
+	IAMResources *IAMResources
+	// Association binds the role to the component's ServiceAccount
+	Association *eks.PodIdentityAssociation
+}
+
+// CreatePodIdentityResources creates an IAM role for a component and binds it to the
+// component's ServiceAccount with an EKS Pod Identity association. When a signing key
+// is given, or the config carries a KMS key ARN, the role is also granted permission
+// to sign with that key.
+//
+// Parameters:
+//   - ctx: The Pulumi context
+//   - name: Base name for the created resources
+//   - serviceName: Name of the service that will use the role
+//   - config: The pod identity configuration
+//   - namespace: Kubernetes namespace of the ServiceAccount
+//   - serviceAccountName: Name of the Kubernetes ServiceAccount to bind
+//   - signingKey: Optional signing key the role may use, takes precedence over config.KmsKeyArn
+//   - parent: Parent Pulumi resource for dependency tracking
+func CreatePodIdentityResources(
+	ctx *pulumi.Context,
+	name string,
+	serviceName string,
+	config PodIdentityConfig,
+	namespace pulumi.StringInput,
+	serviceAccountName pulumi.StringInput,
+	signingKey *SigningKeyComponent,
+	parent pulumi.Resource,
+) (*PodIdentityResources, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid pod identity config: %w", err)
+	}
+
+	resources := &PodIdentityResources{}
+
+	var keyArn pulumi.StringInput
+	if signingKey != nil {
+		keyArn = signingKey.KeyArn
+	} else if config.KmsKeyArn != "" {
+		keyArn = pulumi.String(config.KmsKeyArn)
+	}
+
+	if keyArn != nil {
+		iamResources, err := CreateIAMResources(ctx, name, serviceName, keyArn, parent)
+		if err != nil {
+			return nil, err
+		}
+		resources.IAMResources = iamResources
+		resources.Role = iamResources.Role
+	} else {
+		role, err := CreatePodIdentityRole(ctx, name, serviceName, parent)
+		if err != nil {
+			return nil, err
+		}
+		resources.Role = role
+	}
+
+	association, err := eks.NewPodIdentityAssociation(ctx, fmt.Sprintf("%s%s", name, PodIdentityAssociationSuffix), &eks.PodIdentityAssociationArgs{
+		ClusterName:    pulumi.String(config.ClusterName),
+		Namespace:      namespace,
+		ServiceAccount: serviceAccountName,
+		RoleArn:        resources.Role.Arn,
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod identity association: %w", err)
+	}
+	resources.Association = association
+
+	return resources, nil
+}
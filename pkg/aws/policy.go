@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// PolicyDocument is a typed IAM policy document. It is used for identity policies,
+// trust policies and resource policies such as KMS key policies.
+//
+// Resources, principal identifiers and condition values are Pulumi inputs, so ARNs
+// of resources created in the same stack can be referenced directly.
+type PolicyDocument struct {
+	// Version specifies the policy language version
+	Version string
+	// Statement contains the list of permission statements
+	Statement []PolicyStatement
+}
+
+// PolicyStatement is a single statement in a PolicyDocument. Exactly one of Action or
+// NotAction must be set, and at most one of Resource or NotResource and of Principal
+// or NotPrincipal.
+type PolicyStatement struct {
+	// Sid is an optional identifier for the statement
+	Sid string
+	// Effect specifies whether the statement allows or denies access
+	Effect string
+	// Principal specifies who is allowed or denied access
+	Principal []PolicyPrincipal
+	// NotPrincipal specifies who is excluded from the statement
+	NotPrincipal []PolicyPrincipal
+	// Action specifies the actions that are allowed or denied
+	Action []string
+	// NotAction specifies the actions excluded from the statement
+	NotAction []string
+	// Resource specifies the resources the statement applies to
+	Resource pulumi.StringArray
+	// NotResource specifies the resources excluded from the statement
+	NotResource pulumi.StringArray
+	// Condition specifies when the statement is in effect
+	Condition []PolicyCondition
+}
+
+// PolicyPrincipal identifies a set of principals of a single type.
+type PolicyPrincipal struct {
+	// Type is one of AWS, Service, Federated, CanonicalUser or "*" for everyone
+	Type string
+	// Identifiers are the ARNs, service names or IDs of the principals, unused for "*"
+	Identifiers pulumi.StringArray
+}
+
+// PolicyCondition is a single condition block entry, e.g. StringEquals on aws:SourceArn.
+type PolicyCondition struct {
+	// Operator is the condition operator, e.g. StringEquals or ArnLike
+	Operator string
+	// Key is the condition key, e.g. aws:SourceArn
+	Key string
+	// Values are the values the key is compared against
+	Values pulumi.StringArray
+}
+
+// NewPolicyDocument creates a policy document with the standard policy version.
+func NewPolicyDocument(statements ...PolicyStatement) PolicyDocument {
+	return PolicyDocument{
+		Version:   IAMPolicyVersion,
+		Statement: statements,
+	}
+}
+
+// ToJSON renders the policy document as JSON once all of its inputs are known.
+// Validation errors are surfaced through the returned output; call Validate first
+// to catch them when building the document.
+func (d PolicyDocument) ToJSON() pulumi.StringOutput {
+	// Collect every input in the document so they can be resolved together
+	var inputs []interface{}
+	add := func(values pulumi.StringArray) int {
+		inputs = append(inputs, values)
+		return len(inputs) - 1
+	}
+
+	type principalIndex struct {
+		principalType string
+		index         int
+	}
+	type conditionIndex struct {
+		operator string
+		key      string
+		index    int
+	}
+	type statementIndex struct {
+		statement     PolicyStatement
+		resource      int
+		notResource   int
+		principals    []principalIndex
+		notPrincipals []principalIndex
+		conditions    []conditionIndex
+	}
+
+	indexPrincipals := func(principals []PolicyPrincipal) []principalIndex {
+		indexes := make([]principalIndex, len(principals))
+		for i, principal := range principals {
+			indexes[i] = principalIndex{principalType: principal.Type, index: add(principal.Identifiers)}
+		}
+		return indexes
+	}
+
+	statements := make([]statementIndex, len(d.Statement))
+	for i, stmt := range d.Statement {
+		conditions := make([]conditionIndex, len(stmt.Condition))
+		for j, condition := range stmt.Condition {
+			conditions[j] = conditionIndex{operator: condition.Operator, key: condition.Key, index: add(condition.Values)}
+		}
+		statements[i] = statementIndex{
+			statement:     stmt,
+			resource:      add(stmt.Resource),
+			notResource:   add(stmt.NotResource),
+			principals:    indexPrincipals(stmt.Principal),
+			notPrincipals: indexPrincipals(stmt.NotPrincipal),
+			conditions:    conditions,
+		}
+	}
+
+	return pulumi.All(inputs...).ApplyT(func(values []interface{}) (string, error) {
+		if err := d.Validate(); err != nil {
+			return "", fmt.Errorf("invalid policy document: %w", err)
+		}
+
+		resolved := func(index int) []string {
+			strings, _ := values[index].([]string)
+			return strings
+		}
+		renderPrincipals := func(principals []principalIndex) interface{} {
+			if len(principals) == 0 {
+				return nil
+			}
+			if len(principals) == 1 && principals[0].principalType == PrincipalTypeAll {
+				return PrincipalTypeAll
+			}
+			rendered := make(map[string]interface{}, len(principals))
+			for _, principal := range principals {
+				rendered[principal.principalType] = stringOrList(resolved(principal.index))
+			}
+			return rendered
+		}
+
+		rendered := policyDocumentJSON{
+			Version:   d.Version,
+			Statement: make([]policyStatementJSON, len(statements)),
+		}
+		for i, stmt := range statements {
+			var condition map[string]map[string]interface{}
+			if len(stmt.conditions) > 0 {
+				condition = make(map[string]map[string]interface{})
+				for _, c := range stmt.conditions {
+					if condition[c.operator] == nil {
+						condition[c.operator] = make(map[string]interface{})
+					}
+					condition[c.operator][c.key] = stringOrList(resolved(c.index))
+				}
+			}
+
+			rendered.Statement[i] = policyStatementJSON{
+				Sid:          stmt.statement.Sid,
+				Effect:       stmt.statement.Effect,
+				Principal:    renderPrincipals(stmt.principals),
+				NotPrincipal: renderPrincipals(stmt.notPrincipals),
+				Action:       stringOrList(stmt.statement.Action),
+				NotAction:    stringOrList(stmt.statement.NotAction),
+				Resource:     stringOrList(resolved(stmt.resource)),
+				NotResource:  stringOrList(resolved(stmt.notResource)),
+				Condition:    condition,
+			}
+		}
+
+		jsonBytes, err := json.Marshal(rendered)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	}).(pulumi.StringOutput)
+}
+
+// policyDocumentJSON is the wire format of a PolicyDocument
+type policyDocumentJSON struct {
+	Version   string                `json:"Version"`
+	Statement []policyStatementJSON `json:"Statement"`
+}
+
+// policyStatementJSON is the wire format of a PolicyStatement
+type policyStatementJSON struct {
+	Sid          string                            `json:"Sid,omitempty"`
+	Effect       string                            `json:"Effect"`
+	Principal    interface{}                       `json:"Principal,omitempty"`
+	NotPrincipal interface{}                       `json:"NotPrincipal,omitempty"`
+	Action       interface{}                       `json:"Action,omitempty"`
+	NotAction    interface{}                       `json:"NotAction,omitempty"`
+	Resource     interface{}                       `json:"Resource,omitempty"`
+	NotResource  interface{}                       `json:"NotResource,omitempty"`
+	Condition    map[string]map[string]interface{} `json:"Condition,omitempty"`
+}
+
+// stringOrList renders a single value as a string and several as a list, matching
+// how AWS formats policy documents
+func stringOrList(values []string) interface{} {
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return values
+	}
+}
@@ -0,0 +1,157 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// awaitPolicyJSON resolves a policy output and unmarshals it into target
+func awaitPolicyJSON(t *testing.T, output pulumi.StringOutput, target interface{}) {
+	t.Helper()
+	result, err := internals.UnsafeAwaitOutput(context.Background(), output)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(result.Value.(string)), target))
+}
+
+func TestPolicyDocumentToJSON(t *testing.T) {
+	bucketArn := pulumi.String("arn:aws:s3:::pylon-blobs").ToStringOutput()
+
+	document := NewPolicyDocument(
+		PolicyStatement{
+			Sid:      "ReadBlobs",
+			Effect:   EffectAllow,
+			Action:   []string{"s3:GetObject", "s3:ListBucket"},
+			Resource: pulumi.StringArray{bucketArn, pulumi.Sprintf("%s/*", bucketArn)},
+			Condition: []PolicyCondition{
+				{Operator: ConditionBool, Key: "aws:SecureTransport", Values: pulumi.StringArray{pulumi.String("true")}},
+			},
+		},
+		PolicyStatement{
+			Effect:      EffectDeny,
+			NotAction:   []string{"s3:GetObject"},
+			NotResource: pulumi.StringArray{bucketArn},
+			Principal:   []PolicyPrincipal{{Type: PrincipalTypeAll}},
+		},
+	)
+	require.NoError(t, document.Validate())
+
+	var parsed map[string]interface{}
+	awaitPolicyJSON(t, document.ToJSON(), &parsed)
+
+	assert.Equal(t, IAMPolicyVersion, parsed["Version"])
+	statements := parsed["Statement"].([]interface{})
+	require.Len(t, statements, 2)
+
+	// Every statement is rendered, with Pulumi outputs resolved
+	first := statements[0].(map[string]interface{})
+	assert.Equal(t, "ReadBlobs", first["Sid"])
+	assert.Equal(t, []interface{}{"arn:aws:s3:::pylon-blobs", "arn:aws:s3:::pylon-blobs/*"}, first["Resource"])
+	assert.Equal(t, map[string]interface{}{
+		"Bool": map[string]interface{}{"aws:SecureTransport": "true"},
+	}, first["Condition"])
+	assert.NotContains(t, first, "Principal")
+
+	second := statements[1].(map[string]interface{})
+	assert.Equal(t, EffectDeny, second["Effect"])
+	assert.Equal(t, "*", second["Principal"])
+	assert.Equal(t, "s3:GetObject", second["NotAction"])
+	assert.Equal(t, "arn:aws:s3:::pylon-blobs", second["NotResource"])
+	assert.NotContains(t, second, "Action")
+	assert.NotContains(t, second, "Resource")
+}
+
+func TestPolicyDocumentPrincipalTypes(t *testing.T) {
+	document := NewPolicyDocument(PolicyStatement{
+		Effect: EffectAllow,
+		Action: []string{STSAssumeRoleAction},
+		Principal: []PolicyPrincipal{
+			{Type: PrincipalTypeAWS, Identifiers: pulumi.StringArray{pulumi.String("arn:aws:iam::123456789012:root")}},
+			{Type: PrincipalTypeService, Identifiers: pulumi.StringArray{pulumi.String(EKSPodsService), pulumi.String(EC2Service)}},
+			{Type: PrincipalTypeFederated, Identifiers: pulumi.StringArray{pulumi.String("cognito-identity.amazonaws.com")}},
+			{Type: PrincipalTypeCanonicalUser, Identifiers: pulumi.StringArray{pulumi.String("79a59df900b949e55d96a1e698fbaced")}},
+		},
+	})
+	require.NoError(t, document.Validate())
+
+	var parsed struct {
+		Statement []struct {
+			Principal map[string]interface{}
+		}
+	}
+	awaitPolicyJSON(t, document.ToJSON(), &parsed)
+
+	principal := parsed.Statement[0].Principal
+	assert.Equal(t, "arn:aws:iam::123456789012:root", principal["AWS"])
+	assert.Equal(t, []interface{}{EKSPodsService, EC2Service}, principal["Service"])
+	assert.Equal(t, "cognito-identity.amazonaws.com", principal["Federated"])
+	assert.Equal(t, "79a59df900b949e55d96a1e698fbaced", principal["CanonicalUser"])
+}
+
+func TestPolicyDocumentToJSONInvalid(t *testing.T) {
+	// Validation errors surface through the output
+	document := NewPolicyDocument(PolicyStatement{Effect: "Maybe", Action: []string{"s3:GetObject"}})
+	_, err := internals.UnsafeAwaitOutput(context.Background(), document.ToJSON())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "effect must be")
+}
+
+func TestCreateKMSPolicyFromPublicMultipleStatements(t *testing.T) {
+	policy, err := CreateKMSPolicyFromPublic(KMSPolicy{
+		Version: IAMPolicyVersion,
+		Statement: []KMSStatement{
+			{Effect: EffectAllow, Action: []string{KMSSignAction}, Resource: "arn:aws:kms:us-west-2:123456789012:key/one"},
+			{Effect: EffectAllow, Action: []string{KMSGetPublicKeyAction}, Resource: "arn:aws:kms:us-west-2:123456789012:key/two"},
+		},
+	})
+	require.NoError(t, err)
+
+	var parsed struct {
+		Statement []struct {
+			Action   string
+			Resource string
+		}
+	}
+	awaitPolicyJSON(t, policy, &parsed)
+
+	require.Len(t, parsed.Statement, 2)
+	assert.Equal(t, "arn:aws:kms:us-west-2:123456789012:key/one", parsed.Statement[0].Resource)
+	assert.Equal(t, KMSGetPublicKeyAction, parsed.Statement[1].Action)
+	assert.Equal(t, "arn:aws:kms:us-west-2:123456789012:key/two", parsed.Statement[1].Resource)
+}
+
+func TestCreateEcrDeployPolicyRegion(t *testing.T) {
+	var parsed struct {
+		Statement []struct {
+			Resource string
+		}
+	}
+	awaitPolicyJSON(t, CreateEcrDeployPolicy("eu-west-1", "123456789012", "builder"), &parsed)
+
+	require.Len(t, parsed.Statement, 2)
+	assert.Equal(t, "arn:aws:ecr:eu-west-1:123456789012:repository/builder", parsed.Statement[0].Resource)
+	assert.Equal(t, "*", parsed.Statement[1].Resource)
+}
+
+func TestCreateGithubAssumeRolePolicy(t *testing.T) {
+	var parsed struct {
+		Statement []struct {
+			Principal map[string]string
+			Action    string
+			Condition map[string]map[string]string
+		}
+	}
+	awaitPolicyJSON(t, CreateGithubAssumeRolePolicy("123456789012", "init4tech", "signet"), &parsed)
+
+	require.Len(t, parsed.Statement, 1)
+	stmt := parsed.Statement[0]
+	assert.Equal(t, "arn:aws:iam::123456789012:oidc-provider/token.actions.githubusercontent.com", stmt.Principal["Federated"])
+	assert.Equal(t, STSAssumeRoleWithWebIdentityAction, stmt.Action)
+	assert.Equal(t, "sts.amazonaws.com", stmt.Condition["StringEquals"]["token.actions.githubusercontent.com:aud"])
+	assert.Equal(t, "repo:init4tech/signet:*", stmt.Condition["StringLike"]["token.actions.githubusercontent.com:sub"])
+}
@@ -1,10 +1,26 @@
 package aws
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/rds"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/secretsmanager"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi-random/sdk/v4/go/random"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
+// NewPostgresDbComponent creates an Aurora PostgreSQL Serverless v2 cluster with a writer
+// instance and any configured readers. Resource names are derived from the database name
+// so several databases can live in the same stack.
 func NewPostgresDbComponent(ctx *pulumi.Context, args *PostgresDbArgs, opts ...pulumi.ResourceOption) (*PostgresDbComponent, error) {
 	if err := validateDb(*args); err != nil {
 		return nil, err
@@ -18,43 +34,240 @@ func NewPostgresDbComponent(ctx *pulumi.Context, args *PostgresDbArgs, opts ...p
 		return nil, err
 	}
 
-	// Create a VPC subnet group for the database
-	subnetGroup, err := rds.GetSubnetGroup(ctx, "dbClusterSubnetGroup", pulumi.ID(args.DbSubnetGroupName), nil)
+	// Create a subnet group from the given subnets, or look up an existing one
+	subnetGroupName := fmt.Sprintf("%s%s", args.DbName, DbSubnetGroupSuffix)
+	var subnetGroup *rds.SubnetGroup
+	if len(args.SubnetIds) > 0 {
+		subnetGroup, err = rds.NewSubnetGroup(ctx, subnetGroupName, &rds.SubnetGroupArgs{
+			SubnetIds: internalArgs.SubnetIds,
+		}, pulumi.Parent(component))
+	} else {
+		subnetGroup, err = rds.GetSubnetGroup(ctx, subnetGroupName, pulumi.ID(args.DbSubnetGroupName), nil, pulumi.Parent(component))
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get subnet group: %w", err)
+	}
+	component.DbSubnetGroup = subnetGroup
+
+	clusterParameterGroupName := internalArgs.ClusterParameterGroupName
+	if len(args.ClusterParameters) > 0 {
+		parameterGroup, err := rds.NewClusterParameterGroup(ctx, fmt.Sprintf("%s%s", args.DbName, DbClusterParameterGroupSuffix), &rds.ClusterParameterGroupArgs{
+			Family:     internalArgs.EngineFamily,
+			Parameters: createClusterParameters(internalArgs.ClusterParameters),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cluster parameter group: %w", err)
+		}
+		component.DbClusterParameterGroup = parameterGroup
+		clusterParameterGroupName = parameterGroup.Name
+	}
+
+	// Generate a master password when none is given and RDS is not managing it. The password is
+	// kept in the stack state as a Pulumi secret, so it stays stable across deployments, and is
+	// stored in Secrets Manager for other consumers.
+	masterPassword := internalArgs.DbPassword
+	if args.DbPassword == "" && !args.ManageMasterUserPassword {
+		password, err := random.NewRandomPassword(ctx, fmt.Sprintf("%s%s", args.DbName, DbPasswordSecretSuffix), &random.RandomPasswordArgs{
+			Length:  pulumi.Int(GeneratedPasswordLength),
+			Special: pulumi.Bool(false),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate master password: %w", err)
+		}
+
+		passwordSecret, err := secretsmanager.NewSecret(ctx, fmt.Sprintf("%s%s", args.DbName, DbPasswordSecretSuffix), &secretsmanager.SecretArgs{
+			Description: pulumi.Sprintf("Master password for the %s database", args.DbName),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create master password secret: %w", err)
+		}
+
+		_, err = secretsmanager.NewSecretVersion(ctx, fmt.Sprintf("%s%s", args.DbName, DbPasswordSecretSuffix), &secretsmanager.SecretVersionArgs{
+			SecretId:     passwordSecret.ID(),
+			SecretString: password.Result,
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to store master password: %w", err)
+		}
+
+		component.DbPasswordSecret = passwordSecret
+		component.MasterUserSecretArn = passwordSecret.Arn
+		masterPassword = password.Result
 	}
 
-	dbCluster, err := rds.NewCluster(ctx, "dbCluster", &rds.ClusterArgs{
-		Engine:         pulumi.String(rds.EngineTypeAuroraPostgresql),
-		EngineVersion:  pulumi.String("16.6"),
-		DatabaseName:   internalArgs.DbName,
-		MasterUsername: internalArgs.DbUsername,
-		MasterPassword: internalArgs.DbPassword,
+	// Aliases keep clusters created before names were derived from the database name
+	dbCluster, err := rds.NewCluster(ctx, fmt.Sprintf("%s%s", args.DbName, DbClusterSuffix), &rds.ClusterArgs{
+		Engine:                   pulumi.String(rds.EngineTypeAuroraPostgresql),
+		EngineVersion:            internalArgs.EngineVersion,
+		DatabaseName:             internalArgs.DbName,
+		MasterUsername:           internalArgs.DbUsername,
+		MasterPassword:           masterPassword,
+		ManageMasterUserPassword: internalArgs.ManageMasterUserPassword,
+		MasterUserSecretKmsKeyId: internalArgs.MasterUserSecretKmsKeyId,
 		Serverlessv2ScalingConfiguration: &rds.ClusterServerlessv2ScalingConfigurationArgs{
-			MaxCapacity: pulumi.Float64(2),
-			MinCapacity: pulumi.Float64(1),
+			MaxCapacity: internalArgs.MaxCapacity,
+			MinCapacity: internalArgs.MinCapacity,
 		},
-		DbSubnetGroupName: subnetGroup.Name,
-		SkipFinalSnapshot: pulumi.Bool(true),
-	}, pulumi.Parent(component))
+		DbSubnetGroupName:           subnetGroup.Name,
+		BackupRetentionPeriod:       internalArgs.BackupRetentionPeriod,
+		SkipFinalSnapshot:           internalArgs.SkipFinalSnapshot,
+		FinalSnapshotIdentifier:     internalArgs.FinalSnapshotIdentifier,
+		DeletionProtection:          internalArgs.DeletionProtection,
+		VpcSecurityGroupIds:         internalArgs.VpcSecurityGroupIds,
+		DbClusterParameterGroupName: clusterParameterGroupName,
+		StorageEncrypted:            internalArgs.StorageEncrypted,
+		KmsKeyId:                    internalArgs.KmsKeyId,
+	}, pulumi.Parent(component), pulumi.Aliases([]pulumi.Alias{{Name: pulumi.String("dbCluster")}}))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create db cluster: %w", err)
 	}
 
-	dbClusterInstance, err := rds.NewClusterInstance(ctx, "dbClusterInstance", &rds.ClusterInstanceArgs{
-		ClusterIdentifier: dbCluster.ID(),
-		InstanceClass:     pulumi.String("db.serverless"),
-		Engine:            dbCluster.Engine,
-		EngineVersion:     dbCluster.EngineVersion,
-		DbSubnetGroupName: subnetGroup.Name,
-	}, pulumi.Parent(component))
-	if err != nil {
-		return nil, err
+	// The first instance is the writer, the rest are readers
+	for i := 0; i <= internalArgs.ReaderCount; i++ {
+		instanceOpts := []pulumi.ResourceOption{pulumi.Parent(component)}
+		if i == 0 {
+			instanceOpts = append(instanceOpts, pulumi.Aliases([]pulumi.Alias{{Name: pulumi.String("dbClusterInstance")}}))
+		}
+
+		instance, err := rds.NewClusterInstance(ctx, fmt.Sprintf("%s%s-%d", args.DbName, DbClusterInstanceSuffix, i), &rds.ClusterInstanceArgs{
+			ClusterIdentifier:    dbCluster.ID(),
+			InstanceClass:        pulumi.String(PostgresServerlessInstanceClass),
+			Engine:               dbCluster.Engine,
+			EngineVersion:        dbCluster.EngineVersion,
+			DbSubnetGroupName:    subnetGroup.Name,
+			DbParameterGroupName: internalArgs.DbParameterGroupName,
+		}, instanceOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create db cluster instance: %w", err)
+		}
+		component.DbClusterInstances = append(component.DbClusterInstances, instance)
 	}
 
 	component.DbCluster = dbCluster
-	component.DbClusterInstance = dbClusterInstance
+	component.DbClusterInstance = component.DbClusterInstances[0]
 	component.DbClusterEndpoint = dbCluster.Endpoint
+	component.DbClusterReaderEndpoint = dbCluster.ReaderEndpoint
+
+	// With a managed password, read the current password back from the RDS-owned secret.
+	// ConnectionUrl only reflects it as of this deployment; the connection Secret is synced below
+	if args.ManageMasterUserPassword {
+		secretArn := dbCluster.MasterUserSecrets.Index(pulumi.Int(0)).SecretArn().Elem()
+		secretVersion := secretsmanager.LookupSecretVersionOutput(ctx, secretsmanager.LookupSecretVersionOutputArgs{
+			SecretId: secretArn,
+		}, pulumi.Parent(component))
+		masterPassword = pulumi.ToSecret(secretVersion.SecretString().ApplyT(parseMasterUserSecret)).(pulumi.StringOutput)
+		component.MasterUserSecretArn = secretArn
+	}
+
+	component.ConnectionUrl = pulumi.ToSecret(pulumi.All(internalArgs.DbUsername, masterPassword, dbCluster.Endpoint, dbCluster.Port, internalArgs.DbName).ApplyT(
+		func(values []interface{}) string {
+			return postgresConnectionUrl(values[0].(string), values[1].(string), values[2].(string), values[3].(int), values[4].(string))
+		},
+	)).(pulumi.StringOutput)
+
+	secretName := fmt.Sprintf("%s%s", args.DbName, DbConnectionSecretSuffix)
+	if args.ConnectionSecretNamespace != "" && args.ManageMasterUserPassword {
+		// RDS rotates the managed password, so sync the Secret from the RDS-owned secret
+		// instead of copying the current password into it
+		connectionExternalSecret, err := utils.CreateTemplatedExternalSecret(
+			ctx,
+			kubernetesName(secretName),
+			internalArgs.ConnectionSecretNamespace,
+			nil,
+			args.ConnectionSecretStoreName,
+			args.ConnectionSecretStoreKind,
+			component.MasterUserSecretArn,
+			pulumi.StringMap{
+				DbConnectionUrlKey:      pulumi.All(dbCluster.Endpoint, dbCluster.Port, internalArgs.DbName).ApplyT(managedConnectionUrlTemplate).(pulumi.StringOutput),
+				DbConnectionHostKey:     dbCluster.Endpoint,
+				DbConnectionPortKey:     dbCluster.Port.ApplyT(strconv.Itoa).(pulumi.StringOutput),
+				DbConnectionUsernameKey: pulumi.String(DbManagedUsernameTemplate),
+				DbConnectionPasswordKey: pulumi.String(DbManagedPasswordTemplate),
+				DbConnectionDatabaseKey: internalArgs.DbName,
+			},
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create connection external secret: %w", err)
+		}
+		component.ConnectionExternalSecret = connectionExternalSecret
+	} else if args.ConnectionSecretNamespace != "" {
+		connectionSecret, err := corev1.NewSecret(ctx, secretName, &corev1.SecretArgs{
+			Metadata: &metav1.ObjectMetaArgs{
+				Name:      pulumi.String(kubernetesName(secretName)),
+				Namespace: internalArgs.ConnectionSecretNamespace,
+			},
+			StringData: pulumi.StringMap{
+				DbConnectionUrlKey:      component.ConnectionUrl,
+				DbConnectionHostKey:     dbCluster.Endpoint,
+				DbConnectionPortKey:     dbCluster.Port.ApplyT(strconv.Itoa).(pulumi.StringOutput),
+				DbConnectionUsernameKey: internalArgs.DbUsername,
+				DbConnectionPasswordKey: masterPassword,
+				DbConnectionDatabaseKey: internalArgs.DbName,
+			},
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create connection secret: %w", err)
+		}
+		component.ConnectionSecret = connectionSecret
+	}
 
 	return component, nil
 }
+
+// createClusterParameters converts a parameter map to cluster parameter group parameters,
+// sorted by name so the resource diff is stable
+func createClusterParameters(parameters map[string]string) rds.ClusterParameterGroupParameterArray {
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make(rds.ClusterParameterGroupParameterArray, 0, len(names))
+	for _, name := range names {
+		result = append(result, rds.ClusterParameterGroupParameterArgs{
+			Name:  pulumi.String(name),
+			Value: pulumi.String(parameters[name]),
+		})
+	}
+	return result
+}
+
+// parseMasterUserSecret extracts the password from an RDS managed master user secret,
+// which is stored as {"username": ..., "password": ...}
+func parseMasterUserSecret(secretString string) (string, error) {
+	var secret struct {
+		Password string `json:"password"`
+	}
+	if err := json.Unmarshal([]byte(secretString), &secret); err != nil {
+		return "", fmt.Errorf("failed to parse master user secret: %w", err)
+	}
+	if secret.Password == "" {
+		return "", fmt.Errorf("master user secret has no password")
+	}
+	return secret.Password, nil
+}
+
+// postgresConnectionUrl builds a postgres:// connection URL, escaping the credentials
+func postgresConnectionUrl(username, password, host string, port int, dbName string) string {
+	connectionUrl := url.URL{
+		Scheme: PostgresUrlScheme,
+		User:   url.UserPassword(username, password),
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:   "/" + dbName,
+	}
+	return connectionUrl.String()
+}
+
+// managedConnectionUrlTemplate builds the External Secrets Operator template of the connection URL
+// for a managed password, taking the endpoint, port and database name
+func managedConnectionUrlTemplate(values []interface{}) string {
+	hostPort := net.JoinHostPort(values[0].(string), strconv.Itoa(values[1].(int)))
+	return fmt.Sprintf(DbManagedUrlTemplateFormat, hostPort, values[2].(string))
+}
+
+// kubernetesName converts a name to a valid Kubernetes resource name
+func kubernetesName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
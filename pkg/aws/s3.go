@@ -0,0 +1,381 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// NewS3BucketComponent creates an S3 bucket encrypted with SSE-KMS, with all public access
+// blocked and a bucket policy that denies requests made without TLS. Versioning, lifecycle
+// transitions and cross-region replication are enabled by the args.
+//
+// Workloads are granted access to the bucket with GrantAccess.
+func NewS3BucketComponent(ctx *pulumi.Context, args *S3BucketArgs, opts ...pulumi.ResourceOption) (*S3BucketComponent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid s3 bucket args: %w", err)
+	}
+
+	internalArgs := args.toInternal()
+	component := &S3BucketComponent{KmsKeyArn: args.KmsKeyArn}
+
+	err := ctx.RegisterComponentResource("signet:index:S3Bucket", args.Name, component, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketOpts := []pulumi.ResourceOption{pulumi.Parent(component)}
+	if args.LegacyResourceName != "" {
+		bucketOpts = append(bucketOpts, pulumi.Aliases([]pulumi.Alias{{
+			Name:     pulumi.String(args.LegacyResourceName),
+			NoParent: pulumi.Bool(true),
+		}}))
+	}
+	bucket, err := s3.NewBucketV2(ctx, args.Name, &s3.BucketV2Args{
+		Bucket: internalArgs.BucketName,
+	}, bucketOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bucket: %w", err)
+	}
+	component.Bucket = bucket
+
+	encryption, err := s3.NewBucketServerSideEncryptionConfigurationV2(ctx, fmt.Sprintf("%s%s", args.Name, S3EncryptionSuffix), &s3.BucketServerSideEncryptionConfigurationV2Args{
+		Bucket: bucket.ID(),
+		Rules: s3.BucketServerSideEncryptionConfigurationV2RuleArray{
+			s3.BucketServerSideEncryptionConfigurationV2RuleArgs{
+				ApplyServerSideEncryptionByDefault: &s3.BucketServerSideEncryptionConfigurationV2RuleApplyServerSideEncryptionByDefaultArgs{
+					SseAlgorithm:   pulumi.String(S3SseAlgorithmKMS),
+					KmsMasterKeyId: internalArgs.KmsKeyArn,
+				},
+				// Bucket keys cut KMS request costs for write-heavy buckets
+				BucketKeyEnabled: pulumi.Bool(true),
+			},
+		},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure bucket encryption: %w", err)
+	}
+	component.Encryption = encryption
+
+	publicAccessBlock, err := s3.NewBucketPublicAccessBlock(ctx, fmt.Sprintf("%s%s", args.Name, S3PublicAccessBlockSuffix), &s3.BucketPublicAccessBlockArgs{
+		Bucket:                bucket.ID(),
+		BlockPublicAcls:       pulumi.Bool(true),
+		BlockPublicPolicy:     pulumi.Bool(true),
+		IgnorePublicAcls:      pulumi.Bool(true),
+		RestrictPublicBuckets: pulumi.Bool(true),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to block public access: %w", err)
+	}
+	component.PublicAccessBlock = publicAccessBlock
+
+	bucketPolicy, err := s3.NewBucketPolicy(ctx, fmt.Sprintf("%s%s", args.Name, S3BucketPolicySuffix), &s3.BucketPolicyArgs{
+		Bucket: bucket.ID(),
+		Policy: createS3SecureTransportPolicy(bucket.Arn).ToJSON(),
+	}, pulumi.Parent(component), pulumi.DependsOn([]pulumi.Resource{publicAccessBlock}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bucket policy: %w", err)
+	}
+	component.BucketPolicy = bucketPolicy
+
+	if internalArgs.Versioning {
+		versioning, err := s3.NewBucketVersioningV2(ctx, fmt.Sprintf("%s%s", args.Name, S3VersioningSuffix), &s3.BucketVersioningV2Args{
+			Bucket: bucket.ID(),
+			VersioningConfiguration: &s3.BucketVersioningV2VersioningConfigurationArgs{
+				Status: pulumi.String(S3VersioningEnabled),
+			},
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to enable bucket versioning: %w", err)
+		}
+		component.Versioning = versioning
+	}
+
+	if internalArgs.Lifecycle != nil {
+		lifecycle, err := s3.NewBucketLifecycleConfigurationV2(ctx, fmt.Sprintf("%s%s", args.Name, S3LifecycleSuffix), &s3.BucketLifecycleConfigurationV2Args{
+			Bucket: bucket.ID(),
+			Rules: s3.BucketLifecycleConfigurationV2RuleArray{
+				createS3LifecycleRule(*internalArgs.Lifecycle),
+			},
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure bucket lifecycle: %w", err)
+		}
+		component.Lifecycle = lifecycle
+	}
+
+	if internalArgs.Replication != nil {
+		role, err := createS3ReplicationRole(ctx, args, bucket.Arn, component)
+		if err != nil {
+			return nil, err
+		}
+		component.ReplicationRole = role
+
+		replication, err := s3.NewBucketReplicationConfig(ctx, fmt.Sprintf("%s%s", args.Name, S3ReplicationSuffix), &s3.BucketReplicationConfigArgs{
+			Bucket: bucket.ID(),
+			Role:   role.Arn,
+			Rules: s3.BucketReplicationConfigRuleArray{
+				s3.BucketReplicationConfigRuleArgs{
+					Id:     pulumi.String(S3ReplicationRuleId),
+					Status: pulumi.String(S3RuleEnabled),
+					Filter: &s3.BucketReplicationConfigRuleFilterArgs{},
+					DeleteMarkerReplication: &s3.BucketReplicationConfigRuleDeleteMarkerReplicationArgs{
+						Status: pulumi.String(S3RuleEnabled),
+					},
+					SourceSelectionCriteria: &s3.BucketReplicationConfigRuleSourceSelectionCriteriaArgs{
+						SseKmsEncryptedObjects: &s3.BucketReplicationConfigRuleSourceSelectionCriteriaSseKmsEncryptedObjectsArgs{
+							Status: pulumi.String(S3RuleEnabled),
+						},
+					},
+					Destination: &s3.BucketReplicationConfigRuleDestinationArgs{
+						Bucket:       internalArgs.Replication.DestinationBucketArn,
+						StorageClass: internalArgs.Replication.StorageClass,
+						EncryptionConfiguration: &s3.BucketReplicationConfigRuleDestinationEncryptionConfigurationArgs{
+							ReplicaKmsKeyId: internalArgs.Replication.DestinationKmsKeyArn,
+						},
+					},
+				},
+			},
+		}, pulumi.Parent(component), pulumi.DependsOn([]pulumi.Resource{component.Versioning}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure bucket replication: %w", err)
+		}
+		component.Replication = replication
+	}
+
+	component.BucketName = bucket.Bucket
+	component.BucketArn = bucket.Arn
+	component.Region = bucket.Region
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"bucketName": bucket.Bucket,
+		"bucketArn":  bucket.Arn,
+		"region":     bucket.Region,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+// CreateAccessPolicy creates an IAM policy document allowing objects in the bucket to be
+// listed, read and written. Deletes are not granted.
+func (c *S3BucketComponent) CreateAccessPolicy() pulumi.StringOutput {
+	return createS3AccessPolicy(c.BucketArn, c.KmsKeyArn).ToJSON()
+}
+
+// GrantAccess attaches the bucket access policy to an existing role, such as a pod
+// identity role. The returned IAMResources reference the given role.
+func (c *S3BucketComponent) GrantAccess(ctx *pulumi.Context, name string, role *iam.Role, parent pulumi.Resource) (*IAMResources, error) {
+	policy, err := iam.NewPolicy(ctx, fmt.Sprintf("%s%s", name, S3AccessPolicySuffix), &iam.PolicyArgs{
+		Policy: c.CreateAccessPolicy(),
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 access policy: %w", err)
+	}
+
+	attachment, err := iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s%s", name, S3AccessAttachmentSuffix), &iam.RolePolicyAttachmentArgs{
+		Role:      role.Name,
+		PolicyArn: policy.Arn,
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach S3 access policy to role: %w", err)
+	}
+
+	return &IAMResources{
+		Role:             role,
+		Policy:           policy,
+		PolicyAttachment: attachment,
+	}, nil
+}
+
+// GrantS3ObjectRead attaches a policy to an existing role allowing it to read a single
+// object, such as a chain data archive in a bucket this stack does not manage. Buckets in
+// other accounts must also allow the role in their bucket policy.
+func GrantS3ObjectRead(ctx *pulumi.Context, name string, role *iam.Role, bucket string, key string, parent pulumi.Resource) (*IAMResources, error) {
+	policy, err := iam.NewPolicy(ctx, fmt.Sprintf("%s%s", name, S3ReadPolicySuffix), &iam.PolicyArgs{
+		Policy: createS3ObjectReadPolicy(bucket, key).ToJSON(),
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 read policy: %w", err)
+	}
+
+	attachment, err := iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s%s", name, S3ReadAttachmentSuffix), &iam.RolePolicyAttachmentArgs{
+		Role:      role.Name,
+		PolicyArn: policy.Arn,
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach S3 read policy to role: %w", err)
+	}
+
+	return &IAMResources{
+		Role:             role,
+		Policy:           policy,
+		PolicyAttachment: attachment,
+	}, nil
+}
+
+// createS3LifecycleRule builds a lifecycle rule applying to every object in the bucket
+func createS3LifecycleRule(lifecycle s3LifecycleArgsInternal) s3.BucketLifecycleConfigurationV2RuleArgs {
+	rule := s3.BucketLifecycleConfigurationV2RuleArgs{
+		Id:     pulumi.String(S3LifecycleRuleId),
+		Status: pulumi.String(S3RuleEnabled),
+		Filter: &s3.BucketLifecycleConfigurationV2RuleFilterArgs{},
+	}
+
+	var transitions s3.BucketLifecycleConfigurationV2RuleTransitionArray
+	if lifecycle.InfrequentAccessAfterDays != nil {
+		transitions = append(transitions, s3.BucketLifecycleConfigurationV2RuleTransitionArgs{
+			Days:         lifecycle.InfrequentAccessAfterDays,
+			StorageClass: pulumi.String(S3StorageClassStandardIA),
+		})
+	}
+	if lifecycle.GlacierAfterDays != nil {
+		transitions = append(transitions, s3.BucketLifecycleConfigurationV2RuleTransitionArgs{
+			Days:         lifecycle.GlacierAfterDays,
+			StorageClass: pulumi.String(S3StorageClassGlacier),
+		})
+	}
+	if len(transitions) > 0 {
+		rule.Transitions = transitions
+	}
+
+	if lifecycle.ExpireAfterDays != nil {
+		rule.Expiration = &s3.BucketLifecycleConfigurationV2RuleExpirationArgs{
+			Days: lifecycle.ExpireAfterDays,
+		}
+	}
+	if lifecycle.NoncurrentVersionExpirationDays != nil {
+		rule.NoncurrentVersionExpiration = &s3.BucketLifecycleConfigurationV2RuleNoncurrentVersionExpirationArgs{
+			NoncurrentDays: lifecycle.NoncurrentVersionExpirationDays.ToIntPtrOutput().Elem(),
+		}
+	}
+	return rule
+}
+
+// createS3ReplicationRole creates the role S3 assumes to replicate objects to the destination bucket
+func createS3ReplicationRole(ctx *pulumi.Context, args *S3BucketArgs, bucketArn pulumi.StringInput, parent pulumi.Resource) (*iam.Role, error) {
+	assumeRolePolicy := NewPolicyDocument(PolicyStatement{
+		Effect: EffectAllow,
+		Principal: []PolicyPrincipal{
+			{Type: PrincipalTypeService, Identifiers: pulumi.StringArray{pulumi.String(S3Service)}},
+		},
+		Action: []string{STSAssumeRoleAction},
+	})
+
+	roleName := fmt.Sprintf("%s%s", args.Name, S3ReplicationRoleSuffix)
+	role, err := iam.NewRole(ctx, roleName, &iam.RoleArgs{
+		AssumeRolePolicy: assumeRolePolicy.ToJSON(),
+		Description:      pulumi.String(fmt.Sprintf("Role for S3 to replicate %s", args.BucketName)),
+		Tags: pulumi.StringMap{
+			"Name": pulumi.String(roleName),
+		},
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replication role: %w", err)
+	}
+
+	_, err = iam.NewRolePolicy(ctx, fmt.Sprintf("%s%s", args.Name, S3ReplicationPolicySuffix), &iam.RolePolicyArgs{
+		Role:   role.ID(),
+		Policy: createS3ReplicationPolicy(bucketArn, args.KmsKeyArn, *args.Replication).ToJSON(),
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replication policy: %w", err)
+	}
+
+	return role, nil
+}
+
+// createS3AccessPolicy builds a least-privilege read/write policy for a bucket and its key
+func createS3AccessPolicy(bucketArn pulumi.StringInput, kmsKeyArn string) PolicyDocument {
+	policy := NewPolicyDocument(
+		PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{S3ListBucketAction},
+			Resource: pulumi.StringArray{bucketArn},
+		},
+		PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{S3GetObjectAction, S3PutObjectAction},
+			Resource: pulumi.StringArray{pulumi.Sprintf("%s/*", bucketArn)},
+		},
+	)
+	// The AWS managed aws/s3 key already allows use through S3, customer managed keys do not
+	if kmsKeyArn != "" {
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{KMSDecryptAction, KMSGenerateDataKeyAction},
+			Resource: pulumi.StringArray{pulumi.String(kmsKeyArn)},
+		})
+	}
+	return policy
+}
+
+// createS3ObjectReadPolicy builds a policy allowing a single object to be read
+func createS3ObjectReadPolicy(bucket string, key string) PolicyDocument {
+	return NewPolicyDocument(PolicyStatement{
+		Effect:   EffectAllow,
+		Action:   []string{S3GetObjectAction},
+		Resource: pulumi.StringArray{pulumi.Sprintf(S3ObjectArnFormat, bucket, key)},
+	})
+}
+
+// createS3SecureTransportPolicy builds a bucket policy denying every request made without TLS
+func createS3SecureTransportPolicy(bucketArn pulumi.StringInput) PolicyDocument {
+	return NewPolicyDocument(PolicyStatement{
+		Sid:       S3DenyInsecureTransportId,
+		Effect:    EffectDeny,
+		Principal: []PolicyPrincipal{{Type: PrincipalTypeAll}},
+		Action:    []string{S3AllActions},
+		Resource:  pulumi.StringArray{bucketArn, pulumi.Sprintf("%s/*", bucketArn)},
+		Condition: []PolicyCondition{
+			{Operator: ConditionBool, Key: S3SecureTransportKey, Values: pulumi.StringArray{pulumi.String("false")}},
+		},
+	})
+}
+
+// createS3ReplicationPolicy builds the permissions S3 needs to replicate a bucket
+func createS3ReplicationPolicy(bucketArn pulumi.StringInput, kmsKeyArn string, replication S3ReplicationArgs) PolicyDocument {
+	// Without a customer managed key, objects are encrypted with the AWS managed aws/s3 key
+	sourceKey := pulumi.StringArray{pulumi.String("*")}
+	if kmsKeyArn != "" {
+		sourceKey = pulumi.StringArray{pulumi.String(kmsKeyArn)}
+	}
+
+	return NewPolicyDocument(
+		PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{S3GetReplicationConfigurationAction, S3ListBucketAction},
+			Resource: pulumi.StringArray{bucketArn},
+		},
+		PolicyStatement{
+			Effect: EffectAllow,
+			Action: []string{
+				S3GetObjectVersionForReplicationAction,
+				S3GetObjectVersionAclAction,
+				S3GetObjectVersionTaggingAction,
+			},
+			Resource: pulumi.StringArray{pulumi.Sprintf("%s/*", bucketArn)},
+		},
+		PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{S3ReplicateObjectAction, S3ReplicateDeleteAction, S3ReplicateTagsAction},
+			Resource: pulumi.StringArray{pulumi.String(fmt.Sprintf("%s/*", replication.DestinationBucketArn))},
+		},
+		PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{KMSDecryptAction},
+			Resource: sourceKey,
+			Condition: []PolicyCondition{
+				{Operator: ConditionStringLike, Key: KMSViaServiceKey, Values: pulumi.StringArray{pulumi.String(S3ViaServicePattern)}},
+			},
+		},
+		PolicyStatement{
+			Effect:   EffectAllow,
+			Action:   []string{KMSEncryptAction},
+			Resource: pulumi.StringArray{pulumi.String(replication.DestinationKmsKeyArn)},
+		},
+	)
+}
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBucketArn = "arn:aws:s3:::pylon-blobs"
+
+func TestCreateS3AccessPolicy(t *testing.T) {
+	var policy map[string]interface{}
+	awaitPolicyJSON(t, createS3AccessPolicy(pulumi.String(testBucketArn), "").ToJSON(), &policy)
+
+	statements := policy["Statement"].([]interface{})
+	require.Len(t, statements, 2)
+	list := statements[0].(map[string]interface{})
+	assert.Equal(t, S3ListBucketAction, list["Action"])
+	assert.Equal(t, testBucketArn, list["Resource"])
+	objects := statements[1].(map[string]interface{})
+	assert.Equal(t, []interface{}{S3GetObjectAction, S3PutObjectAction}, objects["Action"])
+	assert.Equal(t, testBucketArn+"/*", objects["Resource"])
+
+	// Customer managed keys must be granted explicitly
+	keyArn := "arn:aws:kms:us-east-1:123456789012:key/abcd"
+	awaitPolicyJSON(t, createS3AccessPolicy(pulumi.String(testBucketArn), keyArn).ToJSON(), &policy)
+	statements = policy["Statement"].([]interface{})
+	require.Len(t, statements, 3)
+	key := statements[2].(map[string]interface{})
+	assert.Equal(t, []interface{}{KMSDecryptAction, KMSGenerateDataKeyAction}, key["Action"])
+	assert.Equal(t, keyArn, key["Resource"])
+}
+
+func TestCreateS3ObjectReadPolicy(t *testing.T) {
+	var policy map[string]interface{}
+	awaitPolicyJSON(t, createS3ObjectReadPolicy("signet-archives", "mainnet/data.tar.zst").ToJSON(), &policy)
+
+	statements := policy["Statement"].([]interface{})
+	require.Len(t, statements, 1)
+	read := statements[0].(map[string]interface{})
+	assert.Equal(t, S3GetObjectAction, read["Action"])
+	assert.Equal(t, "arn:aws:s3:::signet-archives/mainnet/data.tar.zst", read["Resource"])
+}
+
+func TestCreateS3SecureTransportPolicy(t *testing.T) {
+	var policy map[string]interface{}
+	awaitPolicyJSON(t, createS3SecureTransportPolicy(pulumi.String(testBucketArn)).ToJSON(), &policy)
+
+	statements := policy["Statement"].([]interface{})
+	require.Len(t, statements, 1)
+	deny := statements[0].(map[string]interface{})
+	assert.Equal(t, EffectDeny, deny["Effect"])
+	assert.Equal(t, "*", deny["Principal"])
+	assert.Equal(t, []interface{}{testBucketArn, testBucketArn + "/*"}, deny["Resource"])
+	assert.Equal(t, map[string]interface{}{
+		ConditionBool: map[string]interface{}{S3SecureTransportKey: "false"},
+	}, deny["Condition"])
+}
+
+func TestCreateS3ReplicationPolicy(t *testing.T) {
+	replication := S3ReplicationArgs{
+		DestinationBucketArn: "arn:aws:s3:::pylon-blobs-replica",
+		DestinationKmsKeyArn: "arn:aws:kms:us-west-2:123456789012:key/replica",
+	}
+
+	var policy map[string]interface{}
+	awaitPolicyJSON(t, createS3ReplicationPolicy(pulumi.String(testBucketArn), "", replication).ToJSON(), &policy)
+
+	statements := policy["Statement"].([]interface{})
+	require.Len(t, statements, 5)
+	replicate := statements[2].(map[string]interface{})
+	assert.Equal(t, "arn:aws:s3:::pylon-blobs-replica/*", replicate["Resource"])
+	decrypt := statements[3].(map[string]interface{})
+	assert.Equal(t, "*", decrypt["Resource"])
+	encrypt := statements[4].(map[string]interface{})
+	assert.Equal(t, replication.DestinationKmsKeyArn, encrypt["Resource"])
+}
+
+func TestCreateS3LifecycleRule(t *testing.T) {
+	lifecycle := S3LifecycleArgs{
+		InfrequentAccessAfterDays: 30,
+		GlacierAfterDays:          90,
+	}
+	rule := createS3LifecycleRule(lifecycle.toInternal())
+
+	assert.Equal(t, pulumi.String(S3LifecycleRuleId), rule.Id)
+	assert.Nil(t, rule.Expiration)
+	assert.Nil(t, rule.NoncurrentVersionExpiration)
+	require.NotNil(t, rule.Transitions)
+}
@@ -0,0 +1,171 @@
+package aws
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/kms"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"golang.org/x/crypto/sha3"
+)
+
+// NewSigningKeyComponent creates a secp256k1 KMS key for Ethereum signing, along with an alias
+// and a key policy that delegates administration to the account root. The component exports
+// the key ID and ARN, and the Ethereum address derived from the key's public key.
+//
+// The key ID can be used as QUINCEY_KEY_ID or BUILDER_KEY, and the component can be passed to
+// CreatePodIdentityResources to grant a workload permission to sign with it.
+func NewSigningKeyComponent(ctx *pulumi.Context, args *SigningKeyArgs, opts ...pulumi.ResourceOption) (*SigningKeyComponent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid signing key args: %w", err)
+	}
+
+	internalArgs := args.toInternal()
+	component := &SigningKeyComponent{}
+
+	err := ctx.RegisterComponentResource("signet:index:SigningKey", args.Name, component, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPolicy := createSigningKeyPolicy(internalArgs.AccountId, internalArgs.SignerArns)
+	if err := keyPolicy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid key policy: %w", err)
+	}
+
+	key, err := kms.NewKey(ctx, fmt.Sprintf("%s%s", args.Name, SigningKeySuffix), &kms.KeyArgs{
+		Description:           internalArgs.Description,
+		CustomerMasterKeySpec: pulumi.String(KMSKeySpecSecp256k1),
+		KeyUsage:              pulumi.String(KMSKeyUsageSignVerify),
+		DeletionWindowInDays:  internalArgs.DeletionWindowInDays,
+		Policy:                keyPolicy.ToJSON(),
+		Tags: pulumi.StringMap{
+			"Name": pulumi.String(fmt.Sprintf("%s%s", args.Name, SigningKeySuffix)),
+		},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create KMS key: %w", err)
+	}
+
+	alias, err := kms.NewAlias(ctx, fmt.Sprintf("%s%s", args.Name, KeyAliasSuffix), &kms.AliasArgs{
+		Name:        internalArgs.AliasName,
+		TargetKeyId: key.KeyId,
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create KMS alias: %w", err)
+	}
+
+	// Derive the Ethereum address from the DER-encoded public key
+	publicKey := kms.GetPublicKeyOutput(ctx, kms.GetPublicKeyOutputArgs{
+		KeyId: key.KeyId,
+	}, pulumi.Parent(component))
+	address := publicKey.PublicKey().ApplyT(func(encoded string) (string, error) {
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode public key: %w", err)
+		}
+		return ethereumAddressFromPublicKey(der)
+	}).(pulumi.StringOutput)
+
+	component.Key = key
+	component.Alias = alias
+	component.KeyId = key.KeyId
+	component.KeyArn = key.Arn
+	component.Address = address
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"keyId":   key.KeyId,
+		"keyArn":  key.Arn,
+		"address": address,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+// CreateKMSPolicy creates an IAM policy document allowing signing with this key
+func (c *SigningKeyComponent) CreateKMSPolicy() pulumi.StringOutput {
+	return CreateKMSPolicy(c.KeyArn)
+}
+
+// CreateIAMResources creates an EKS pod identity role allowed to sign with this key
+func (c *SigningKeyComponent) CreateIAMResources(ctx *pulumi.Context, name string, serviceName string, parent pulumi.Resource) (*IAMResources, error) {
+	return CreateIAMResources(ctx, name, serviceName, c.KeyArn, parent)
+}
+
+// createSigningKeyPolicy builds the key policy for a signing key. The account root keeps full
+// access so IAM policies can grant usage, and any signer ARNs are allowed to sign directly.
+func createSigningKeyPolicy(accountId string, signerArns []string) PolicyDocument {
+	policy := NewPolicyDocument(PolicyStatement{
+		Sid:    KeyAdminStatementSid,
+		Effect: EffectAllow,
+		Principal: []PolicyPrincipal{
+			{Type: PrincipalTypeAWS, Identifiers: pulumi.StringArray{pulumi.Sprintf("arn:aws:iam::%s:root", accountId)}},
+		},
+		Action:   []string{KMSAllActions},
+		Resource: pulumi.StringArray{pulumi.String("*")},
+	})
+	if len(signerArns) > 0 {
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Sid:    KeySignerStatementSid,
+			Effect: EffectAllow,
+			Principal: []PolicyPrincipal{
+				{Type: PrincipalTypeAWS, Identifiers: pulumi.ToStringArray(signerArns)},
+			},
+			Action:   []string{KMSSignAction, KMSGetPublicKeyAction},
+			Resource: pulumi.StringArray{pulumi.String("*")},
+		})
+	}
+	return policy
+}
+
+// ethereumAddressFromPublicKey derives an EIP-55 checksummed Ethereum address from a
+// DER-encoded SubjectPublicKeyInfo, as returned by KMS GetPublicKey
+func ethereumAddressFromPublicKey(der []byte) (string, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if len(rest) > 0 {
+		return "", fmt.Errorf("trailing data after public key")
+	}
+
+	// Uncompressed secp256k1 points are 0x04 || X || Y
+	point := spki.PublicKey.Bytes
+	if len(point) != 65 || point[0] != 0x04 {
+		return "", fmt.Errorf("public key is not an uncompressed secp256k1 point")
+	}
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(point[1:])
+	return toChecksumAddress(hash.Sum(nil)[12:]), nil
+}
+
+// toChecksumAddress formats a 20 byte address with EIP-55 mixed-case checksum
+func toChecksumAddress(address []byte) string {
+	lower := hex.EncodeToString(address)
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	var checksummed strings.Builder
+	checksummed.WriteString("0x")
+	for i, c := range lower {
+		if c >= 'a' && digest[i] >= '8' {
+			checksummed.WriteString(strings.ToUpper(string(c)))
+		} else {
+			checksummed.WriteRune(c)
+		}
+	}
+	return checksummed.String()
+}
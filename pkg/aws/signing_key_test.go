@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// secp256k1 public key for private key 1 (the generator point), DER encoded as
+// a SubjectPublicKeyInfo the way KMS GetPublicKey returns it
+const generatorPublicKeyDER = "3056301006072a8648ce3d020106052b8104000a034200" +
+	"0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+	"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+
+func TestEthereumAddressFromPublicKey(t *testing.T) {
+	der, err := hex.DecodeString(generatorPublicKeyDER)
+	require.NoError(t, err)
+
+	address, err := ethereumAddressFromPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", address)
+
+	// Truncated input is rejected
+	_, err = ethereumAddressFromPublicKey(der[:20])
+	assert.Error(t, err)
+
+	// Keys without an uncompressed point are rejected
+	_, err = ethereumAddressFromPublicKey([]byte{0x30, 0x00})
+	assert.Error(t, err)
+}
+
+func TestToChecksumAddress(t *testing.T) {
+	// EIP-55 test vector
+	address, err := hex.DecodeString("5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NoError(t, err)
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", toChecksumAddress(address))
+}
+
+func TestCreateSigningKeyPolicy(t *testing.T) {
+	type statement struct {
+		Sid       string
+		Effect    string
+		Principal map[string]interface{}
+		Action    interface{}
+		Resource  string
+	}
+	type policy struct {
+		Version   string
+		Statement []statement
+	}
+
+	// Without signers only the account root is granted access
+	var parsed policy
+	awaitPolicyJSON(t, createSigningKeyPolicy("123456789012", nil).ToJSON(), &parsed)
+	assert.Equal(t, IAMPolicyVersion, parsed.Version)
+	require.Len(t, parsed.Statement, 1)
+	assert.Equal(t, KeyAdminStatementSid, parsed.Statement[0].Sid)
+	assert.Equal(t, "arn:aws:iam::123456789012:root", parsed.Statement[0].Principal["AWS"])
+	assert.Equal(t, KMSAllActions, parsed.Statement[0].Action)
+
+	// Signers are granted sign and get public key
+	signer := "arn:aws:iam::123456789012:role/quincey-role"
+	parsed = policy{}
+	awaitPolicyJSON(t, createSigningKeyPolicy("123456789012", []string{signer}).ToJSON(), &parsed)
+	require.Len(t, parsed.Statement, 2)
+	assert.Equal(t, KeySignerStatementSid, parsed.Statement[1].Sid)
+	assert.Equal(t, signer, parsed.Statement[1].Principal["AWS"])
+	assert.Equal(t, []interface{}{KMSSignAction, KMSGetPublicKeyAction}, parsed.Statement[1].Action)
+}
+
+func TestSigningKeyArgsToInternalDefaults(t *testing.T) {
+	internal := SigningKeyArgs{Name: "quincey", AccountId: "123456789012"}.toInternal()
+
+	assert.Equal(t, "quincey", internal.Name)
+	assert.Equal(t, "123456789012", internal.AccountId)
+	assert.Equal(t, pulumi.String("alias/quincey"), internal.AliasName)
+	assert.Equal(t, pulumi.Int(DefaultKeyDeletionWindowInDays), internal.DeletionWindowInDays)
+	assert.Equal(t, pulumi.String("Ethereum signing key for quincey"), internal.Description)
+}
@@ -4,7 +4,20 @@
 package aws
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ec2"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/eks"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/kms"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/rds"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/s3"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/secretsmanager"
+	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes"
+	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
@@ -13,6 +26,8 @@ import (
 // IAMStatement represents a statement in an IAM policy document.
 // It defines a single permission statement that specifies what actions
 // are allowed or denied on which resources.
+//
+// Deprecated: use PolicyStatement, which supports all principal types and conditions.
 type IAMStatement struct {
 	// Sid is an optional identifier for the statement
 	Sid string `json:"sid,omitempty"`
@@ -29,6 +44,8 @@ type IAMStatement struct {
 
 // IAMPolicy represents a complete IAM policy document.
 // It contains a version and a list of statements that define the policy's permissions.
+//
+// Deprecated: use PolicyDocument.
 type IAMPolicy struct {
 	// Version specifies the policy language version
 	Version string `json:"Version"`
@@ -56,69 +73,591 @@ type KMSPolicy struct {
 	Statement []KMSStatement `json:"Statement"`
 }
 
-// Internal structs with Pulumi types
-
-type kmsStatementInternal struct {
-	Effect   string             `json:"Effect"`
-	Action   []string           `json:"Action"`
-	Resource pulumi.StringInput `json:"Resource"`
-}
-
-type kmsPolicyInternal struct {
-	Version   string                 `json:"Version"`
-	Statement []kmsStatementInternal `json:"Statement"`
-}
-
 // Conversion functions
 
-// toInternal converts a public KMSStatement to internal format
-func (s KMSStatement) toInternal() kmsStatementInternal {
-	return kmsStatementInternal{
+// toInternal converts a public KMSStatement to a policy statement
+func (s KMSStatement) toInternal() PolicyStatement {
+	return PolicyStatement{
 		Effect:   s.Effect,
 		Action:   s.Action,
-		Resource: pulumi.String(s.Resource),
+		Resource: pulumi.StringArray{pulumi.String(s.Resource)},
 	}
 }
 
-// toInternal converts a public KMSPolicy to internal format
-func (p KMSPolicy) toInternal() kmsPolicyInternal {
-	statements := make([]kmsStatementInternal, len(p.Statement))
+// toInternal converts a public KMSPolicy to a policy document with every statement
+func (p KMSPolicy) toInternal() PolicyDocument {
+	statements := make([]PolicyStatement, len(p.Statement))
 	for i, stmt := range p.Statement {
 		statements[i] = stmt.toInternal()
 	}
-	return kmsPolicyInternal{
+	return PolicyDocument{
 		Version:   p.Version,
 		Statement: statements,
 	}
 }
 
+// PostgresDbArgs configures an Aurora PostgreSQL Serverless v2 cluster.
+// Only the subnet group, username and database name are required; every
+// other field falls back to a default when left at its zero value.
+//
+// The master password is taken from DbPassword, managed by RDS when
+// ManageMasterUserPassword is set, or otherwise generated and stored in
+// Secrets Manager.
 type PostgresDbArgs struct {
+	// DbSubnetGroupName is an existing DB subnet group, mutually exclusive with SubnetIds
 	DbSubnetGroupName string
-	DbUsername        string
-	DbPassword        string
-	DbName            string
+	// SubnetIds creates a new DB subnet group from these subnets, mutually exclusive with DbSubnetGroupName
+	SubnetIds  []string
+	DbUsername string
+	// DbPassword is the master password, generated when empty
+	DbPassword string
+	DbName     string
+	// ManageMasterUserPassword lets RDS generate and rotate the master password in Secrets Manager,
+	// mutually exclusive with DbPassword
+	ManageMasterUserPassword bool
+	// MasterUserSecretKmsKeyId is the KMS key used to encrypt the managed master password secret
+	MasterUserSecretKmsKeyId string
+	// ConnectionSecretNamespace creates a Kubernetes Secret with the connection URL in this namespace
+	ConnectionSecretNamespace string
+	// ConnectionSecretStoreName is the External Secrets Operator store that reads the managed master
+	// password secret. Required with ManageMasterUserPassword and ConnectionSecretNamespace, so the
+	// connection Secret is synced from Secrets Manager and follows password rotation
+	ConnectionSecretStoreName string
+	// ConnectionSecretStoreKind is "SecretStore" or "ClusterSecretStore", defaults to "ClusterSecretStore"
+	ConnectionSecretStoreKind string
+	// EngineVersion is the Aurora PostgreSQL engine version, defaults to DefaultPostgresEngineVersion
+	EngineVersion string
+	// MinCapacity is the minimum serverless capacity in ACUs, defaults to DefaultPostgresMinCapacity
+	MinCapacity float64
+	// MaxCapacity is the maximum serverless capacity in ACUs, defaults to DefaultPostgresMaxCapacity
+	MaxCapacity float64
+	// ReaderCount is the number of reader instances created alongside the writer
+	ReaderCount int
+	// BackupRetentionPeriod is the number of days automated backups are kept, defaults to DefaultPostgresBackupRetentionDays
+	BackupRetentionPeriod int
+	// FinalSnapshotIdentifier takes a final snapshot with this name on deletion; when empty no snapshot is taken
+	FinalSnapshotIdentifier string
+	// DeletionProtection prevents the cluster from being deleted
+	DeletionProtection bool
+	// VpcSecurityGroupIds are the security groups attached to the cluster
+	VpcSecurityGroupIds []string
+	// ClusterParameterGroupName is an existing cluster parameter group, mutually exclusive with ClusterParameters
+	ClusterParameterGroupName string
+	// ClusterParameters creates a cluster parameter group with these parameters
+	ClusterParameters map[string]string
+	// DbParameterGroupName is an existing instance parameter group applied to every instance
+	DbParameterGroupName string
+	// StorageEncrypted encrypts the cluster storage, implied when KmsKeyArn is set
+	StorageEncrypted bool
+	// KmsKeyArn is the KMS key used to encrypt the cluster storage
+	KmsKeyArn string
 }
 
 type postgresDbArgsInternal struct {
-	DbSubnetGroupName pulumi.StringInput
-	DbUsername        pulumi.StringInput
-	DbPassword        pulumi.StringInput
-	DbName            pulumi.StringInput
+	DbSubnetGroupName         pulumi.StringInput
+	SubnetIds                 pulumi.StringArray
+	DbUsername                pulumi.StringInput
+	DbPassword                pulumi.StringInput
+	DbName                    pulumi.StringInput
+	ManageMasterUserPassword  pulumi.BoolPtrInput
+	MasterUserSecretKmsKeyId  pulumi.StringPtrInput
+	ConnectionSecretNamespace pulumi.StringInput
+	EngineVersion             pulumi.StringInput
+	EngineFamily              pulumi.StringInput
+	MinCapacity               pulumi.Float64Input
+	MaxCapacity               pulumi.Float64Input
+	ReaderCount               int
+	BackupRetentionPeriod     pulumi.IntPtrInput
+	SkipFinalSnapshot         pulumi.BoolPtrInput
+	FinalSnapshotIdentifier   pulumi.StringPtrInput
+	DeletionProtection        pulumi.BoolPtrInput
+	VpcSecurityGroupIds       pulumi.StringArray
+	ClusterParameterGroupName pulumi.StringPtrInput
+	ClusterParameters         map[string]string
+	DbParameterGroupName      pulumi.StringPtrInput
+	StorageEncrypted          pulumi.BoolPtrInput
+	KmsKeyId                  pulumi.StringPtrInput
 }
 
 func (args PostgresDbArgs) toInternal() postgresDbArgsInternal {
-	return postgresDbArgsInternal{
-		DbSubnetGroupName: pulumi.String(args.DbSubnetGroupName),
-		DbUsername:        pulumi.String(args.DbUsername),
-		DbPassword:        pulumi.String(args.DbPassword),
-		DbName:            pulumi.String(args.DbName),
+	engineVersion := args.EngineVersion
+	if engineVersion == "" {
+		engineVersion = DefaultPostgresEngineVersion
+	}
+	minCapacity := args.MinCapacity
+	if minCapacity == 0 {
+		minCapacity = DefaultPostgresMinCapacity
+	}
+	maxCapacity := args.MaxCapacity
+	if maxCapacity == 0 {
+		maxCapacity = DefaultPostgresMaxCapacity
+	}
+	backupRetention := args.BackupRetentionPeriod
+	if backupRetention == 0 {
+		backupRetention = DefaultPostgresBackupRetentionDays
+	}
+
+	internal := postgresDbArgsInternal{
+		DbSubnetGroupName:         pulumi.String(args.DbSubnetGroupName),
+		SubnetIds:                 pulumi.ToStringArray(args.SubnetIds),
+		DbUsername:                pulumi.String(args.DbUsername),
+		DbName:                    pulumi.String(args.DbName),
+		ManageMasterUserPassword:  pulumi.Bool(args.ManageMasterUserPassword),
+		ConnectionSecretNamespace: pulumi.String(args.ConnectionSecretNamespace),
+		EngineVersion:             pulumi.String(engineVersion),
+		EngineFamily:              pulumi.String(postgresEngineFamily(engineVersion)),
+		MinCapacity:               pulumi.Float64(minCapacity),
+		MaxCapacity:               pulumi.Float64(maxCapacity),
+		ReaderCount:               args.ReaderCount,
+		BackupRetentionPeriod:     pulumi.Int(backupRetention),
+		SkipFinalSnapshot:         pulumi.Bool(args.FinalSnapshotIdentifier == ""),
+		DeletionProtection:        pulumi.Bool(args.DeletionProtection),
+		VpcSecurityGroupIds:       pulumi.ToStringArray(args.VpcSecurityGroupIds),
+		ClusterParameters:         args.ClusterParameters,
+		StorageEncrypted:          pulumi.Bool(args.StorageEncrypted || args.KmsKeyArn != ""),
+	}
+	if args.DbPassword != "" {
+		internal.DbPassword = utils.SecretString(args.DbPassword)
+	}
+	if args.MasterUserSecretKmsKeyId != "" {
+		internal.MasterUserSecretKmsKeyId = pulumi.StringPtr(args.MasterUserSecretKmsKeyId)
 	}
+	if args.FinalSnapshotIdentifier != "" {
+		internal.FinalSnapshotIdentifier = pulumi.StringPtr(args.FinalSnapshotIdentifier)
+	}
+	if args.ClusterParameterGroupName != "" {
+		internal.ClusterParameterGroupName = pulumi.StringPtr(args.ClusterParameterGroupName)
+	}
+	if args.DbParameterGroupName != "" {
+		internal.DbParameterGroupName = pulumi.StringPtr(args.DbParameterGroupName)
+	}
+	if args.KmsKeyArn != "" {
+		internal.KmsKeyId = pulumi.StringPtr(args.KmsKeyArn)
+	}
+	return internal
+}
+
+// postgresEngineFamily returns the parameter group family for an Aurora PostgreSQL
+// engine version, e.g. aurora-postgresql16 for 16.6
+func postgresEngineFamily(engineVersion string) string {
+	major, _, _ := strings.Cut(engineVersion, ".")
+	return fmt.Sprintf("%s%s", PostgresEngineFamilyPrefix, major)
 }
 
 type PostgresDbComponent struct {
 	pulumi.ResourceState
-	DbCluster         *rds.Cluster
+	DbCluster *rds.Cluster
+	// DbClusterInstance is the writer instance
 	DbClusterInstance *rds.ClusterInstance
-	DbClusterEndpoint pulumi.StringOutput
-	DbSubnetGroup     *rds.SubnetGroup
+	// DbClusterInstances holds the writer followed by any readers
+	DbClusterInstances      []*rds.ClusterInstance
+	DbClusterEndpoint       pulumi.StringOutput
+	DbClusterReaderEndpoint pulumi.StringOutput
+	DbSubnetGroup           *rds.SubnetGroup
+	DbClusterParameterGroup *rds.ClusterParameterGroup
+	// DbPasswordSecret holds the generated master password, set only when the password is generated
+	DbPasswordSecret *secretsmanager.Secret
+	// MasterUserSecretArn is the Secrets Manager secret holding the master password,
+	// set when the password is managed by RDS or generated
+	MasterUserSecretArn pulumi.StringOutput
+	// ConnectionUrl is a postgres:// URL for the writer endpoint, marked as a Pulumi secret.
+	// With a managed password it holds the password as of the last deployment
+	ConnectionUrl pulumi.StringOutput
+	// ConnectionSecret holds the connection details, set when ConnectionSecretNamespace is given
+	// and the password is not managed by RDS
+	ConnectionSecret *corev1.Secret
+	// ConnectionExternalSecret syncs the connection details from the managed master password secret,
+	// set when ConnectionSecretNamespace is given and the password is managed by RDS
+	ConnectionExternalSecret *crd.CustomResource
+}
+
+// SigningKeyArgs configures a KMS key used to sign Ethereum transactions.
+type SigningKeyArgs struct {
+	// Name is the base name for the key and its alias
+	Name string
+	// AccountId is the AWS account that owns the key; its root principal administers the key
+	AccountId string
+	// Description is an optional description for the key
+	Description string
+	// AliasName is an optional alias without the "alias/" prefix, defaults to Name
+	AliasName string
+	// SignerArns are optional IAM principals granted signing access in the key policy
+	SignerArns []string
+	// DeletionWindowInDays is the optional waiting period before the key is deleted
+	DeletionWindowInDays int
+}
+
+type signingKeyArgsInternal struct {
+	Name                 string
+	AccountId            string
+	Description          pulumi.StringInput
+	AliasName            pulumi.StringInput
+	SignerArns           []string
+	DeletionWindowInDays pulumi.IntInput
+}
+
+func (args SigningKeyArgs) toInternal() signingKeyArgsInternal {
+	description := args.Description
+	if description == "" {
+		description = fmt.Sprintf("Ethereum signing key for %s", args.Name)
+	}
+	aliasName := args.AliasName
+	if aliasName == "" {
+		aliasName = args.Name
+	}
+	deletionWindow := args.DeletionWindowInDays
+	if deletionWindow == 0 {
+		deletionWindow = DefaultKeyDeletionWindowInDays
+	}
+	return signingKeyArgsInternal{
+		Name:                 args.Name,
+		AccountId:            args.AccountId,
+		Description:          pulumi.String(description),
+		AliasName:            pulumi.String(fmt.Sprintf("%s%s", KMSAliasPrefix, aliasName)),
+		SignerArns:           args.SignerArns,
+		DeletionWindowInDays: pulumi.Int(deletionWindow),
+	}
+}
+
+// SigningKeyComponent is a secp256k1 KMS key and alias usable as an Ethereum signer.
+type SigningKeyComponent struct {
+	pulumi.ResourceState
+	Key   *kms.Key
+	Alias *kms.Alias
+	// KeyId is the KMS key ID, suitable for QUINCEY_KEY_ID or BUILDER_KEY
+	KeyId pulumi.StringOutput
+	// KeyArn is the KMS key ARN, used to grant signing access
+	KeyArn pulumi.StringOutput
+	// Address is the EIP-55 checksummed Ethereum address derived from the public key
+	Address pulumi.StringOutput
+}
+
+// S3BucketArgs configures a hardened S3 bucket. Buckets are always encrypted with
+// SSE-KMS, block all public access and deny requests made without TLS.
+type S3BucketArgs struct {
+	// Name is the base name of the Pulumi resources
+	Name string
+	// BucketName is the globally unique name of the bucket
+	BucketName string
+	// KmsKeyArn encrypts objects with a customer managed key, defaults to the AWS managed aws/s3 key
+	KmsKeyArn string
+	// Versioning keeps previous versions of overwritten and deleted objects, required for replication
+	Versioning bool
+	// Lifecycle optionally moves objects to cheaper storage classes as they age
+	Lifecycle *S3LifecycleArgs
+	// Replication optionally replicates objects to a bucket in another region
+	Replication *S3ReplicationArgs
+	// LegacyResourceName adopts an unparented bucket previously created under this Pulumi name
+	LegacyResourceName string
+}
+
+// S3LifecycleArgs configures lifecycle transitions for every object in a bucket.
+// Ages are in days since object creation; zero disables the step.
+type S3LifecycleArgs struct {
+	// InfrequentAccessAfterDays moves objects to Standard-IA, at least 30 days
+	InfrequentAccessAfterDays int
+	// GlacierAfterDays moves objects to Glacier Flexible Retrieval
+	GlacierAfterDays int
+	// ExpireAfterDays deletes objects
+	ExpireAfterDays int
+	// NoncurrentVersionExpirationDays deletes previous object versions
+	NoncurrentVersionExpirationDays int
+}
+
+// S3ReplicationArgs configures replication of every object to a destination bucket.
+// The destination bucket must already exist with versioning enabled.
+type S3ReplicationArgs struct {
+	// DestinationBucketArn is the ARN of the destination bucket
+	DestinationBucketArn string
+	// DestinationKmsKeyArn encrypts replicas with this key in the destination region, required
+	// because objects are always SSE-KMS encrypted
+	DestinationKmsKeyArn string
+	// StorageClass is the storage class of replicas, defaults to the source storage class
+	StorageClass string
+}
+
+type s3BucketArgsInternal struct {
+	BucketName  pulumi.StringInput
+	KmsKeyArn   pulumi.StringPtrInput
+	Versioning  bool
+	Lifecycle   *s3LifecycleArgsInternal
+	Replication *s3ReplicationArgsInternal
+}
+
+type s3LifecycleArgsInternal struct {
+	InfrequentAccessAfterDays       pulumi.IntPtrInput
+	GlacierAfterDays                pulumi.IntPtrInput
+	ExpireAfterDays                 pulumi.IntPtrInput
+	NoncurrentVersionExpirationDays pulumi.IntPtrInput
+}
+
+type s3ReplicationArgsInternal struct {
+	DestinationBucketArn pulumi.StringInput
+	DestinationKmsKeyArn pulumi.StringInput
+	StorageClass         pulumi.StringPtrInput
+}
+
+func (args S3BucketArgs) toInternal() s3BucketArgsInternal {
+	internal := s3BucketArgsInternal{
+		BucketName: pulumi.String(args.BucketName),
+		Versioning: args.Versioning,
+	}
+	if args.KmsKeyArn != "" {
+		internal.KmsKeyArn = pulumi.StringPtr(args.KmsKeyArn)
+	}
+	if args.Lifecycle != nil {
+		lifecycle := args.Lifecycle.toInternal()
+		internal.Lifecycle = &lifecycle
+	}
+	if args.Replication != nil {
+		replication := args.Replication.toInternal()
+		internal.Replication = &replication
+	}
+	return internal
+}
+
+func (args S3LifecycleArgs) toInternal() s3LifecycleArgsInternal {
+	return s3LifecycleArgsInternal{
+		InfrequentAccessAfterDays:       optionalDays(args.InfrequentAccessAfterDays),
+		GlacierAfterDays:                optionalDays(args.GlacierAfterDays),
+		ExpireAfterDays:                 optionalDays(args.ExpireAfterDays),
+		NoncurrentVersionExpirationDays: optionalDays(args.NoncurrentVersionExpirationDays),
+	}
+}
+
+func (args S3ReplicationArgs) toInternal() s3ReplicationArgsInternal {
+	internal := s3ReplicationArgsInternal{
+		DestinationBucketArn: pulumi.String(args.DestinationBucketArn),
+		DestinationKmsKeyArn: pulumi.String(args.DestinationKmsKeyArn),
+	}
+	if args.StorageClass != "" {
+		internal.StorageClass = pulumi.StringPtr(args.StorageClass)
+	}
+	return internal
+}
+
+// optionalDays returns nil for a zero day count so disabled steps are left unset
+func optionalDays(days int) pulumi.IntPtrInput {
+	if days == 0 {
+		return nil
+	}
+	return pulumi.IntPtr(days)
+}
+
+// S3BucketComponent is a hardened S3 bucket and its configuration resources.
+type S3BucketComponent struct {
+	pulumi.ResourceState
+	Bucket            *s3.BucketV2
+	Encryption        *s3.BucketServerSideEncryptionConfigurationV2
+	PublicAccessBlock *s3.BucketPublicAccessBlock
+	BucketPolicy      *s3.BucketPolicy
+	// Versioning is set only when versioning is enabled
+	Versioning *s3.BucketVersioningV2
+	// Lifecycle is set only when lifecycle rules are configured
+	Lifecycle *s3.BucketLifecycleConfigurationV2
+	// Replication and ReplicationRole are set only when replication is configured
+	Replication     *s3.BucketReplicationConfig
+	ReplicationRole *iam.Role
+	BucketName      pulumi.StringOutput
+	BucketArn       pulumi.StringOutput
+	Region          pulumi.StringOutput
+	// KmsKeyArn is the customer managed key objects are encrypted with, empty for the AWS managed key
+	KmsKeyArn string
+}
+
+// VpcArgs configures a VPC with a public and a private subnet in each availability zone.
+// Private subnets reach the internet through NAT gateways and are used for the DB subnet
+// group and for EKS nodes.
+type VpcArgs struct {
+	// Name is the base name of the VPC resources
+	Name string
+	// CidrBlock is the VPC CIDR, defaults to DefaultVpcCidrBlock
+	CidrBlock string
+	// AvailabilityZones lists the zones subnets are created in, at least two
+	AvailabilityZones []string
+	// SingleNatGateway shares one NAT gateway between all private subnets instead of one per zone
+	SingleNatGateway bool
+	// ClusterName tags subnets for discovery by an EKS cluster of this name
+	ClusterName string
+}
+
+type vpcArgsInternal struct {
+	CidrBlock         string
+	AvailabilityZones []string
+	SingleNatGateway  bool
+	ClusterName       string
+}
+
+func (args VpcArgs) toInternal() vpcArgsInternal {
+	cidrBlock := args.CidrBlock
+	if cidrBlock == "" {
+		cidrBlock = DefaultVpcCidrBlock
+	}
+	return vpcArgsInternal{
+		CidrBlock:         cidrBlock,
+		AvailabilityZones: args.AvailabilityZones,
+		SingleNatGateway:  args.SingleNatGateway,
+		ClusterName:       args.ClusterName,
+	}
+}
+
+// VpcComponent is a VPC with public and private subnets, NAT and a DB subnet group.
+type VpcComponent struct {
+	pulumi.ResourceState
+	Vpc              *ec2.Vpc
+	InternetGateway  *ec2.InternetGateway
+	PublicSubnets    []*ec2.Subnet
+	PrivateSubnets   []*ec2.Subnet
+	NatGateways      []*ec2.NatGateway
+	DbSubnetGroup    *rds.SubnetGroup
+	VpcId            pulumi.StringOutput
+	PublicSubnetIds  pulumi.StringArrayOutput
+	PrivateSubnetIds pulumi.StringArrayOutput
+	// DbSubnetGroupName is the name of the DB subnet group, usable as PostgresDbArgs.DbSubnetGroupName
+	DbSubnetGroupName string
+}
+
+// EksClusterArgs configures an EKS cluster with managed node groups, the EBS CSI and
+// Pod Identity add-ons and an IAM OIDC provider.
+type EksClusterArgs struct {
+	// Name is the name of the cluster
+	Name string
+	// Version is the Kubernetes version, defaults to DefaultKubernetesVersion
+	Version string
+	// Vpc runs the cluster in this VPC's private subnets, mutually exclusive with SubnetIds
+	Vpc *VpcComponent
+	// SubnetIds are existing subnets for the control plane and nodes, mutually exclusive with Vpc
+	SubnetIds []string
+	// PublicAccessCidrs restricts access to the public API endpoint, defaults to everywhere
+	PublicAccessCidrs []string
+	// EnabledClusterLogTypes are the control plane logs sent to CloudWatch
+	EnabledClusterLogTypes []string
+	// NodeGroups are the managed node groups, at least one
+	NodeGroups []EksNodeGroupArgs
+}
+
+// EksNodeGroupArgs configures a managed node group.
+type EksNodeGroupArgs struct {
+	// Name is unique within the cluster
+	Name          string
+	InstanceTypes []string
+	MinSize       int
+	MaxSize       int
+	// DesiredSize defaults to MinSize
+	DesiredSize int
+	// DiskSize is the root volume size in GiB, defaults to DefaultNodeGroupDiskSize
+	DiskSize int
+	// CapacityType is ON_DEMAND or SPOT, defaults to ON_DEMAND
+	CapacityType string
+	Labels       map[string]string
+	Taints       []EksNodeGroupTaint
+}
+
+// EksNodeGroupTaint is a Kubernetes taint applied to every node in a node group.
+type EksNodeGroupTaint struct {
+	Key   string
+	Value string
+	// Effect is NO_SCHEDULE, NO_EXECUTE or PREFER_NO_SCHEDULE
+	Effect string
+}
+
+type eksClusterArgsInternal struct {
+	Version                pulumi.StringInput
+	SubnetIds              pulumi.StringArrayInput
+	PublicAccessCidrs      pulumi.StringArray
+	EnabledClusterLogTypes pulumi.StringArray
+	NodeGroups             []eksNodeGroupArgsInternal
+}
+
+type eksNodeGroupArgsInternal struct {
+	Name          string
+	InstanceTypes pulumi.StringArray
+	MinSize       pulumi.IntInput
+	MaxSize       pulumi.IntInput
+	DesiredSize   pulumi.IntInput
+	DiskSize      pulumi.IntPtrInput
+	CapacityType  pulumi.StringPtrInput
+	Labels        pulumi.StringMap
+	Taints        eks.NodeGroupTaintArray
+}
+
+func (args EksClusterArgs) toInternal() eksClusterArgsInternal {
+	version := args.Version
+	if version == "" {
+		version = DefaultKubernetesVersion
+	}
+	publicAccessCidrs := args.PublicAccessCidrs
+	if len(publicAccessCidrs) == 0 {
+		publicAccessCidrs = []string{AllIPv4CidrBlock}
+	}
+
+	internal := eksClusterArgsInternal{
+		Version:                pulumi.String(version),
+		PublicAccessCidrs:      pulumi.ToStringArray(publicAccessCidrs),
+		EnabledClusterLogTypes: pulumi.ToStringArray(args.EnabledClusterLogTypes),
+	}
+	if args.Vpc != nil {
+		internal.SubnetIds = args.Vpc.PrivateSubnetIds
+	} else {
+		internal.SubnetIds = pulumi.ToStringArray(args.SubnetIds)
+	}
+	for _, nodeGroup := range args.NodeGroups {
+		internal.NodeGroups = append(internal.NodeGroups, nodeGroup.toInternal())
+	}
+	return internal
+}
+
+func (args EksNodeGroupArgs) toInternal() eksNodeGroupArgsInternal {
+	desiredSize := args.DesiredSize
+	if desiredSize == 0 {
+		desiredSize = args.MinSize
+	}
+	diskSize := args.DiskSize
+	if diskSize == 0 {
+		diskSize = DefaultNodeGroupDiskSize
+	}
+	capacityType := args.CapacityType
+	if capacityType == "" {
+		capacityType = EKSCapacityTypeOnDemand
+	}
+
+	internal := eksNodeGroupArgsInternal{
+		Name:          args.Name,
+		InstanceTypes: pulumi.ToStringArray(args.InstanceTypes),
+		MinSize:       pulumi.Int(args.MinSize),
+		MaxSize:       pulumi.Int(args.MaxSize),
+		DesiredSize:   pulumi.Int(desiredSize),
+		DiskSize:      pulumi.IntPtr(diskSize),
+		CapacityType:  pulumi.StringPtr(capacityType),
+		Labels:        pulumi.ToStringMap(args.Labels),
+	}
+	for _, taint := range args.Taints {
+		taintArgs := eks.NodeGroupTaintArgs{
+			Key:    pulumi.String(taint.Key),
+			Effect: pulumi.String(taint.Effect),
+		}
+		if taint.Value != "" {
+			taintArgs.Value = pulumi.StringPtr(taint.Value)
+		}
+		internal.Taints = append(internal.Taints, taintArgs)
+	}
+	return internal
+}
+
+// EksClusterComponent is an EKS cluster, its node groups and add-ons, and a Kubernetes
+// provider that other components can be deployed with via pulumi.Provider.
+type EksClusterComponent struct {
+	pulumi.ResourceState
+	Cluster      *eks.Cluster
+	ClusterRole  *iam.Role
+	NodeRole     *iam.Role
+	NodeGroups   []*eks.NodeGroup
+	Addons       []*eks.Addon
+	EbsCsiRole   *iam.Role
+	OidcProvider *iam.OpenIdConnectProvider
+	// Kubeconfig authenticates with `aws eks get-token`, marked as a Pulumi secret
+	Kubeconfig  pulumi.StringOutput
+	Provider    *kubernetes.Provider
+	ClusterName pulumi.StringOutput
+	Endpoint    pulumi.StringOutput
 }
@@ -2,6 +2,10 @@ package aws
 
 import (
 	"fmt"
+	"net"
+	"strings"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 )
 
 // Validate validates the IAMStatement
@@ -62,21 +66,337 @@ func (p *KMSPolicy) Validate() error {
 }
 
 func validateDb(db PostgresDbArgs) error {
-	if db.DbSubnetGroupName == "" {
-		return fmt.Errorf("dbSubnetGroupName is required")
+	if db.DbSubnetGroupName == "" && len(db.SubnetIds) == 0 {
+		return fmt.Errorf("dbSubnetGroupName or subnetIds is required")
+	}
+
+	if db.DbSubnetGroupName != "" && len(db.SubnetIds) > 0 {
+		return fmt.Errorf("dbSubnetGroupName and subnetIds are mutually exclusive")
 	}
 
 	if db.DbUsername == "" {
 		return fmt.Errorf("dbUsername is required")
 	}
 
-	if db.DbPassword == "" {
-		return fmt.Errorf("dbPassword is required")
+	if db.DbPassword != "" && db.ManageMasterUserPassword {
+		return fmt.Errorf("dbPassword and manageMasterUserPassword are mutually exclusive")
+	}
+
+	if db.MasterUserSecretKmsKeyId != "" && !db.ManageMasterUserPassword {
+		return fmt.Errorf("masterUserSecretKmsKeyId requires manageMasterUserPassword")
+	}
+
+	// RDS rotates a managed password, so the connection Secret has to be synced from Secrets Manager
+	if db.ManageMasterUserPassword && db.ConnectionSecretNamespace != "" && db.ConnectionSecretStoreName == "" {
+		return fmt.Errorf("connectionSecretStoreName is required when manageMasterUserPassword and connectionSecretNamespace are set")
+	}
+
+	if db.ConnectionSecretStoreName != "" && !(db.ManageMasterUserPassword && db.ConnectionSecretNamespace != "") {
+		return fmt.Errorf("connectionSecretStoreName requires manageMasterUserPassword and connectionSecretNamespace")
+	}
+
+	if db.ConnectionSecretStoreKind != "" &&
+		db.ConnectionSecretStoreKind != utils.SecretStoreKindSecretStore &&
+		db.ConnectionSecretStoreKind != utils.SecretStoreKindClusterSecretStore {
+		return fmt.Errorf("connectionSecretStoreKind must be %q or %q", utils.SecretStoreKindSecretStore, utils.SecretStoreKindClusterSecretStore)
 	}
 
 	if db.DbName == "" {
 		return fmt.Errorf("dbName is required")
 	}
 
+	if db.MinCapacity < PostgresMinCapacityLimit || db.MinCapacity > PostgresMaxCapacityLimit {
+		return fmt.Errorf("minCapacity must be between %v and %v", PostgresMinCapacityLimit, PostgresMaxCapacityLimit)
+	}
+
+	if db.MaxCapacity < PostgresMinCapacityLimit || db.MaxCapacity > PostgresMaxCapacityLimit {
+		return fmt.Errorf("maxCapacity must be between %v and %v", PostgresMinCapacityLimit, PostgresMaxCapacityLimit)
+	}
+
+	// Compare against the defaults that will actually be applied
+	minCapacity, maxCapacity := db.MinCapacity, db.MaxCapacity
+	if minCapacity == 0 {
+		minCapacity = DefaultPostgresMinCapacity
+	}
+	if maxCapacity == 0 {
+		maxCapacity = DefaultPostgresMaxCapacity
+	}
+	if maxCapacity < minCapacity {
+		return fmt.Errorf("maxCapacity must be greater than or equal to minCapacity")
+	}
+
+	if db.ReaderCount < 0 || db.ReaderCount > PostgresMaxReaderCount {
+		return fmt.Errorf("readerCount must be between 0 and %d", PostgresMaxReaderCount)
+	}
+
+	if db.BackupRetentionPeriod < 0 || db.BackupRetentionPeriod > PostgresMaxBackupRetentionDays {
+		return fmt.Errorf("backupRetentionPeriod must be between 0 and %d days", PostgresMaxBackupRetentionDays)
+	}
+
+	if db.ClusterParameterGroupName != "" && len(db.ClusterParameters) > 0 {
+		return fmt.Errorf("clusterParameterGroupName and clusterParameters are mutually exclusive")
+	}
+
+	return nil
+}
+
+// Validate validates the PodIdentityConfig
+func (c *PodIdentityConfig) Validate() error {
+	if c.ClusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	return nil
+}
+
+// Validate validates the SigningKeyArgs
+func (args *SigningKeyArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if args.AccountId == "" {
+		return fmt.Errorf("account id is required")
+	}
+	if args.DeletionWindowInDays != 0 && (args.DeletionWindowInDays < 7 || args.DeletionWindowInDays > 30) {
+		return fmt.Errorf("deletion window must be between 7 and 30 days")
+	}
+	if strings.HasPrefix(args.AliasName, KMSAliasPrefix) {
+		return fmt.Errorf("alias name must not include the %q prefix", KMSAliasPrefix)
+	}
+	return nil
+}
+
+// Validate validates the PolicyDocument
+func (d PolicyDocument) Validate() error {
+	if d.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	if d.Version != IAMPolicyVersion && d.Version != IAMPolicyVersionLegacy {
+		return fmt.Errorf("version must be %s or %s", IAMPolicyVersion, IAMPolicyVersionLegacy)
+	}
+	if len(d.Statement) == 0 {
+		return fmt.Errorf("statement is required")
+	}
+	sids := make(map[string]bool)
+	for i, stmt := range d.Statement {
+		if err := stmt.Validate(); err != nil {
+			return fmt.Errorf("statement %d: %w", i, err)
+		}
+		if stmt.Sid != "" {
+			if sids[stmt.Sid] {
+				return fmt.Errorf("statement %d: duplicate sid %s", i, stmt.Sid)
+			}
+			sids[stmt.Sid] = true
+		}
+	}
+	return nil
+}
+
+// Validate validates the PolicyStatement
+func (s PolicyStatement) Validate() error {
+	if s.Effect != EffectAllow && s.Effect != EffectDeny {
+		return fmt.Errorf("effect must be %s or %s", EffectAllow, EffectDeny)
+	}
+	if len(s.Action) == 0 && len(s.NotAction) == 0 {
+		return fmt.Errorf("action or notAction is required")
+	}
+	if len(s.Action) > 0 && len(s.NotAction) > 0 {
+		return fmt.Errorf("action and notAction are mutually exclusive")
+	}
+	if len(s.Resource) > 0 && len(s.NotResource) > 0 {
+		return fmt.Errorf("resource and notResource are mutually exclusive")
+	}
+	if len(s.Principal) > 0 && len(s.NotPrincipal) > 0 {
+		return fmt.Errorf("principal and notPrincipal are mutually exclusive")
+	}
+	for i, principal := range append(append([]PolicyPrincipal{}, s.Principal...), s.NotPrincipal...) {
+		if err := principal.Validate(); err != nil {
+			return fmt.Errorf("principal %d: %w", i, err)
+		}
+	}
+	for i, condition := range s.Condition {
+		if err := condition.Validate(); err != nil {
+			return fmt.Errorf("condition %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the PolicyPrincipal
+func (p PolicyPrincipal) Validate() error {
+	switch p.Type {
+	case PrincipalTypeAll:
+		if len(p.Identifiers) > 0 {
+			return fmt.Errorf("identifiers must be empty for principal type %q", PrincipalTypeAll)
+		}
+		return nil
+	case PrincipalTypeAWS, PrincipalTypeService, PrincipalTypeFederated, PrincipalTypeCanonicalUser:
+		if len(p.Identifiers) == 0 {
+			return fmt.Errorf("identifiers are required for principal type %s", p.Type)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported principal type %q", p.Type)
+	}
+}
+
+// Validate validates the PolicyCondition
+func (c PolicyCondition) Validate() error {
+	if c.Operator == "" {
+		return fmt.Errorf("operator is required")
+	}
+	if c.Key == "" {
+		return fmt.Errorf("key is required")
+	}
+	if len(c.Values) == 0 {
+		return fmt.Errorf("values are required")
+	}
+	return nil
+}
+
+// Validate validates the S3BucketArgs
+func (args *S3BucketArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if args.BucketName == "" {
+		return fmt.Errorf("bucket name is required")
+	}
+	if args.Lifecycle != nil {
+		if err := args.Lifecycle.Validate(); err != nil {
+			return fmt.Errorf("invalid lifecycle: %w", err)
+		}
+	}
+	if args.Replication != nil {
+		if !args.Versioning {
+			return fmt.Errorf("replication requires versioning")
+		}
+		if err := args.Replication.Validate(); err != nil {
+			return fmt.Errorf("invalid replication: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the S3LifecycleArgs
+func (args *S3LifecycleArgs) Validate() error {
+	if args.InfrequentAccessAfterDays < 0 || args.GlacierAfterDays < 0 || args.ExpireAfterDays < 0 || args.NoncurrentVersionExpirationDays < 0 {
+		return fmt.Errorf("lifecycle ages must not be negative")
+	}
+	if args.InfrequentAccessAfterDays == 0 && args.GlacierAfterDays == 0 && args.ExpireAfterDays == 0 && args.NoncurrentVersionExpirationDays == 0 {
+		return fmt.Errorf("at least one lifecycle age is required")
+	}
+	if args.InfrequentAccessAfterDays != 0 && args.InfrequentAccessAfterDays < S3MinInfrequentAccessDays {
+		return fmt.Errorf("infrequent access transition must be at least %d days", S3MinInfrequentAccessDays)
+	}
+	if args.GlacierAfterDays != 0 && args.GlacierAfterDays <= args.InfrequentAccessAfterDays {
+		return fmt.Errorf("glacier transition must be after the infrequent access transition")
+	}
+	if args.ExpireAfterDays != 0 && (args.ExpireAfterDays <= args.InfrequentAccessAfterDays || args.ExpireAfterDays <= args.GlacierAfterDays) {
+		return fmt.Errorf("expiration must be after every transition")
+	}
+	return nil
+}
+
+// Validate validates the S3ReplicationArgs
+func (args *S3ReplicationArgs) Validate() error {
+	if args.DestinationBucketArn == "" {
+		return fmt.Errorf("destination bucket arn is required")
+	}
+	// Objects are always SSE-KMS encrypted, so replicas need a key in the destination region
+	if args.DestinationKmsKeyArn == "" {
+		return fmt.Errorf("destination kms key arn is required")
+	}
+	return nil
+}
+
+// Validate validates the VpcArgs
+func (args *VpcArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if args.CidrBlock != "" {
+		ip, network, err := net.ParseCIDR(args.CidrBlock)
+		if err != nil || ip.To4() == nil {
+			return fmt.Errorf("cidr block must be an IPv4 CIDR")
+		}
+		if prefix, _ := network.Mask.Size(); prefix < VpcMinPrefixLength || prefix > VpcMaxPrefixLength {
+			return fmt.Errorf("cidr block prefix must be between /%d and /%d", VpcMinPrefixLength, VpcMaxPrefixLength)
+		}
+	}
+	if len(args.AvailabilityZones) < VpcMinAvailabilityZones || len(args.AvailabilityZones) > VpcMaxAvailabilityZones {
+		return fmt.Errorf("between %d and %d availability zones are required", VpcMinAvailabilityZones, VpcMaxAvailabilityZones)
+	}
+	seen := make(map[string]bool)
+	for _, zone := range args.AvailabilityZones {
+		if seen[zone] {
+			return fmt.Errorf("duplicate availability zone %s", zone)
+		}
+		seen[zone] = true
+	}
+	return nil
+}
+
+// Validate validates the EksClusterArgs
+func (args *EksClusterArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if args.Vpc == nil && len(args.SubnetIds) == 0 {
+		return fmt.Errorf("vpc or subnet ids is required")
+	}
+	if args.Vpc != nil && len(args.SubnetIds) > 0 {
+		return fmt.Errorf("vpc and subnet ids are mutually exclusive")
+	}
+	if len(args.NodeGroups) == 0 {
+		return fmt.Errorf("at least one node group is required")
+	}
+	seen := make(map[string]bool)
+	for _, nodeGroup := range args.NodeGroups {
+		if err := nodeGroup.Validate(); err != nil {
+			return fmt.Errorf("invalid node group %s: %w", nodeGroup.Name, err)
+		}
+		if seen[nodeGroup.Name] {
+			return fmt.Errorf("duplicate node group %s", nodeGroup.Name)
+		}
+		seen[nodeGroup.Name] = true
+	}
+	return nil
+}
+
+// Validate validates the EksNodeGroupArgs
+func (args *EksNodeGroupArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(args.InstanceTypes) == 0 {
+		return fmt.Errorf("at least one instance type is required")
+	}
+	if args.MaxSize < 1 {
+		return fmt.Errorf("max size must be at least 1")
+	}
+	if args.MinSize < 0 || args.MinSize > args.MaxSize {
+		return fmt.Errorf("min size must be between 0 and max size")
+	}
+	if args.DesiredSize != 0 && (args.DesiredSize < args.MinSize || args.DesiredSize > args.MaxSize) {
+		return fmt.Errorf("desired size must be between min size and max size")
+	}
+	if args.DiskSize < 0 {
+		return fmt.Errorf("disk size must not be negative")
+	}
+	switch args.CapacityType {
+	case "", EKSCapacityTypeOnDemand, EKSCapacityTypeSpot:
+	default:
+		return fmt.Errorf("capacity type must be %s or %s", EKSCapacityTypeOnDemand, EKSCapacityTypeSpot)
+	}
+	for _, taint := range args.Taints {
+		if taint.Key == "" {
+			return fmt.Errorf("taint key is required")
+		}
+		switch taint.Effect {
+		case EKSTaintNoSchedule, EKSTaintNoExecute, EKSTaintPreferNoSchedule:
+		default:
+			return fmt.Errorf("taint effect must be %s, %s or %s", EKSTaintNoSchedule, EKSTaintNoExecute, EKSTaintPreferNoSchedule)
+		}
+	}
 	return nil
 }
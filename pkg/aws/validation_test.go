@@ -3,6 +3,7 @@ package aws
 import (
 	"testing"
 
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -172,3 +173,530 @@ func TestKMSPolicyToInternal(t *testing.T) {
 	assert.Equal(t, publicPolicy.Statement[0].Action, internalPolicy.Statement[0].Action)
 	assert.NotNil(t, internalPolicy.Statement[0].Resource)
 }
+
+// TestPodIdentityConfigValidate tests the validation of PodIdentityConfig
+func TestPodIdentityConfigValidate(t *testing.T) {
+	// Test valid config without a KMS key
+	validConfig := PodIdentityConfig{
+		ClusterName: "signet-cluster",
+	}
+	err := validConfig.Validate()
+	assert.NoError(t, err)
+
+	// Test valid config with a KMS key
+	validConfig.KmsKeyArn = "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	err = validConfig.Validate()
+	assert.NoError(t, err)
+
+	// Test missing cluster name
+	invalidConfig := PodIdentityConfig{}
+	err = invalidConfig.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "cluster name is required", err.Error())
+}
+
+// TestSigningKeyArgsValidate tests the validation of SigningKeyArgs
+func TestSigningKeyArgsValidate(t *testing.T) {
+	// Test valid args with defaults
+	validArgs := SigningKeyArgs{
+		Name:      "quincey",
+		AccountId: "123456789012",
+	}
+	err := validArgs.Validate()
+	assert.NoError(t, err)
+
+	// Test missing name
+	invalidArgs1 := SigningKeyArgs{AccountId: "123456789012"}
+	err = invalidArgs1.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "name is required", err.Error())
+
+	// Test missing account id
+	invalidArgs2 := SigningKeyArgs{Name: "quincey"}
+	err = invalidArgs2.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "account id is required", err.Error())
+
+	// Test out of range deletion window
+	invalidArgs3 := validArgs
+	invalidArgs3.DeletionWindowInDays = 3
+	err = invalidArgs3.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "deletion window must be between 7 and 30 days", err.Error())
+
+	// Test alias with prefix
+	invalidArgs4 := validArgs
+	invalidArgs4.AliasName = "alias/quincey"
+	err = invalidArgs4.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must not include")
+}
+
+// TestPolicyDocumentValidate tests the validation of PolicyDocument
+func TestPolicyDocumentValidate(t *testing.T) {
+	validStatement := PolicyStatement{
+		Effect:   EffectAllow,
+		Action:   []string{KMSSignAction},
+		Resource: pulumi.StringArray{pulumi.String("*")},
+	}
+
+	testCases := []struct {
+		name     string
+		document PolicyDocument
+		wantErr  string
+	}{
+		{
+			name:     "valid document",
+			document: NewPolicyDocument(validStatement),
+		},
+		{
+			name:     "missing version",
+			document: PolicyDocument{Statement: []PolicyStatement{validStatement}},
+			wantErr:  "version is required",
+		},
+		{
+			name:     "unknown version",
+			document: PolicyDocument{Version: "2020-01-01", Statement: []PolicyStatement{validStatement}},
+			wantErr:  "version must be",
+		},
+		{
+			name:     "missing statement",
+			document: NewPolicyDocument(),
+			wantErr:  "statement is required",
+		},
+		{
+			name:     "invalid effect",
+			document: NewPolicyDocument(PolicyStatement{Effect: "allow", Action: []string{KMSSignAction}}),
+			wantErr:  "statement 0: effect must be",
+		},
+		{
+			name:     "missing action",
+			document: NewPolicyDocument(PolicyStatement{Effect: EffectAllow}),
+			wantErr:  "action or notAction is required",
+		},
+		{
+			name: "action and notAction",
+			document: NewPolicyDocument(PolicyStatement{
+				Effect: EffectAllow, Action: []string{KMSSignAction}, NotAction: []string{KMSGetPublicKeyAction},
+			}),
+			wantErr: "action and notAction are mutually exclusive",
+		},
+		{
+			name: "resource and notResource",
+			document: NewPolicyDocument(PolicyStatement{
+				Effect:      EffectAllow,
+				Action:      []string{KMSSignAction},
+				Resource:    pulumi.StringArray{pulumi.String("*")},
+				NotResource: pulumi.StringArray{pulumi.String("arn:aws:kms:us-west-2:123456789012:key/one")},
+			}),
+			wantErr: "resource and notResource are mutually exclusive",
+		},
+		{
+			name: "principal and notPrincipal",
+			document: NewPolicyDocument(PolicyStatement{
+				Effect:       EffectAllow,
+				Action:       []string{KMSSignAction},
+				Principal:    []PolicyPrincipal{{Type: PrincipalTypeAll}},
+				NotPrincipal: []PolicyPrincipal{{Type: PrincipalTypeAll}},
+			}),
+			wantErr: "principal and notPrincipal are mutually exclusive",
+		},
+		{
+			name: "unsupported principal type",
+			document: NewPolicyDocument(PolicyStatement{
+				Effect:    EffectAllow,
+				Action:    []string{KMSSignAction},
+				Principal: []PolicyPrincipal{{Type: "User", Identifiers: pulumi.StringArray{pulumi.String("bob")}}},
+			}),
+			wantErr: "unsupported principal type",
+		},
+		{
+			name: "principal without identifiers",
+			document: NewPolicyDocument(PolicyStatement{
+				Effect:    EffectAllow,
+				Action:    []string{KMSSignAction},
+				Principal: []PolicyPrincipal{{Type: PrincipalTypeService}},
+			}),
+			wantErr: "identifiers are required for principal type Service",
+		},
+		{
+			name: "condition without values",
+			document: NewPolicyDocument(PolicyStatement{
+				Effect:    EffectAllow,
+				Action:    []string{KMSSignAction},
+				Condition: []PolicyCondition{{Operator: ConditionStringEquals, Key: "aws:SourceAccount"}},
+			}),
+			wantErr: "condition 0: values are required",
+		},
+		{
+			name: "duplicate sid",
+			document: NewPolicyDocument(
+				PolicyStatement{Sid: "Sign", Effect: EffectAllow, Action: []string{KMSSignAction}},
+				PolicyStatement{Sid: "Sign", Effect: EffectAllow, Action: []string{KMSGetPublicKeyAction}},
+			),
+			wantErr: "statement 1: duplicate sid Sign",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.document.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidateDb tests the validation of PostgresDbArgs
+func TestValidateDb(t *testing.T) {
+	validArgs := PostgresDbArgs{
+		DbSubnetGroupName: "signet-db-subnets",
+		DbUsername:        "signet",
+		DbPassword:        "password",
+		DbName:            "signet",
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(args *PostgresDbArgs)
+		wantErr string
+	}{
+		{
+			name:   "valid with defaults",
+			modify: func(args *PostgresDbArgs) {},
+		},
+		{
+			name: "valid with subnet ids and readers",
+			modify: func(args *PostgresDbArgs) {
+				args.DbSubnetGroupName = ""
+				args.SubnetIds = []string{"subnet-a", "subnet-b"}
+				args.ReaderCount = 2
+				args.MinCapacity = 0.5
+				args.MaxCapacity = 8
+				args.ClusterParameters = map[string]string{"log_min_duration_statement": "1000"}
+			},
+		},
+		{
+			name: "valid with managed password",
+			modify: func(args *PostgresDbArgs) {
+				args.DbPassword = ""
+				args.ManageMasterUserPassword = true
+				args.MasterUserSecretKmsKeyId = "arn:aws:kms:us-east-1:123456789012:key/abcd"
+			},
+		},
+		{
+			name:   "valid with generated password",
+			modify: func(args *PostgresDbArgs) { args.DbPassword = "" },
+		},
+		{
+			name:    "password and managed password",
+			modify:  func(args *PostgresDbArgs) { args.ManageMasterUserPassword = true },
+			wantErr: "dbPassword and manageMasterUserPassword are mutually exclusive",
+		},
+		{
+			name: "valid with managed password and connection secret",
+			modify: func(args *PostgresDbArgs) {
+				args.DbPassword = ""
+				args.ManageMasterUserPassword = true
+				args.ConnectionSecretNamespace = "pylon"
+				args.ConnectionSecretStoreName = "aws-secrets-manager"
+			},
+		},
+		{
+			name: "managed password connection secret without store",
+			modify: func(args *PostgresDbArgs) {
+				args.DbPassword = ""
+				args.ManageMasterUserPassword = true
+				args.ConnectionSecretNamespace = "pylon"
+			},
+			wantErr: "connectionSecretStoreName is required when manageMasterUserPassword and connectionSecretNamespace are set",
+		},
+		{
+			name: "connection secret store without managed password",
+			modify: func(args *PostgresDbArgs) {
+				args.ConnectionSecretNamespace = "pylon"
+				args.ConnectionSecretStoreName = "aws-secrets-manager"
+			},
+			wantErr: "connectionSecretStoreName requires manageMasterUserPassword and connectionSecretNamespace",
+		},
+		{
+			name: "invalid connection secret store kind",
+			modify: func(args *PostgresDbArgs) {
+				args.DbPassword = ""
+				args.ManageMasterUserPassword = true
+				args.ConnectionSecretNamespace = "pylon"
+				args.ConnectionSecretStoreName = "aws-secrets-manager"
+				args.ConnectionSecretStoreKind = "Vault"
+			},
+			wantErr: `connectionSecretStoreKind must be "SecretStore" or "ClusterSecretStore"`,
+		},
+		{
+			name:    "secret kms key without managed password",
+			modify:  func(args *PostgresDbArgs) { args.MasterUserSecretKmsKeyId = "alias/rds" },
+			wantErr: "masterUserSecretKmsKeyId requires manageMasterUserPassword",
+		},
+		{
+			name:    "missing subnet group",
+			modify:  func(args *PostgresDbArgs) { args.DbSubnetGroupName = "" },
+			wantErr: "dbSubnetGroupName or subnetIds is required",
+		},
+		{
+			name:    "subnet group and subnet ids",
+			modify:  func(args *PostgresDbArgs) { args.SubnetIds = []string{"subnet-a"} },
+			wantErr: "dbSubnetGroupName and subnetIds are mutually exclusive",
+		},
+		{
+			name:    "capacity out of range",
+			modify:  func(args *PostgresDbArgs) { args.MaxCapacity = 512 },
+			wantErr: "maxCapacity must be between 0 and 256",
+		},
+		{
+			name:    "max below min",
+			modify:  func(args *PostgresDbArgs) { args.MinCapacity = 4 },
+			wantErr: "maxCapacity must be greater than or equal to minCapacity",
+		},
+		{
+			name:    "too many readers",
+			modify:  func(args *PostgresDbArgs) { args.ReaderCount = 16 },
+			wantErr: "readerCount must be between 0 and 15",
+		},
+		{
+			name:    "backup retention out of range",
+			modify:  func(args *PostgresDbArgs) { args.BackupRetentionPeriod = 36 },
+			wantErr: "backupRetentionPeriod must be between 0 and 35 days",
+		},
+		{
+			name: "parameter group name and parameters",
+			modify: func(args *PostgresDbArgs) {
+				args.ClusterParameterGroupName = "custom"
+				args.ClusterParameters = map[string]string{"timezone": "UTC"}
+			},
+			wantErr: "clusterParameterGroupName and clusterParameters are mutually exclusive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := validArgs
+			tc.modify(&args)
+			err := validateDb(args)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+// TestS3BucketArgsValidate tests the validation of S3BucketArgs
+func TestS3BucketArgsValidate(t *testing.T) {
+	validArgs := S3BucketArgs{
+		Name:       "pylon-blob-bucket",
+		BucketName: "pylon-blobs",
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(args *S3BucketArgs)
+		wantErr string
+	}{
+		{
+			name:   "valid with defaults",
+			modify: func(args *S3BucketArgs) {},
+		},
+		{
+			name: "valid with lifecycle and replication",
+			modify: func(args *S3BucketArgs) {
+				args.Versioning = true
+				args.Lifecycle = &S3LifecycleArgs{InfrequentAccessAfterDays: 30, GlacierAfterDays: 90, ExpireAfterDays: 365}
+				args.Replication = &S3ReplicationArgs{
+					DestinationBucketArn: "arn:aws:s3:::pylon-blobs-replica",
+					DestinationKmsKeyArn: "arn:aws:kms:us-west-2:123456789012:key/replica",
+				}
+			},
+		},
+		{
+			name:    "missing name",
+			modify:  func(args *S3BucketArgs) { args.Name = "" },
+			wantErr: "name is required",
+		},
+		{
+			name:    "missing bucket name",
+			modify:  func(args *S3BucketArgs) { args.BucketName = "" },
+			wantErr: "bucket name is required",
+		},
+		{
+			name:    "empty lifecycle",
+			modify:  func(args *S3BucketArgs) { args.Lifecycle = &S3LifecycleArgs{} },
+			wantErr: "invalid lifecycle: at least one lifecycle age is required",
+		},
+		{
+			name:    "infrequent access too early",
+			modify:  func(args *S3BucketArgs) { args.Lifecycle = &S3LifecycleArgs{InfrequentAccessAfterDays: 7} },
+			wantErr: "invalid lifecycle: infrequent access transition must be at least 30 days",
+		},
+		{
+			name: "glacier before infrequent access",
+			modify: func(args *S3BucketArgs) {
+				args.Lifecycle = &S3LifecycleArgs{InfrequentAccessAfterDays: 60, GlacierAfterDays: 30}
+			},
+			wantErr: "invalid lifecycle: glacier transition must be after the infrequent access transition",
+		},
+		{
+			name:    "expiration before transition",
+			modify:  func(args *S3BucketArgs) { args.Lifecycle = &S3LifecycleArgs{GlacierAfterDays: 90, ExpireAfterDays: 60} },
+			wantErr: "invalid lifecycle: expiration must be after every transition",
+		},
+		{
+			name: "replication without versioning",
+			modify: func(args *S3BucketArgs) {
+				args.Replication = &S3ReplicationArgs{DestinationBucketArn: "arn:aws:s3:::replica", DestinationKmsKeyArn: "key"}
+			},
+			wantErr: "replication requires versioning",
+		},
+		{
+			name: "replication without destination key",
+			modify: func(args *S3BucketArgs) {
+				args.Versioning = true
+				args.Replication = &S3ReplicationArgs{DestinationBucketArn: "arn:aws:s3:::replica"}
+			},
+			wantErr: "invalid replication: destination kms key arn is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := validArgs
+			tc.modify(&args)
+			err := args.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+// TestVpcArgsValidate tests the validation of VpcArgs
+func TestVpcArgsValidate(t *testing.T) {
+	validArgs := VpcArgs{
+		Name:              "signet",
+		AvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+	}
+	assert.NoError(t, validArgs.Validate())
+
+	invalidArgs1 := validArgs
+	invalidArgs1.Name = ""
+	assert.EqualError(t, invalidArgs1.Validate(), "name is required")
+
+	invalidArgs2 := validArgs
+	invalidArgs2.CidrBlock = "10.0.0.0/8"
+	assert.EqualError(t, invalidArgs2.Validate(), "cidr block prefix must be between /16 and /24")
+
+	invalidArgs3 := validArgs
+	invalidArgs3.CidrBlock = "not-a-cidr"
+	assert.EqualError(t, invalidArgs3.Validate(), "cidr block must be an IPv4 CIDR")
+
+	invalidArgs4 := validArgs
+	invalidArgs4.AvailabilityZones = []string{"us-east-1a"}
+	assert.EqualError(t, invalidArgs4.Validate(), "between 2 and 8 availability zones are required")
+
+	invalidArgs5 := validArgs
+	invalidArgs5.AvailabilityZones = []string{"us-east-1a", "us-east-1a"}
+	assert.EqualError(t, invalidArgs5.Validate(), "duplicate availability zone us-east-1a")
+}
+
+// TestEksClusterArgsValidate tests the validation of EksClusterArgs
+func TestEksClusterArgsValidate(t *testing.T) {
+	nodeGroup := EksNodeGroupArgs{
+		Name:          "general",
+		InstanceTypes: []string{"m6i.xlarge"},
+		MinSize:       1,
+		MaxSize:       3,
+	}
+	validArgs := EksClusterArgs{
+		Name:       "signet",
+		SubnetIds:  []string{"subnet-a", "subnet-b"},
+		NodeGroups: []EksNodeGroupArgs{nodeGroup},
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(args *EksClusterArgs)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(args *EksClusterArgs) {},
+		},
+		{
+			name:    "missing name",
+			modify:  func(args *EksClusterArgs) { args.Name = "" },
+			wantErr: "name is required",
+		},
+		{
+			name:    "missing subnets",
+			modify:  func(args *EksClusterArgs) { args.SubnetIds = nil },
+			wantErr: "vpc or subnet ids is required",
+		},
+		{
+			name:    "vpc and subnets",
+			modify:  func(args *EksClusterArgs) { args.Vpc = &VpcComponent{} },
+			wantErr: "vpc and subnet ids are mutually exclusive",
+		},
+		{
+			name:    "no node groups",
+			modify:  func(args *EksClusterArgs) { args.NodeGroups = nil },
+			wantErr: "at least one node group is required",
+		},
+		{
+			name:    "duplicate node groups",
+			modify:  func(args *EksClusterArgs) { args.NodeGroups = []EksNodeGroupArgs{nodeGroup, nodeGroup} },
+			wantErr: "duplicate node group general",
+		},
+		{
+			name: "desired size out of range",
+			modify: func(args *EksClusterArgs) {
+				args.NodeGroups[0].DesiredSize = 5
+			},
+			wantErr: "invalid node group general: desired size must be between min size and max size",
+		},
+		{
+			name: "invalid capacity type",
+			modify: func(args *EksClusterArgs) {
+				args.NodeGroups[0].CapacityType = "RESERVED"
+			},
+			wantErr: "invalid node group general: capacity type must be ON_DEMAND or SPOT",
+		},
+		{
+			name: "invalid taint effect",
+			modify: func(args *EksClusterArgs) {
+				args.NodeGroups[0].Taints = []EksNodeGroupTaint{{Key: "dedicated", Effect: "NoSchedule"}}
+			},
+			wantErr: "invalid node group general: taint effect must be NO_SCHEDULE, NO_EXECUTE or PREFER_NO_SCHEDULE",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := validArgs
+			args.NodeGroups = append([]EksNodeGroupArgs(nil), validArgs.NodeGroups...)
+			tc.modify(&args)
+			err := args.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantErr, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package aws
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ec2"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/rds"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// NewVpcComponent creates a VPC with a public and a private subnet in each availability
+// zone, an internet gateway, NAT gateways for the private subnets and a DB subnet group
+// spanning the private subnets.
+//
+// Subnet prefixes are VpcSubnetNewBits longer than the VPC's, i.e. /20s in a /16. Public
+// subnets take the first ranges and private subnets start at VpcPrivateSubnetOffset.
+func NewVpcComponent(ctx *pulumi.Context, args *VpcArgs, opts ...pulumi.ResourceOption) (*VpcComponent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid vpc args: %w", err)
+	}
+
+	internalArgs := args.toInternal()
+	component := &VpcComponent{}
+
+	err := ctx.RegisterComponentResource("signet:index:Vpc", args.Name, component, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcName := fmt.Sprintf("%s%s", args.Name, VpcSuffix)
+	vpc, err := ec2.NewVpc(ctx, vpcName, &ec2.VpcArgs{
+		CidrBlock:          pulumi.String(internalArgs.CidrBlock),
+		EnableDnsHostnames: pulumi.Bool(true),
+		EnableDnsSupport:   pulumi.Bool(true),
+		Tags:               vpcTags(vpcName, internalArgs.ClusterName, ""),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vpc: %w", err)
+	}
+	component.Vpc = vpc
+
+	igwName := fmt.Sprintf("%s%s", args.Name, InternetGatewaySuffix)
+	igw, err := ec2.NewInternetGateway(ctx, igwName, &ec2.InternetGatewayArgs{
+		VpcId: vpc.ID(),
+		Tags:  vpcTags(igwName, "", ""),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create internet gateway: %w", err)
+	}
+	component.InternetGateway = igw
+
+	publicRouteTableName := fmt.Sprintf("%s%s", args.Name, PublicRouteTableSuffix)
+	publicRouteTable, err := ec2.NewRouteTable(ctx, publicRouteTableName, &ec2.RouteTableArgs{
+		VpcId: vpc.ID(),
+		Routes: ec2.RouteTableRouteArray{
+			ec2.RouteTableRouteArgs{
+				CidrBlock: pulumi.String(AllIPv4CidrBlock),
+				GatewayId: igw.ID(),
+			},
+		},
+		Tags: vpcTags(publicRouteTableName, "", ""),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public route table: %w", err)
+	}
+
+	var publicSubnetIds, privateSubnetIds pulumi.StringArray
+	for i, zone := range internalArgs.AvailabilityZones {
+		publicCidr, err := cidrSubnet(internalArgs.CidrBlock, VpcSubnetNewBits, i)
+		if err != nil {
+			return nil, err
+		}
+		privateCidr, err := cidrSubnet(internalArgs.CidrBlock, VpcSubnetNewBits, VpcPrivateSubnetOffset+i)
+		if err != nil {
+			return nil, err
+		}
+
+		publicName := fmt.Sprintf("%s%s-%s", args.Name, PublicSubnetSuffix, zone)
+		publicSubnet, err := ec2.NewSubnet(ctx, publicName, &ec2.SubnetArgs{
+			VpcId:               vpc.ID(),
+			CidrBlock:           pulumi.String(publicCidr),
+			AvailabilityZone:    pulumi.String(zone),
+			MapPublicIpOnLaunch: pulumi.Bool(true),
+			Tags:                vpcTags(publicName, internalArgs.ClusterName, ElbRoleTag),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create public subnet: %w", err)
+		}
+		component.PublicSubnets = append(component.PublicSubnets, publicSubnet)
+		publicSubnetIds = append(publicSubnetIds, publicSubnet.ID())
+
+		_, err = ec2.NewRouteTableAssociation(ctx, fmt.Sprintf("%s%s", publicName, RouteTableAssociationSuffix), &ec2.RouteTableAssociationArgs{
+			SubnetId:     publicSubnet.ID(),
+			RouteTableId: publicRouteTable.ID(),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to associate public route table: %w", err)
+		}
+
+		// One NAT gateway per zone keeps private subnets online when a zone fails,
+		// unless a single shared gateway was requested to save cost
+		if i == 0 || !internalArgs.SingleNatGateway {
+			natName := fmt.Sprintf("%s%s-%s", args.Name, NatGatewaySuffix, zone)
+			eip, err := ec2.NewEip(ctx, fmt.Sprintf("%s%s-%s", args.Name, NatEipSuffix, zone), &ec2.EipArgs{
+				Domain: pulumi.String(EipDomainVpc),
+				Tags:   vpcTags(natName, "", ""),
+			}, pulumi.Parent(component))
+			if err != nil {
+				return nil, fmt.Errorf("failed to allocate NAT elastic ip: %w", err)
+			}
+
+			natGateway, err := ec2.NewNatGateway(ctx, natName, &ec2.NatGatewayArgs{
+				AllocationId: eip.ID(),
+				SubnetId:     publicSubnet.ID(),
+				Tags:         vpcTags(natName, "", ""),
+			}, pulumi.Parent(component), pulumi.DependsOn([]pulumi.Resource{igw}))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create NAT gateway: %w", err)
+			}
+			component.NatGateways = append(component.NatGateways, natGateway)
+		}
+		natGateway := component.NatGateways[len(component.NatGateways)-1]
+
+		privateName := fmt.Sprintf("%s%s-%s", args.Name, PrivateSubnetSuffix, zone)
+		privateSubnet, err := ec2.NewSubnet(ctx, privateName, &ec2.SubnetArgs{
+			VpcId:            vpc.ID(),
+			CidrBlock:        pulumi.String(privateCidr),
+			AvailabilityZone: pulumi.String(zone),
+			Tags:             vpcTags(privateName, internalArgs.ClusterName, InternalElbRoleTag),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create private subnet: %w", err)
+		}
+		component.PrivateSubnets = append(component.PrivateSubnets, privateSubnet)
+		privateSubnetIds = append(privateSubnetIds, privateSubnet.ID())
+
+		privateRouteTableName := fmt.Sprintf("%s%s-%s", args.Name, PrivateRouteTableSuffix, zone)
+		privateRouteTable, err := ec2.NewRouteTable(ctx, privateRouteTableName, &ec2.RouteTableArgs{
+			VpcId: vpc.ID(),
+			Routes: ec2.RouteTableRouteArray{
+				ec2.RouteTableRouteArgs{
+					CidrBlock:    pulumi.String(AllIPv4CidrBlock),
+					NatGatewayId: natGateway.ID(),
+				},
+			},
+			Tags: vpcTags(privateRouteTableName, "", ""),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create private route table: %w", err)
+		}
+
+		_, err = ec2.NewRouteTableAssociation(ctx, fmt.Sprintf("%s%s", privateName, RouteTableAssociationSuffix), &ec2.RouteTableAssociationArgs{
+			SubnetId:     privateSubnet.ID(),
+			RouteTableId: privateRouteTable.ID(),
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to associate private route table: %w", err)
+		}
+	}
+
+	dbSubnetGroupName := kubernetesName(fmt.Sprintf("%s%s", args.Name, VpcDbSubnetGroupSuffix))
+	dbSubnetGroup, err := rds.NewSubnetGroup(ctx, fmt.Sprintf("%s%s", args.Name, VpcDbSubnetGroupSuffix), &rds.SubnetGroupArgs{
+		Name:      pulumi.String(dbSubnetGroupName),
+		SubnetIds: privateSubnetIds,
+		Tags:      vpcTags(dbSubnetGroupName, "", ""),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create db subnet group: %w", err)
+	}
+	component.DbSubnetGroup = dbSubnetGroup
+	component.DbSubnetGroupName = dbSubnetGroupName
+
+	component.VpcId = vpc.ID().ToStringOutput()
+	component.PublicSubnetIds = publicSubnetIds.ToStringArrayOutput()
+	component.PrivateSubnetIds = privateSubnetIds.ToStringArrayOutput()
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"vpcId":             component.VpcId,
+		"publicSubnetIds":   component.PublicSubnetIds,
+		"privateSubnetIds":  component.PrivateSubnetIds,
+		"dbSubnetGroupName": dbSubnetGroup.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+// vpcTags returns the Name tag for a VPC resource, plus the cluster discovery tag when a
+// cluster name is given and the load balancer role tag when a role is given
+func vpcTags(name string, clusterName string, elbRole string) pulumi.StringMap {
+	tags := pulumi.StringMap{
+		"Name": pulumi.String(name),
+	}
+	if clusterName != "" {
+		tags[ClusterTagPrefix+clusterName] = pulumi.String(ClusterTagShared)
+	}
+	if elbRole != "" {
+		tags[elbRole] = pulumi.String("1")
+	}
+	return tags
+}
+
+// cidrSubnet returns the netNum'th subnet of an IPv4 CIDR block with newBits added to
+// its prefix, like Terraform's cidrsubnet
+func cidrSubnet(cidrBlock string, newBits int, netNum int) (string, error) {
+	_, network, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return "", fmt.Errorf("invalid cidr block %q: %w", cidrBlock, err)
+	}
+	base := network.IP.To4()
+	if base == nil {
+		return "", fmt.Errorf("cidr block %q is not IPv4", cidrBlock)
+	}
+
+	prefix, _ := network.Mask.Size()
+	newPrefix := prefix + newBits
+	if newPrefix > 32 {
+		return "", fmt.Errorf("cannot add %d bits to /%d", newBits, prefix)
+	}
+	if netNum < 0 || netNum >= 1<<newBits {
+		return "", fmt.Errorf("subnet number %d does not fit in %d bits", netNum, newBits)
+	}
+
+	address := binary.BigEndian.Uint32(base) | uint32(netNum)<<(32-newPrefix)
+	subnet := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(subnet, address)
+	return fmt.Sprintf("%s/%d", subnet, newPrefix), nil
+}
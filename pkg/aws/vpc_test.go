@@ -0,0 +1,45 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCidrSubnet(t *testing.T) {
+	testCases := []struct {
+		cidrBlock string
+		newBits   int
+		netNum    int
+		want      string
+	}{
+		{"10.0.0.0/16", 4, 0, "10.0.0.0/20"},
+		{"10.0.0.0/16", 4, 1, "10.0.16.0/20"},
+		{"10.0.0.0/16", 4, VpcPrivateSubnetOffset, "10.0.128.0/20"},
+		{"10.0.0.0/16", 4, 15, "10.0.240.0/20"},
+		{"172.16.0.0/20", 4, 3, "172.16.3.0/24"},
+	}
+
+	for _, tc := range testCases {
+		got, err := cidrSubnet(tc.cidrBlock, tc.newBits, tc.netNum)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got)
+	}
+
+	_, err := cidrSubnet("10.0.0.0/16", 4, 16)
+	assert.Error(t, err)
+	_, err = cidrSubnet("10.0.0.0/30", 4, 0)
+	assert.Error(t, err)
+	_, err = cidrSubnet("fd00::/48", 4, 0)
+	assert.Error(t, err)
+}
+
+func TestVpcTags(t *testing.T) {
+	assert.Equal(t, pulumi.StringMap{"Name": pulumi.String("signet-vpc")}, vpcTags("signet-vpc", "", ""))
+
+	tags := vpcTags("signet-private-us-east-1a", "signet", InternalElbRoleTag)
+	assert.Equal(t, pulumi.String(ClusterTagShared), tags["kubernetes.io/cluster/signet"])
+	assert.Equal(t, pulumi.String("1"), tags[InternalElbRoleTag])
+}
@@ -4,6 +4,7 @@ package builder
 import (
 	"fmt"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
@@ -48,6 +49,24 @@ func NewBuilder(ctx *pulumi.Context, args BuilderComponentArgs, opts ...pulumi.R
 	}
 	component.ServiceAccount = sa
 
+	// Bind an IAM role to the service account when using EKS Pod Identity
+	if internalArgs.PodIdentity != nil {
+		podIdentity, err := aws.CreatePodIdentityResources(
+			ctx,
+			args.Name,
+			args.Name,
+			*internalArgs.PodIdentity,
+			internalArgs.Namespace,
+			sa.Metadata.Name().Elem(),
+			internalArgs.SigningKey,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pod identity resources: %w", err)
+		}
+		component.PodIdentity = podIdentity
+	}
+
 	// Create ConfigMap for environment variables
 	configMapName := fmt.Sprintf("%s%s", args.Name, ConfigMapSuffix)
 	configMap, err := utils.CreateConfigMap(
@@ -55,13 +74,50 @@ func NewBuilder(ctx *pulumi.Context, args BuilderComponentArgs, opts ...pulumi.R
 		configMapName,
 		internalArgs.Namespace,
 		utils.CreateResourceLabels(args.Name, configMapName, args.Name, nil),
-		internalArgs.BuilderEnv,
+		internalArgs.configEnv(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create environment ConfigMap: %w", err)
 	}
 	component.ConfigMap = configMap
 
+	// Create Secret for sensitive environment variables, either synced from
+	// AWS Secrets Manager or populated from the builder env
+	var envSecretName pulumi.StringPtrInput
+	if internalArgs.ExternalSecretRef != nil {
+		externalSecretName := fmt.Sprintf("%s%s", args.Name, ExternalSecretSuffix)
+		externalSecret, err := utils.CreateExternalSecret(
+			ctx,
+			externalSecretName,
+			internalArgs.Namespace,
+			utils.CreateResourceLabels(args.Name, externalSecretName, args.Name, nil),
+			*internalArgs.ExternalSecretRef,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create external secret: %w", err)
+		}
+		component.ExternalSecret = externalSecret
+		envSecretName = pulumi.String(externalSecretName)
+	} else {
+		// The alias keeps the Secret created at the stack root before it was parented
+		secretName := fmt.Sprintf("%s%s", args.Name, SecretSuffix)
+		secret, err := utils.CreateSecretWithOptions(
+			ctx,
+			secretName,
+			internalArgs.Namespace,
+			utils.CreateResourceLabels(args.Name, secretName, args.Name, nil),
+			internalArgs.BuilderEnv,
+			pulumi.Parent(component),
+			pulumi.Aliases([]pulumi.Alias{{Name: pulumi.String(secretName), NoParent: pulumi.Bool(true)}}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create environment Secret: %w", err)
+		}
+		component.Secret = secret
+		envSecretName = secret.Metadata.Name()
+	}
+
 	// Create pod labels with app label for routing
 	podLabels := utils.CreateResourceLabels(args.Name, args.Name, args.Name, args.AppLabels.Labels)
 	podLabels["app"] = pulumi.String(args.Name)
@@ -95,6 +151,11 @@ func NewBuilder(ctx *pulumi.Context, args BuilderComponentArgs, opts ...pulumi.R
 										Name: component.ConfigMap.Metadata.Name(),
 									},
 								},
+								&corev1.EnvFromSourceArgs{
+									SecretRef: &corev1.SecretEnvSourceArgs{
+										Name: envSecretName,
+									},
+								},
 							},
 							Ports: corev1.ContainerPortArray{
 								&corev1.ContainerPortArgs{
@@ -25,10 +25,19 @@ const (
 	DeploymentSuffix     = "-deployment"
 	ServiceAccountSuffix = "-sa"
 	ConfigMapSuffix      = "-env"
+	SecretSuffix         = "-secret-env"
+	ExternalSecretSuffix = "-external-secret"
 	PodMonitorSuffix     = "-pod-monitor"
 	ServiceMonitorSuffix = "-svcmon"
 )
 
+// Env names of the sensitive BuilderEnv fields, which an ExternalSecretRef has to sync
+const (
+	AwsSecretAccessKeyEnvName = "AWS_SECRET_ACCESS_KEY"
+	BuilderKeyEnvName         = "BUILDER_KEY"
+	OauthClientSecretEnvName  = "OAUTH_CLIENT_SECRET"
+)
+
 // Health check paths
 const (
 	HealthCheckPath = "/healthcheck"
@@ -1,8 +1,12 @@
 package builder
 
 import (
+	"context"
 	"testing"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,7 +33,7 @@ func TestBuilderEnvGetEnvMap(t *testing.T) {
 	assert.True(t, hasBuilderPort, "BUILDER_PORT should be in the map")
 
 	_, hasBuilderKey := envMap["BUILDER_KEY"]
-	assert.True(t, hasBuilderKey, "BUILDER_KEY should be in the map")
+	assert.False(t, hasBuilderKey, "BUILDER_KEY should not be in the plain map")
 
 	_, hasHostRpcUrl := envMap["HOST_RPC_URL"]
 	assert.True(t, hasHostRpcUrl, "HOST_RPC_URL should be in the map")
@@ -37,3 +41,85 @@ func TestBuilderEnvGetEnvMap(t *testing.T) {
 	_, hasAwsRegion := envMap["AWS_REGION"]
 	assert.True(t, hasAwsRegion, "AWS_REGION should be in the map")
 }
+
+func TestBuilderEnvGetSecretEnvMap(t *testing.T) {
+	env := BuilderEnv{
+		BuilderKey:         "test-key",
+		OauthClientSecret:  "test-client-secret",
+		AwsSecretAccessKey: "test-secret-access-key",
+		AwsRegion:          "us-west-2",
+	}
+
+	secretMap := env.GetSecretEnvMap()
+
+	// Only fields tagged as secret should be present
+	assert.Len(t, secretMap, 3)
+	assert.Contains(t, secretMap, "BUILDER_KEY")
+	assert.Contains(t, secretMap, "OAUTH_CLIENT_SECRET")
+	assert.Contains(t, secretMap, "AWS_SECRET_ACCESS_KEY")
+	assert.NotContains(t, secretMap, "AWS_REGION")
+}
+
+func TestBuilderEnvSecretsAreMarkedSecret(t *testing.T) {
+	env := BuilderEnv{
+		BuilderKey:         "test-key",
+		OauthClientSecret:  "test-client-secret",
+		AwsSecretAccessKey: "test-secret-access-key",
+		AwsRegion:          "us-west-2",
+	}
+
+	// Every value routed into the Secret should also be a Pulumi secret
+	for key, value := range env.GetSecretEnvMap() {
+		result, err := internals.UnsafeAwaitOutput(context.Background(), value.ToStringOutput())
+		assert.NoError(t, err)
+		assert.True(t, result.Secret, "%s should be marked secret", key)
+	}
+
+	// Plain values should not be marked secret
+	result, err := internals.UnsafeAwaitOutput(context.Background(), env.GetEnvMap()["AWS_REGION"].ToStringOutput())
+	assert.NoError(t, err)
+	assert.False(t, result.Secret)
+}
+
+func TestBuilderPodIdentityOmitsStaticKeys(t *testing.T) {
+	args := BuilderComponentArgs{
+		BuilderEnv: BuilderEnv{
+			AwsAccessKeyId:     "test-access-key",
+			AwsSecretAccessKey: "test-secret-access-key",
+			AwsRegion:          "us-west-2",
+		},
+		PodIdentity: &aws.PodIdentityConfig{ClusterName: "signet-cluster"},
+	}
+
+	// Static keys would shadow pod identity credentials, so they are not rendered
+	internal := args.toInternal()
+	assert.NotContains(t, internal.BuilderEnv.GetEnvMap(), "AWS_ACCESS_KEY_ID")
+	assert.NotContains(t, internal.BuilderEnv.GetSecretEnvMap(), "AWS_SECRET_ACCESS_KEY")
+	assert.Contains(t, internal.BuilderEnv.GetEnvMap(), "AWS_REGION")
+}
+
+func TestBuilderSigningKeyIdInConfigMap(t *testing.T) {
+	args := BuilderComponentArgs{
+		BuilderEnv: BuilderEnv{
+			BuilderKey: "test-builder-key",
+			AwsRegion:  "us-west-2",
+		},
+		SigningKey: &aws.SigningKeyComponent{KeyId: pulumi.String("test-key-id").ToStringOutput()},
+	}
+
+	// The key ID must reach the pod even when the Secret is synced from an external store
+	internal := args.toInternal()
+	assert.NotContains(t, internal.BuilderEnv.GetSecretEnvMap(), "BUILDER_KEY")
+
+	envMap := internal.configEnv().GetEnvMap()
+	assert.Contains(t, envMap, "AWS_REGION")
+	keyId, err := internals.UnsafeAwaitOutput(context.Background(), envMap["BUILDER_KEY"].ToStringOutput())
+	assert.NoError(t, err)
+	assert.Equal(t, "test-key-id", keyId.Value)
+
+	// Without a signing key the builder key stays in the Secret
+	args.SigningKey = nil
+	internal = args.toInternal()
+	assert.Contains(t, internal.BuilderEnv.GetSecretEnvMap(), "BUILDER_KEY")
+	assert.NotContains(t, internal.configEnv().GetEnvMap(), "BUILDER_KEY")
+}
@@ -3,7 +3,9 @@ package builder
 import (
 	"strconv"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
+	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
@@ -19,19 +21,25 @@ type AppLabels struct {
 // Internal struct will use Pulumi types
 
 type BuilderComponentArgs struct {
-	Namespace  string     // k8s namespace to deploy the builder to
-	AppLabels  AppLabels  // Labels to apply to the builder pod
-	Name       string     // Builder name identifier
-	Image      string     // Builder docker image
-	BuilderEnv BuilderEnv // Builder environment variables
+	Namespace         string                   // k8s namespace to deploy the builder to
+	AppLabels         AppLabels                // Labels to apply to the builder pod
+	Name              string                   // Builder name identifier
+	Image             string                   // Builder docker image
+	BuilderEnv        BuilderEnv               // Builder environment variables
+	ExternalSecretRef *utils.ExternalSecretRef // Optional: sync sensitive env from AWS Secrets Manager instead of BuilderEnv
+	PodIdentity       *aws.PodIdentityConfig   // Optional: use EKS Pod Identity instead of static AWS access keys
+	SigningKey        *aws.SigningKeyComponent // Optional: sign with a KMS key instead of a raw BuilderKey, requires PodIdentity
 }
 
 type builderComponentArgsInternal struct {
-	Namespace  pulumi.StringInput
-	AppLabels  AppLabels
-	Name       string
-	Image      pulumi.StringInput
-	BuilderEnv builderEnvInternal
+	Namespace         pulumi.StringInput
+	AppLabels         AppLabels
+	Name              string
+	Image             pulumi.StringInput
+	BuilderEnv        builderEnvInternal
+	ExternalSecretRef *utils.ExternalSecretRef
+	PodIdentity       *aws.PodIdentityConfig
+	SigningKey        *aws.SigningKeyComponent
 }
 
 // Public-facing struct for builder environment variables
@@ -82,12 +90,12 @@ type builderEnvInternal struct {
 	AwsAccountId             pulumi.StringInput
 	AwsAccessKeyId           pulumi.StringInput
 	AwsRegion                pulumi.StringInput
-	AwsSecretAccessKey       pulumi.StringInput
+	AwsSecretAccessKey       pulumi.StringInput `secret:"true"`
 	BlockConfirmationBuffer  pulumi.StringInput
 	BlockQueryCutoff         pulumi.StringInput
 	BlockQueryStart          pulumi.StringInput
 	BuilderHelperAddress     pulumi.StringInput
-	BuilderKey               pulumi.StringInput
+	BuilderKey               pulumi.StringInput `secret:"true"`
 	BuilderPort              pulumi.StringInput
 	BuilderRewardsAddress    pulumi.StringInput
 	ChainOffset              pulumi.StringInput
@@ -97,7 +105,7 @@ type builderEnvInternal struct {
 	OauthAudience            pulumi.StringInput
 	OauthAuthenticateUrl     pulumi.StringInput
 	OAuthClientId            pulumi.StringInput
-	OauthClientSecret        pulumi.StringInput
+	OauthClientSecret        pulumi.StringInput `secret:"true"`
 	OauthIssuer              pulumi.StringInput
 	OauthTokenUrl            pulumi.StringInput
 	OtelExporterOtlpEndpoint pulumi.StringInput
@@ -118,13 +126,51 @@ type builderEnvInternal struct {
 
 // Conversion function for BuilderComponentArgs
 func (args BuilderComponentArgs) toInternal() builderComponentArgsInternal {
-	return builderComponentArgsInternal{
-		Namespace:  pulumi.String(args.Namespace),
-		AppLabels:  args.AppLabels,
-		Name:       args.Name,
-		Image:      pulumi.String(args.Image),
-		BuilderEnv: args.BuilderEnv.toInternal(),
+	internal := builderComponentArgsInternal{
+		Namespace:         pulumi.String(args.Namespace),
+		AppLabels:         args.AppLabels,
+		Name:              args.Name,
+		Image:             pulumi.String(args.Image),
+		BuilderEnv:        args.BuilderEnv.toInternal(),
+		ExternalSecretRef: args.ExternalSecretRef,
+		PodIdentity:       args.PodIdentity,
+		SigningKey:        args.SigningKey,
 	}
+	if args.SigningKey != nil {
+		// The key ID is written to the ConfigMap instead, see configEnv
+		internal.BuilderEnv.BuilderKey = nil
+	}
+	if args.PodIdentity != nil {
+		internal.BuilderEnv.AwsAccessKeyId = nil
+		internal.BuilderEnv.AwsSecretAccessKey = nil
+	}
+	return internal
+}
+
+// configEnv returns the env written to the ConfigMap. The builder loads a KMS signer when
+// BUILDER_KEY is a key ID, and a key ID is not sensitive, so with a signing key it is kept
+// out of the Secret, which is not created when env is synced from an external store
+func (args builderComponentArgsInternal) configEnv() builderConfigEnv {
+	env := builderConfigEnv{builderEnvInternal: args.BuilderEnv}
+	if args.SigningKey != nil {
+		env.SigningKeyId = args.SigningKey.KeyId
+	}
+	return env
+}
+
+// builderConfigEnv is the plain builder env plus the optional KMS signing key ID
+type builderConfigEnv struct {
+	builderEnvInternal
+	SigningKeyId pulumi.StringInput
+}
+
+// GetEnvMap implements the utils.EnvProvider interface, adding the signing key ID as BUILDER_KEY
+func (e builderConfigEnv) GetEnvMap() pulumi.StringMap {
+	envMap := e.builderEnvInternal.GetEnvMap()
+	if e.SigningKeyId != nil {
+		envMap[BuilderKeyEnvName] = e.SigningKeyId
+	}
+	return envMap
 }
 
 // Conversion function for BuilderEnv
@@ -134,12 +180,12 @@ func (e BuilderEnv) toInternal() builderEnvInternal {
 		AwsAccountId:             pulumi.String(e.AwsAccountId),
 		AwsAccessKeyId:           pulumi.String(e.AwsAccessKeyId),
 		AwsRegion:                pulumi.String(e.AwsRegion),
-		AwsSecretAccessKey:       pulumi.String(e.AwsSecretAccessKey),
+		AwsSecretAccessKey:       utils.SecretString(e.AwsSecretAccessKey),
 		BlockConfirmationBuffer:  pulumi.String(strconv.Itoa(e.BlockConfirmationBuffer)),
 		BlockQueryCutoff:         pulumi.String(strconv.Itoa(e.BlockQueryCutoff)),
 		BlockQueryStart:          pulumi.String(strconv.Itoa(e.BlockQueryStart)),
 		BuilderHelperAddress:     pulumi.String(e.BuilderHelperAddress),
-		BuilderKey:               pulumi.String(e.BuilderKey),
+		BuilderKey:               utils.SecretString(e.BuilderKey),
 		BuilderPort:              pulumi.String(strconv.Itoa(e.BuilderPort)),
 		BuilderRewardsAddress:    pulumi.String(e.BuilderRewardsAddress),
 		ChainOffset:              pulumi.String(strconv.Itoa(e.ChainOffset)),
@@ -149,7 +195,7 @@ func (e BuilderEnv) toInternal() builderEnvInternal {
 		OauthAudience:            pulumi.String(e.OauthAudience),
 		OauthAuthenticateUrl:     pulumi.String(e.OauthAuthenticateUrl),
 		OAuthClientId:            pulumi.String(e.OAuthClientId),
-		OauthClientSecret:        pulumi.String(e.OauthClientSecret),
+		OauthClientSecret:        utils.SecretString(e.OauthClientSecret),
 		OauthIssuer:              pulumi.String(e.OauthIssuer),
 		OauthTokenUrl:            pulumi.String(e.OauthTokenUrl),
 		OtelExporterOtlpEndpoint: pulumi.String(e.OtelExporterOtlpEndpoint),
@@ -171,7 +217,14 @@ func (e BuilderEnv) toInternal() builderEnvInternal {
 
 // GetEnvMap implements the utils.EnvProvider interface for internal env
 func (e builderEnvInternal) GetEnvMap() pulumi.StringMap {
-	return utils.CreateEnvMap(e)
+	envMap, _ := utils.CreateEnvMap(e)
+	return envMap
+}
+
+// GetSecretEnvMap implements the utils.SecretEnvProvider interface for internal env
+func (e builderEnvInternal) GetSecretEnvMap() pulumi.StringMap {
+	_, secretMap := utils.CreateEnvMap(e)
+	return secretMap
 }
 
 // GetEnvMap returns the environment variables as a pulumi.StringMap for the public BuilderEnv
@@ -179,6 +232,11 @@ func (e BuilderEnv) GetEnvMap() pulumi.StringMap {
 	return e.toInternal().GetEnvMap()
 }
 
+// GetSecretEnvMap returns the sensitive environment variables as a pulumi.StringMap for the public BuilderEnv
+func (e BuilderEnv) GetSecretEnvMap() pulumi.StringMap {
+	return e.toInternal().GetSecretEnvMap()
+}
+
 type Builder interface {
 	GetServiceURL() pulumi.StringOutput
 	GetMetricsURL() pulumi.StringOutput
@@ -192,6 +250,9 @@ type BuilderComponent struct {
 	Service              *corev1.Service
 	ServiceAccount       *corev1.ServiceAccount
 	ConfigMap            *corev1.ConfigMap
+	Secret               *corev1.Secret
+	ExternalSecret       *crd.CustomResource
+	PodIdentity          *aws.PodIdentityResources
 }
 
 // Ensure BuilderComponent implements Builder
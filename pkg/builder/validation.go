@@ -18,30 +18,85 @@ func (args *BuilderComponentArgs) Validate() error {
 	if args.AppLabels.Labels == nil {
 		return fmt.Errorf("app labels are required")
 	}
-	return args.BuilderEnv.Validate()
+	required := envRequirements{secrets: true, staticCredentials: true, builderKey: true}
+	if args.ExternalSecretRef != nil {
+		if err := args.ExternalSecretRef.Validate(); err != nil {
+			return fmt.Errorf("invalid external secret ref: %w", err)
+		}
+		// Sensitive values are synced from the external store
+		required.secrets = false
+	}
+	if args.PodIdentity != nil {
+		if err := args.PodIdentity.Validate(); err != nil {
+			return fmt.Errorf("invalid pod identity config: %w", err)
+		}
+		// AWS credentials are provided by EKS Pod Identity
+		required.staticCredentials = false
+	}
+	if args.SigningKey != nil {
+		// kms:Sign is granted to the pod identity role, so a signing key needs one
+		if args.PodIdentity == nil {
+			return fmt.Errorf("signing key requires pod identity")
+		}
+		// The builder key is the KMS signing key ID
+		required.builderKey = false
+	}
+	if args.ExternalSecretRef != nil {
+		if err := args.ExternalSecretRef.RequireEnvNames(required.secretEnvNames()...); err != nil {
+			return fmt.Errorf("invalid external secret ref: %w", err)
+		}
+	}
+	return args.BuilderEnv.validate(required)
+}
+
+// envRequirements selects which BuilderEnv fields are required, since some may be
+// provided by an external secret store, EKS Pod Identity or a KMS signing key. When
+// credentials come from EKS Pod Identity, static keys are left out of the env.
+type envRequirements struct {
+	secrets           bool
+	staticCredentials bool
+	builderKey        bool
+}
+
+// secretEnvNames returns the env names of the required sensitive fields, ignoring
+// whether they come from BuilderEnv or an external secret store
+func (required envRequirements) secretEnvNames() []string {
+	names := []string{OauthClientSecretEnvName}
+	if required.staticCredentials {
+		names = append(names, AwsSecretAccessKeyEnvName)
+	}
+	if required.builderKey {
+		names = append(names, BuilderKeyEnvName)
+	}
+	return names
 }
 
 // Validate validates the BuilderEnv
 func (env *BuilderEnv) Validate() error {
+	return env.validate(envRequirements{secrets: true, staticCredentials: true, builderKey: true})
+}
+
+// validate checks required BuilderEnv fields, skipping those not in required
+func (env *BuilderEnv) validate(required envRequirements) error {
 	if env.AuthTokenRefreshInterval == "" {
 		return fmt.Errorf("auth token refresh interval is required")
 	}
 	if env.AwsAccountId == "" {
 		return fmt.Errorf("aws account id is required")
 	}
-	if env.AwsAccessKeyId == "" {
+	if required.staticCredentials && env.AwsAccessKeyId == "" {
 		return fmt.Errorf("aws access key id is required")
 	}
 	if env.AwsRegion == "" {
 		return fmt.Errorf("aws region is required")
 	}
-	if env.AwsSecretAccessKey == "" {
+	if required.secrets && required.staticCredentials && env.AwsSecretAccessKey == "" {
 		return fmt.Errorf("aws secret access key is required")
 	}
 	if env.BuilderHelperAddress == "" {
 		return fmt.Errorf("builder helper address is required")
 	}
-	if env.BuilderKey == "" {
+	if required.secrets && required.builderKey && env.BuilderKey == "" {
 		return fmt.Errorf("builder key is required")
 	}
 	if env.BuilderPort == 0 {
@@ -71,7 +126,7 @@ func (env *BuilderEnv) Validate() error {
 	if env.OAuthClientId == "" {
 		return fmt.Errorf("oauth client ID is required")
 	}
-	if env.OauthClientSecret == "" {
+	if required.secrets && env.OauthClientSecret == "" {
 		return fmt.Errorf("oauth client secret is required")
 	}
 	if env.OauthIssuer == "" {
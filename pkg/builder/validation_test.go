@@ -3,6 +3,8 @@ package builder
 import (
 	"testing"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/stretchr/testify/assert"
 )
@@ -114,5 +116,79 @@ func TestBuilderComponentArgsValidate(t *testing.T) {
 		}
 		err := args.Validate()
 		assert.NoError(t, err)
+
+		// Sensitive fields are required without an external secret ref
+		args.BuilderEnv.BuilderKey = ""
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "builder key is required", err.Error())
+
+		// Sensitive fields may be omitted when synced from an external store
+		args.BuilderEnv.AwsSecretAccessKey = ""
+		args.BuilderEnv.OauthClientSecret = ""
+		args.ExternalSecretRef = &utils.ExternalSecretRef{
+			SecretStoreName: "aws-secrets-manager",
+			Data: []utils.ExternalSecretData{
+				{EnvName: "BUILDER_KEY", RemoteKey: "signet/builder"},
+			},
+		}
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "invalid external secret ref: no data entry for env name OAUTH_CLIENT_SECRET", err.Error())
+
+		// Every required sensitive env name has to be synced
+		args.ExternalSecretRef.Data = append(args.ExternalSecretRef.Data,
+			utils.ExternalSecretData{EnvName: "OAUTH_CLIENT_SECRET", RemoteKey: "signet/builder"},
+		)
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "invalid external secret ref: no data entry for env name AWS_SECRET_ACCESS_KEY", err.Error())
+
+		args.ExternalSecretRef.Data = append(args.ExternalSecretRef.Data,
+			utils.ExternalSecretData{EnvName: "AWS_SECRET_ACCESS_KEY", RemoteKey: "signet/builder"},
+		)
+		err = args.Validate()
+		assert.NoError(t, err)
+
+		// An invalid external secret ref is rejected
+		args.ExternalSecretRef.SecretStoreName = ""
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid external secret ref")
+
+		// Static AWS keys are required without pod identity
+		args.ExternalSecretRef = nil
+		args.BuilderEnv.BuilderKey = "test-builder-key"
+		args.BuilderEnv.OauthClientSecret = "test-client-secret"
+		args.BuilderEnv.AwsAccessKeyId = ""
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "aws access key id is required", err.Error())
+
+		// Static AWS keys may be omitted when using EKS pod identity
+		args.PodIdentity = &aws.PodIdentityConfig{ClusterName: "signet-cluster"}
+		err = args.Validate()
+		assert.NoError(t, err)
+
+		// An invalid pod identity config is rejected
+		args.PodIdentity.ClusterName = ""
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid pod identity config")
+
+		// A KMS signing key is rejected without pod identity, which grants kms:Sign
+		args.PodIdentity = nil
+		args.BuilderEnv.AwsAccessKeyId = "test-access-key"
+		args.BuilderEnv.AwsSecretAccessKey = "test-secret-access-key"
+		args.BuilderEnv.BuilderKey = ""
+		args.SigningKey = &aws.SigningKeyComponent{}
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "signing key requires pod identity", err.Error())
+
+		// The builder key may be omitted when a KMS signing key is provided
+		args.PodIdentity = &aws.PodIdentityConfig{ClusterName: "signet-cluster"}
+		err = args.Validate()
+		assert.NoError(t, err)
 	})
 }
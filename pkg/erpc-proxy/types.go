@@ -156,18 +156,12 @@ type erpcProxyEnvInternal struct {
 
 // toInternal converts public args to internal args
 func (args ErpcProxyComponentArgs) toInternal() erpcProxyComponentArgsInternal {
-	// Convert ApiKeys map to pulumi.StringMap
-	apiKeysMap := make(pulumi.StringMap)
-	for k, v := range args.ApiKeys {
-		apiKeysMap[k] = pulumi.String(v)
-	}
-
 	return erpcProxyComponentArgsInternal{
 		Namespace: pulumi.String(args.Namespace),
 		Name:      pulumi.String(args.Name),
 		Image:     pulumi.String(args.Image),
 		Config:    args.Config.toInternal(),
-		ApiKeys:   apiKeysMap,
+		ApiKeys:   utils.SecretStringMap(args.ApiKeys),
 		Resources: args.Resources.toInternal(),
 		Replicas:  pulumi.Int(args.Replicas),
 	}
@@ -213,5 +207,6 @@ func (r ErpcProxyResources) toInternal() erpcProxyResourcesInternal {
 
 // GetEnvMap implements the utils.EnvProvider interface for internal env
 func (e erpcProxyEnvInternal) GetEnvMap() pulumi.StringMap {
-	return utils.CreateEnvMap(e)
+	envMap, _ := utils.CreateEnvMap(e)
+	return envMap
 }
@@ -2,7 +2,9 @@ package consensus
 
 import (
 	"fmt"
+	"path"
 
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
@@ -29,46 +31,23 @@ func NewConsensusClient(ctx *pulumi.Context, args *ConsensusClientArgs, opts ...
 		return nil, fmt.Errorf("failed to register component resource: %w", err)
 	}
 
-	// Create PVC for data storage
-	pvcName := fmt.Sprintf("%s-data", args.Name)
-	component.PVC, err = corev1.NewPersistentVolumeClaim(ctx, pvcName, &corev1.PersistentVolumeClaimArgs{
-		Metadata: &metav1.ObjectMetaArgs{
-			Namespace: internalArgs.Namespace,
-			Labels:    utils.CreateResourceLabels(args.Name, pvcName, args.Name, nil),
-		},
-		Spec: &corev1.PersistentVolumeClaimSpecArgs{
-			AccessModes: pulumi.StringArray{
-				pulumi.String("ReadWriteOnce"),
+	// Mount the given JWT secret, or create one from JWTSecret
+	var jwtSecretName pulumi.StringPtrInput = pulumi.String(args.JWTSecretName)
+	if args.JWTSecretName == "" {
+		secretName := fmt.Sprintf("%s-jwt", args.Name)
+		component.JWTSecret, err = corev1.NewSecret(ctx, secretName, &corev1.SecretArgs{
+			StringData: pulumi.StringMap{
+				JWTFileName: internalArgs.JWTSecret,
 			},
-			Resources: &corev1.VolumeResourceRequirementsArgs{
-				Requests: pulumi.StringMap{
-					"storage": internalArgs.StorageSize,
-				},
+			Metadata: &metav1.ObjectMetaArgs{
+				Namespace: internalArgs.Namespace,
+				Labels:    utils.CreateResourceLabels(args.Name, secretName, args.Name, nil),
 			},
-			StorageClassName: internalArgs.StorageClass,
-		},
-	}, pulumi.Parent(component))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create PVC: %w", err)
-	}
-
-	pvcNameInput := component.PVC.Metadata.Name().ApplyT(func(name *string) pulumi.StringInput {
-		return pulumi.String(*name)
-	}).(pulumi.StringInput)
-
-	// Create JWT secret
-	jwtSecretName := fmt.Sprintf("%s-jwt", args.Name)
-	component.JWTSecret, err = corev1.NewSecret(ctx, jwtSecretName, &corev1.SecretArgs{
-		StringData: pulumi.StringMap{
-			"jwt.hex": internalArgs.JWTSecret,
-		},
-		Metadata: &metav1.ObjectMetaArgs{
-			Namespace: internalArgs.Namespace,
-			Labels:    utils.CreateResourceLabels(args.Name, jwtSecretName, args.Name, nil),
-		},
-	}, pulumi.Parent(component))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create JWT secret: %w", err)
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create JWT secret: %w", err)
+		}
+		jwtSecretName = component.JWTSecret.Metadata.Name()
 	}
 
 	// Create P2P service
@@ -127,13 +106,46 @@ func NewConsensusClient(ctx *pulumi.Context, args *ConsensusClientArgs, opts ...
 
 	// Create StatefulSet
 	statefulSetName := args.Name
+
+	// Create the headless service governing the StatefulSet, giving each replica a stable DNS name
+	headlessServiceName := fmt.Sprintf("%s%s", statefulSetName, utils.HeadlessServiceSuffix)
+	component.HeadlessService, err = utils.CreateHeadlessService(
+		ctx,
+		headlessServiceName,
+		internalArgs.Namespace,
+		pulumi.StringMap{
+			"app": pulumi.String(args.Name),
+		},
+		corev1.ServicePortArray{
+			corev1.ServicePortArgs{
+				Name:       pulumi.String("p2p"),
+				Port:       internalArgs.P2PPort,
+				TargetPort: internalArgs.P2PPort,
+				Protocol:   pulumi.String("TCP"),
+			},
+			corev1.ServicePortArgs{
+				Name:       pulumi.String("beacon-api"),
+				Port:       internalArgs.BeaconAPIPort,
+				TargetPort: internalArgs.BeaconAPIPort,
+			},
+		},
+		utils.CreateResourceLabels(args.Name, headlessServiceName, args.Name, nil),
+		component,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	component.StatefulSet, err = appsv1.NewStatefulSet(ctx, statefulSetName, &appsv1.StatefulSetArgs{
 		Metadata: &metav1.ObjectMetaArgs{
-			Namespace: internalArgs.Namespace,
-			Labels:    utils.CreateResourceLabels(args.Name, statefulSetName, args.Name, nil),
+			Namespace:   internalArgs.Namespace,
+			Labels:      utils.CreateResourceLabels(args.Name, statefulSetName, args.Name, nil),
+			Annotations: args.statefulSetAnnotations(),
 		},
 		Spec: &appsv1.StatefulSetSpecArgs{
-			Replicas: pulumi.Int(1),
+			Replicas:            internalArgs.Replicas,
+			PodManagementPolicy: args.podManagementPolicy(),
+			ServiceName:         component.HeadlessService.Metadata.Name(),
 			Selector: &metav1.LabelSelectorArgs{
 				MatchLabels: pulumi.StringMap{
 					"app": pulumi.String(args.Name),
@@ -146,12 +158,16 @@ func NewConsensusClient(ctx *pulumi.Context, args *ConsensusClientArgs, opts ...
 					},
 				},
 				Spec: &corev1.PodSpecArgs{
-					Containers: corev1.ContainerArray{
+					Containers: append(corev1.ContainerArray{
 						corev1.ContainerArgs{
 							Name:            pulumi.String("consensus"),
 							Image:           internalArgs.Image,
 							ImagePullPolicy: internalArgs.ImagePullPolicy,
 							Command:         createConsensusClientCommand(args),
+							// Exposes the replica's ordinal to an ExecutionClientEndpoint referencing $(POD_INDEX)
+							Env: corev1.EnvVarArray{
+								utils.PodIndexEnv(),
+							},
 							Ports: corev1.ContainerPortArray{
 								corev1.ContainerPortArgs{
 									Name:          pulumi.String("p2p"),
@@ -169,77 +185,128 @@ func NewConsensusClient(ctx *pulumi.Context, args *ConsensusClientArgs, opts ...
 							},
 							VolumeMounts: corev1.VolumeMountArray{
 								corev1.VolumeMountArgs{
-									Name:      pulumi.String("data"),
-									MountPath: pulumi.String("/data"),
+									Name:      pulumi.String(DataVolumeName),
+									MountPath: pulumi.String(DataMountPath),
 								},
 								corev1.VolumeMountArgs{
-									Name:      pulumi.String("jwt"),
-									MountPath: pulumi.String("/etc/execution/jwt"),
+									Name:      pulumi.String(JWTVolumeName),
+									MountPath: pulumi.String(JWTMountPath),
 								},
 							},
-							Resources: nil,
+							Resources:      internalArgs.Resources,
+							StartupProbe:   internalArgs.StartupProbe,
+							LivenessProbe:  internalArgs.LivenessProbe,
+							ReadinessProbe: internalArgs.ReadinessProbe,
+							Lifecycle:      internalArgs.Lifecycle,
 						},
-					},
+					}, args.syncReadinessContainers()...),
 					Volumes: corev1.VolumeArray{
 						corev1.VolumeArgs{
-							Name: pulumi.String("data"),
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSourceArgs{
-								ClaimName: pvcNameInput,
-							},
-						},
-						corev1.VolumeArgs{
-							Name: pulumi.String("jwt"),
+							Name: pulumi.String(JWTVolumeName),
 							Secret: &corev1.SecretVolumeSourceArgs{
-								SecretName: component.JWTSecret.Metadata.Name(),
+								SecretName: jwtSecretName,
 							},
 						},
 					},
-					NodeSelector: args.NodeSelector,
-					Tolerations:  args.Tolerations,
+					NodeSelector:                  args.NodeSelector,
+					Tolerations:                   args.Tolerations,
+					Affinity:                      internalArgs.Affinity,
+					PriorityClassName:             internalArgs.PriorityClassName,
+					TerminationGracePeriodSeconds: internalArgs.TerminationGracePeriodSeconds,
 				},
 			},
+			// Each replica gets its own data volume
+			VolumeClaimTemplates: corev1.PersistentVolumeClaimTypeArray{
+				utils.VolumeClaimTemplate(
+					DataVolumeName,
+					internalArgs.StorageSize,
+					internalArgs.StorageClass,
+					internalArgs.DataSnapshotName,
+					utils.CreateResourceLabels(args.Name, DataVolumeName, args.Name, nil),
+				),
+			},
 		},
-	}, pulumi.Parent(component))
+	}, pulumi.Parent(component), utils.IgnoreClaimTemplateDataSourceChanges())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create StatefulSet: %w", err)
 	}
+	component.PvcNames = utils.StatefulSetClaimNames(DataVolumeName, component.StatefulSet.Metadata.Name().Elem(), args.replicas())
+
+	// Snapshot each replica's data volume on a schedule when configured
+	if args.Snapshots != nil {
+		component.SnapshotSchedule, err = storage.NewVolumeSnapshotScheduleComponent(ctx, &storage.VolumeSnapshotScheduleArgs{
+			Name:      args.Name,
+			Namespace: args.Namespace,
+			PvcNames:  component.PvcNames,
+			Snapshots: *args.Snapshots,
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot schedule: %w", err)
+		}
+	}
 
 	return component, nil
 }
 
-// createConsensusClientCommand creates the command array for the consensus client
+// createConsensusClientCommand creates the command array for the consensus client from its
+// flavor, unless the args replace it with their own command
 func createConsensusClientCommand(args *ConsensusClientArgs) pulumi.StringArray {
-	cmd := pulumi.StringArray{
-		pulumi.String("lighthouse"),
-		pulumi.String("bn"),
-		pulumi.String("--datadir=/data/lighthouse"),
-		pulumi.String("--http"),
-		pulumi.Sprintf("--http-port=%d", args.BeaconAPIPort),
-		pulumi.String("--http-address=0.0.0.0"),
-		pulumi.Sprintf("--execution-jwt=/etc/execution/jwt/jwt.hex"),
-		pulumi.Sprintf("--execution-endpoint=%s", args.ExecutionClientEndpoint),
-		pulumi.Sprintf("--port=%d", args.P2PPort),
-		pulumi.String("--metrics"),
-		pulumi.Sprintf("--metrics-port=%d", args.MetricsPort),
-		pulumi.String("--metrics-address=0.0.0.0"),
-		pulumi.String("--validator-monitor-auto"),
-		pulumi.String("--suggested-fee-recipient=0x0000000000000000000000000000000000000000"),
-		pulumi.String("--checkpoint-sync-url=https://mainnet.checkpoint.sigp.io"),
+	if len(args.Command) > 0 {
+		return pulumi.ToStringArray(args.Command)
 	}
 
-	// Add bootnodes
-	if args.Bootnodes != nil {
-		for _, bootnode := range args.Bootnodes {
-			cmd = append(cmd, pulumi.Sprintf("--boot-nodes=%s", bootnode))
-		}
-	}
+	cmd := args.flavor().Command(ClientSettings{
+		DataMountPath:     DataMountPath,
+		JWTPath:           path.Join(JWTMountPath, JWTFileName),
+		ExecutionEndpoint: args.ExecutionClientEndpoint,
+		Network:           args.Network,
+		P2PPort:           args.P2PPort,
+		BeaconAPIPort:     args.BeaconAPIPort,
+		MetricsPort:       args.MetricsPort,
+		CheckpointSyncURL: args.checkpointSyncURL(),
+		FeeRecipient:      args.feeRecipient(),
+		Bootnodes:         args.Bootnodes,
+	})
 
 	// Add additional args
-	if args.AdditionalArgs != nil {
-		for _, arg := range args.AdditionalArgs {
-			cmd = append(cmd, pulumi.String(arg))
-		}
+	cmd = append(cmd, args.AdditionalArgs...)
+
+	return pulumi.ToStringArray(cmd)
+}
+
+// statefulSetAnnotations stops pulumi up from waiting for replicas the sync check holds
+// unready, since syncing can take hours
+func (args *ConsensusClientArgs) statefulSetAnnotations() pulumi.StringMapInput {
+	if args.SyncReadiness == nil {
+		return nil
+	}
+	return utils.SkipAwaitAnnotations()
+}
+
+// podManagementPolicy starts replicas in parallel when the sync check holds them unready,
+// so they sync side by side rather than each waiting for the previous one. The policy cannot
+// be changed in place, so toggling SyncReadiness replaces the StatefulSet; its claims are kept
+func (args *ConsensusClientArgs) podManagementPolicy() pulumi.StringPtrInput {
+	if args.SyncReadiness == nil {
+		return nil
 	}
+	return pulumi.StringPtr(utils.PodManagementPolicyParallel)
+}
 
-	return cmd
+// syncReadinessContainers returns the sidecar checking the beacon node's sync state, if configured
+func (args *ConsensusClientArgs) syncReadinessContainers() corev1.ContainerArray {
+	if args.SyncReadiness == nil {
+		return nil
+	}
+	minPeers := args.SyncReadiness.MinPeers
+	if minPeers == 0 {
+		minPeers = DefaultSyncReadinessMinPeers
+	}
+	return corev1.ContainerArray{
+		utils.ReadinessSidecar(args.SyncReadiness.Image, syncCheckScript, corev1.EnvVarArray{
+			corev1.EnvVarArgs{Name: pulumi.String("BEACON_API_URL"), Value: pulumi.Sprintf("http://localhost:%d", args.BeaconAPIPort)},
+			corev1.EnvVarArgs{Name: pulumi.String("MAX_HEAD_LAG"), Value: pulumi.Sprintf("%d", args.SyncReadiness.MaxHeadLag)},
+			corev1.EnvVarArgs{Name: pulumi.String("MIN_PEERS"), Value: pulumi.Sprintf("%d", minPeers)},
+		}),
+	}
 }
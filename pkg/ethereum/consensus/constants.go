@@ -0,0 +1,67 @@
+package consensus
+
+import "github.com/init4tech/signet-infra-components/pkg/utils"
+
+// StatefulSet defaults
+const (
+	DefaultReplicas = 1
+	// DataVolumeName names the data volume and the claim template it is created from
+	DataVolumeName = "data"
+	DataMountPath  = "/data"
+	JWTVolumeName  = "jwt"
+	JWTMountPath   = "/etc/execution/jwt"
+	JWTFileName    = utils.JWTSecretKey
+)
+
+// Command defaults
+const (
+	DefaultCheckpointSyncURL = "https://mainnet.checkpoint.sigp.io"
+	DefaultFeeRecipient      = "0x0000000000000000000000000000000000000000"
+)
+
+// Probe and shutdown defaults. The beacon API health endpoint answers 200 when synced and
+// 206 while syncing, both of which pass an HTTP probe.
+const (
+	HealthPath                           = "/eth/v1/node/health"
+	StartupProbePeriodSeconds            = 10
+	StartupProbeFailureThreshold         = 60
+	LivenessProbePeriodSeconds           = 30
+	LivenessProbeFailureThreshold        = 5
+	ReadinessProbePeriodSeconds          = 10
+	ReadinessProbeFailureThreshold       = 3
+	DefaultTerminationGracePeriodSeconds = 120
+)
+
+// Consensus client flavor names
+const (
+	FlavorLighthouse = "lighthouse"
+	FlavorPrysm      = "prysm"
+	FlavorTeku       = "teku"
+	FlavorNimbus     = "nimbus"
+	FlavorLodestar   = "lodestar"
+)
+
+// Sync readiness defaults
+const (
+	DefaultSyncReadinessMinPeers = 1
+)
+
+// syncCheckScript fails while the beacon node at $BEACON_API_URL is more than $MAX_HEAD_LAG slots
+// behind the head, has lost its execution client or has fewer than $MIN_PEERS peers
+const syncCheckScript = `set -eu
+get() {
+  wget -q -T 5 -O - "${BEACON_API_URL}$1"
+}
+field() {
+  sed -n "s/.*\"$1\": *\"\([0-9]*\)\".*/\1/p"
+}
+syncing=$(get /eth/v1/node/syncing)
+case "${syncing}" in
+  *'"el_offline":true'* | *'"el_offline": true'*) echo "execution client offline"; exit 1 ;;
+esac
+distance=$(echo "${syncing}" | field sync_distance)
+[ -n "${distance}" ] || { echo "unknown sync distance"; exit 1; }
+[ "${distance}" -le "${MAX_HEAD_LAG}" ] || { echo "syncing, ${distance} slots behind"; exit 1; }
+peers=$(get /eth/v1/node/peer_count | field connected)
+[ -n "${peers}" ] && [ "${peers}" -ge "${MIN_PEERS}" ] || { echo "fewer than ${MIN_PEERS} peers"; exit 1; }
+`
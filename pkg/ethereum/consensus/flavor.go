@@ -0,0 +1,208 @@
+package consensus
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ConsensusClientFlavor is a consensus client implementation. It owns the client's binary,
+// flag syntax and data directory layout.
+type ConsensusClientFlavor interface {
+	// Name identifies the client
+	Name() string
+	// Command returns the command starting the beacon node with the given settings
+	Command(settings ClientSettings) []string
+}
+
+// ClientSettings are the paths, ports and endpoints a beacon node is started with
+type ClientSettings struct {
+	DataMountPath     string // Where the data volume is mounted, the flavor picks its data directory below it
+	JWTPath           string // Path of the Engine API JWT file
+	ExecutionEndpoint string
+	Network           string // Name of the network to join, empty leaves the client's default
+	P2PPort           int
+	BeaconAPIPort     int
+	MetricsPort       int
+	CheckpointSyncURL string
+	FeeRecipient      string
+	Bootnodes         []string
+}
+
+// Consensus client flavors
+var (
+	Lighthouse ConsensusClientFlavor = lighthouseFlavor{}
+	Prysm      ConsensusClientFlavor = prysmFlavor{}
+	Teku       ConsensusClientFlavor = tekuFlavor{}
+	Nimbus     ConsensusClientFlavor = nimbusFlavor{}
+	Lodestar   ConsensusClientFlavor = lodestarFlavor{}
+)
+
+// lighthouseFlavor runs the Lighthouse beacon node
+type lighthouseFlavor struct{}
+
+func (lighthouseFlavor) Name() string {
+	return FlavorLighthouse
+}
+
+func (lighthouseFlavor) Command(settings ClientSettings) []string {
+	cmd := []string{
+		"lighthouse",
+		"bn",
+		fmt.Sprintf("--datadir=%s", path.Join(settings.DataMountPath, "lighthouse")),
+		"--http",
+		fmt.Sprintf("--http-port=%d", settings.BeaconAPIPort),
+		"--http-address=0.0.0.0",
+		fmt.Sprintf("--execution-jwt=%s", settings.JWTPath),
+		fmt.Sprintf("--execution-endpoint=%s", settings.ExecutionEndpoint),
+		fmt.Sprintf("--port=%d", settings.P2PPort),
+		"--metrics",
+		fmt.Sprintf("--metrics-port=%d", settings.MetricsPort),
+		"--metrics-address=0.0.0.0",
+		"--validator-monitor-auto",
+		fmt.Sprintf("--suggested-fee-recipient=%s", settings.FeeRecipient),
+		fmt.Sprintf("--checkpoint-sync-url=%s", settings.CheckpointSyncURL),
+	}
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--network=%s", settings.Network))
+	}
+	if len(settings.Bootnodes) > 0 {
+		cmd = append(cmd, fmt.Sprintf("--boot-nodes=%s", strings.Join(settings.Bootnodes, ",")))
+	}
+	return cmd
+}
+
+// prysmFlavor runs the Prysm beacon node
+type prysmFlavor struct{}
+
+func (prysmFlavor) Name() string {
+	return FlavorPrysm
+}
+
+func (prysmFlavor) Command(settings ClientSettings) []string {
+	cmd := []string{
+		"/app/cmd/beacon-chain/beacon-chain",
+		"--accept-terms-of-use",
+		fmt.Sprintf("--datadir=%s", path.Join(settings.DataMountPath, "prysm")),
+		"--http-host=0.0.0.0",
+		fmt.Sprintf("--http-port=%d", settings.BeaconAPIPort),
+		fmt.Sprintf("--jwt-secret=%s", settings.JWTPath),
+		fmt.Sprintf("--execution-endpoint=%s", settings.ExecutionEndpoint),
+		fmt.Sprintf("--p2p-tcp-port=%d", settings.P2PPort),
+		fmt.Sprintf("--p2p-udp-port=%d", settings.P2PPort),
+		"--monitoring-host=0.0.0.0",
+		fmt.Sprintf("--monitoring-port=%d", settings.MetricsPort),
+		fmt.Sprintf("--suggested-fee-recipient=%s", settings.FeeRecipient),
+		fmt.Sprintf("--checkpoint-sync-url=%s", settings.CheckpointSyncURL),
+		fmt.Sprintf("--genesis-beacon-api-url=%s", settings.CheckpointSyncURL),
+	}
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--%s", settings.Network))
+	}
+	for _, bootnode := range settings.Bootnodes {
+		cmd = append(cmd, fmt.Sprintf("--bootstrap-node=%s", bootnode))
+	}
+	return cmd
+}
+
+// tekuFlavor runs the Teku beacon node
+type tekuFlavor struct{}
+
+func (tekuFlavor) Name() string {
+	return FlavorTeku
+}
+
+func (tekuFlavor) Command(settings ClientSettings) []string {
+	cmd := []string{
+		"/opt/teku/bin/teku",
+		fmt.Sprintf("--data-path=%s", path.Join(settings.DataMountPath, "teku")),
+		"--rest-api-enabled=true",
+		"--rest-api-interface=0.0.0.0",
+		fmt.Sprintf("--rest-api-port=%d", settings.BeaconAPIPort),
+		"--rest-api-host-allowlist=*",
+		fmt.Sprintf("--ee-jwt-secret-file=%s", settings.JWTPath),
+		fmt.Sprintf("--ee-endpoint=%s", settings.ExecutionEndpoint),
+		fmt.Sprintf("--p2p-port=%d", settings.P2PPort),
+		"--metrics-enabled=true",
+		"--metrics-interface=0.0.0.0",
+		fmt.Sprintf("--metrics-port=%d", settings.MetricsPort),
+		"--metrics-host-allowlist=*",
+		fmt.Sprintf("--validators-proposer-default-fee-recipient=%s", settings.FeeRecipient),
+		fmt.Sprintf("--checkpoint-sync-url=%s", settings.CheckpointSyncURL),
+	}
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--network=%s", settings.Network))
+	}
+	if len(settings.Bootnodes) > 0 {
+		cmd = append(cmd, fmt.Sprintf("--p2p-discovery-bootnodes=%s", strings.Join(settings.Bootnodes, ",")))
+	}
+	return cmd
+}
+
+// nimbusFlavor runs the Nimbus beacon node
+type nimbusFlavor struct{}
+
+func (nimbusFlavor) Name() string {
+	return FlavorNimbus
+}
+
+func (nimbusFlavor) Command(settings ClientSettings) []string {
+	cmd := []string{
+		"/home/user/nimbus-eth2/build/nimbus_beacon_node",
+		"--non-interactive",
+		fmt.Sprintf("--data-dir=%s", path.Join(settings.DataMountPath, "nimbus")),
+		"--rest",
+		"--rest-address=0.0.0.0",
+		fmt.Sprintf("--rest-port=%d", settings.BeaconAPIPort),
+		fmt.Sprintf("--jwt-secret=%s", settings.JWTPath),
+		fmt.Sprintf("--el=%s", settings.ExecutionEndpoint),
+		fmt.Sprintf("--tcp-port=%d", settings.P2PPort),
+		fmt.Sprintf("--udp-port=%d", settings.P2PPort),
+		"--metrics",
+		"--metrics-address=0.0.0.0",
+		fmt.Sprintf("--metrics-port=%d", settings.MetricsPort),
+		fmt.Sprintf("--suggested-fee-recipient=%s", settings.FeeRecipient),
+		fmt.Sprintf("--external-beacon-api-url=%s", settings.CheckpointSyncURL),
+	}
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--network=%s", settings.Network))
+	}
+	for _, bootnode := range settings.Bootnodes {
+		cmd = append(cmd, fmt.Sprintf("--bootstrap-node=%s", bootnode))
+	}
+	return cmd
+}
+
+// lodestarFlavor runs the Lodestar beacon node
+type lodestarFlavor struct{}
+
+func (lodestarFlavor) Name() string {
+	return FlavorLodestar
+}
+
+func (lodestarFlavor) Command(settings ClientSettings) []string {
+	cmd := []string{
+		"node",
+		"/usr/app/packages/cli/bin/lodestar",
+		"beacon",
+		fmt.Sprintf("--dataDir=%s", path.Join(settings.DataMountPath, "lodestar")),
+		"--rest",
+		"--rest.address=0.0.0.0",
+		fmt.Sprintf("--rest.port=%d", settings.BeaconAPIPort),
+		fmt.Sprintf("--jwt-secret=%s", settings.JWTPath),
+		fmt.Sprintf("--execution.urls=%s", settings.ExecutionEndpoint),
+		fmt.Sprintf("--port=%d", settings.P2PPort),
+		"--metrics",
+		"--metrics.address=0.0.0.0",
+		fmt.Sprintf("--metrics.port=%d", settings.MetricsPort),
+		fmt.Sprintf("--suggestedFeeRecipient=%s", settings.FeeRecipient),
+		fmt.Sprintf("--checkpointSyncUrl=%s", settings.CheckpointSyncURL),
+	}
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--network=%s", settings.Network))
+	}
+	for _, bootnode := range settings.Bootnodes {
+		cmd = append(cmd, fmt.Sprintf("--bootnodes=%s", bootnode))
+	}
+	return cmd
+}
@@ -0,0 +1,206 @@
+package consensus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestConsensusClientFlavor_Command(t *testing.T) {
+	settings := ClientSettings{
+		DataMountPath:     "/data",
+		JWTPath:           "/etc/execution/jwt/jwt.hex",
+		ExecutionEndpoint: "http://execution-0.execution-headless:8551",
+		P2PPort:           9000,
+		BeaconAPIPort:     4000,
+		MetricsPort:       5054,
+		CheckpointSyncURL: "https://checkpoint.example.com",
+		FeeRecipient:      "0x1111111111111111111111111111111111111111",
+		Bootnodes:         []string{"enr:-a", "enr:-b"},
+	}
+
+	tests := []struct {
+		name   string
+		flavor ConsensusClientFlavor
+		want   []string
+	}{
+		{
+			name:   FlavorLighthouse,
+			flavor: Lighthouse,
+			want: []string{
+				"lighthouse",
+				"bn",
+				"--datadir=/data/lighthouse",
+				"--http",
+				"--http-port=4000",
+				"--http-address=0.0.0.0",
+				"--execution-jwt=/etc/execution/jwt/jwt.hex",
+				"--execution-endpoint=http://execution-0.execution-headless:8551",
+				"--port=9000",
+				"--metrics",
+				"--metrics-port=5054",
+				"--metrics-address=0.0.0.0",
+				"--validator-monitor-auto",
+				"--suggested-fee-recipient=0x1111111111111111111111111111111111111111",
+				"--checkpoint-sync-url=https://checkpoint.example.com",
+				"--boot-nodes=enr:-a,enr:-b",
+			},
+		},
+		{
+			name:   FlavorPrysm,
+			flavor: Prysm,
+			want: []string{
+				"/app/cmd/beacon-chain/beacon-chain",
+				"--accept-terms-of-use",
+				"--datadir=/data/prysm",
+				"--http-host=0.0.0.0",
+				"--http-port=4000",
+				"--jwt-secret=/etc/execution/jwt/jwt.hex",
+				"--execution-endpoint=http://execution-0.execution-headless:8551",
+				"--p2p-tcp-port=9000",
+				"--p2p-udp-port=9000",
+				"--monitoring-host=0.0.0.0",
+				"--monitoring-port=5054",
+				"--suggested-fee-recipient=0x1111111111111111111111111111111111111111",
+				"--checkpoint-sync-url=https://checkpoint.example.com",
+				"--genesis-beacon-api-url=https://checkpoint.example.com",
+				"--bootstrap-node=enr:-a",
+				"--bootstrap-node=enr:-b",
+			},
+		},
+		{
+			name:   FlavorTeku,
+			flavor: Teku,
+			want: []string{
+				"/opt/teku/bin/teku",
+				"--data-path=/data/teku",
+				"--rest-api-enabled=true",
+				"--rest-api-interface=0.0.0.0",
+				"--rest-api-port=4000",
+				"--rest-api-host-allowlist=*",
+				"--ee-jwt-secret-file=/etc/execution/jwt/jwt.hex",
+				"--ee-endpoint=http://execution-0.execution-headless:8551",
+				"--p2p-port=9000",
+				"--metrics-enabled=true",
+				"--metrics-interface=0.0.0.0",
+				"--metrics-port=5054",
+				"--metrics-host-allowlist=*",
+				"--validators-proposer-default-fee-recipient=0x1111111111111111111111111111111111111111",
+				"--checkpoint-sync-url=https://checkpoint.example.com",
+				"--p2p-discovery-bootnodes=enr:-a,enr:-b",
+			},
+		},
+		{
+			name:   FlavorNimbus,
+			flavor: Nimbus,
+			want: []string{
+				"/home/user/nimbus-eth2/build/nimbus_beacon_node",
+				"--non-interactive",
+				"--data-dir=/data/nimbus",
+				"--rest",
+				"--rest-address=0.0.0.0",
+				"--rest-port=4000",
+				"--jwt-secret=/etc/execution/jwt/jwt.hex",
+				"--el=http://execution-0.execution-headless:8551",
+				"--tcp-port=9000",
+				"--udp-port=9000",
+				"--metrics",
+				"--metrics-address=0.0.0.0",
+				"--metrics-port=5054",
+				"--suggested-fee-recipient=0x1111111111111111111111111111111111111111",
+				"--external-beacon-api-url=https://checkpoint.example.com",
+				"--bootstrap-node=enr:-a",
+				"--bootstrap-node=enr:-b",
+			},
+		},
+		{
+			name:   FlavorLodestar,
+			flavor: Lodestar,
+			want: []string{
+				"node",
+				"/usr/app/packages/cli/bin/lodestar",
+				"beacon",
+				"--dataDir=/data/lodestar",
+				"--rest",
+				"--rest.address=0.0.0.0",
+				"--rest.port=4000",
+				"--jwt-secret=/etc/execution/jwt/jwt.hex",
+				"--execution.urls=http://execution-0.execution-headless:8551",
+				"--port=9000",
+				"--metrics",
+				"--metrics.address=0.0.0.0",
+				"--metrics.port=5054",
+				"--suggestedFeeRecipient=0x1111111111111111111111111111111111111111",
+				"--checkpointSyncUrl=https://checkpoint.example.com",
+				"--bootnodes=enr:-a",
+				"--bootnodes=enr:-b",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flavor.Name(); got != tt.name {
+				t.Errorf("Name() = %q, want %q", got, tt.name)
+			}
+			if got := tt.flavor.Command(settings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Command() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateConsensusClientCommand(t *testing.T) {
+	args := &ConsensusClientArgs{
+		Flavor:         Teku,
+		BeaconAPIPort:  4000,
+		AdditionalArgs: []string{"--network=sepolia"},
+	}
+	cmd := createConsensusClientCommand(args)
+	if len(cmd) == 0 || cmd[0] != pulumi.String("/opt/teku/bin/teku") || cmd[len(cmd)-1] != pulumi.String("--network=sepolia") {
+		t.Errorf("createConsensusClientCommand() did not run teku with the additional args: %v", cmd)
+	}
+
+	args.Command = []string{"./start"}
+	if cmd := createConsensusClientCommand(args); len(cmd) != 1 || cmd[0] != pulumi.String("./start") {
+		t.Errorf("createConsensusClientCommand() did not use the command override: %v", cmd)
+	}
+}
+
+func TestCreateConsensusClientCommand_Network(t *testing.T) {
+	args := &ConsensusClientArgs{
+		Network:      "hoodi",
+		FeeRecipient: "0x1111111111111111111111111111111111111111",
+	}
+	cmd := createConsensusClientCommand(args)
+	for _, want := range []string{
+		"--checkpoint-sync-url=https://hoodi.checkpoint.sigp.io",
+		"--suggested-fee-recipient=0x1111111111111111111111111111111111111111",
+		"--network=hoodi",
+	} {
+		if !containsArg(cmd, want) {
+			t.Errorf("createConsensusClientCommand() = %v, missing %s", cmd, want)
+		}
+	}
+
+	args.CheckpointSyncURL = "https://checkpoint.example.com"
+	if cmd := createConsensusClientCommand(args); !containsArg(cmd, "--checkpoint-sync-url=https://checkpoint.example.com") {
+		t.Errorf("createConsensusClientCommand() did not use the checkpoint sync url override: %v", cmd)
+	}
+
+	args.Flavor = Prysm
+	if cmd := createConsensusClientCommand(args); !containsArg(cmd, "--hoodi") {
+		t.Errorf("createConsensusClientCommand() did not pass the prysm network flag: %v", cmd)
+	}
+}
+
+// containsArg reports whether cmd holds the argument arg
+func containsArg(cmd pulumi.StringArray, arg string) bool {
+	for _, a := range cmd {
+		if a == pulumi.String(arg) {
+			return true
+		}
+	}
+	return false
+}
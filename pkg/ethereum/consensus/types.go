@@ -1,6 +1,9 @@
 package consensus
 
 import (
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/network"
+	"github.com/init4tech/signet-infra-components/pkg/storage"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
@@ -10,64 +13,186 @@ import (
 
 // ConsensusClientArgs represents the arguments for creating a consensus client
 type ConsensusClientArgs struct {
-	Name                    string
-	Namespace               string
-	StorageSize             string
-	StorageClass            string
-	Image                   string
-	ImagePullPolicy         string
-	JWTSecret               string
-	NodeSelector            pulumi.StringMap
-	Tolerations             corev1.TolerationArray
-	P2PPort                 int
-	BeaconAPIPort           int
-	MetricsPort             int
-	ExecutionClientEndpoint string
-	Bootnodes               []string
-	AdditionalArgs          []string
+	Name                          string
+	Namespace                     string
+	StorageSize                   string
+	StorageClass                  *storage.StorageClassComponent
+	Flavor                        ConsensusClientFlavor // Optional: the client the image runs, defaults to Lighthouse
+	Image                         string
+	ImagePullPolicy               string
+	Replicas                      int                          // Optional: number of replicas, each with its own data volume, defaults to 1
+	JWTSecret                     string                       // Hex encoded 32 byte Engine API JWT secret, required unless JWTSecretName is set
+	JWTSecretName                 string                       // Optional: mount an existing Secret holding the JWT under "jwt.hex" instead of creating one
+	Resources                     *corev1.ResourceRequirements // Optional: resource requests and limits
+	NodeSelector                  pulumi.StringMap
+	Tolerations                   corev1.TolerationArray
+	Affinity                      *corev1.AffinityArgs // Optional: affinity rules
+	PriorityClassName             string               // Optional: priority class of the pods
+	StartupProbe                  *corev1.ProbeArgs    // Optional: replaces the default probe waiting for the beacon API health endpoint
+	LivenessProbe                 *corev1.ProbeArgs    // Optional: replaces the default probe checking the beacon API port
+	ReadinessProbe                *corev1.ProbeArgs    // Optional: replaces the default probe checking the beacon API health endpoint
+	SyncReadiness                 *SyncReadinessArgs   // Optional: hold replicas out of their services while they sync
+	TerminationGracePeriodSeconds int                  // Optional: how long the client may take to shut down, defaults to 120
+	PreStopCommand                []string             // Optional: runs in the container before it is stopped
+	P2PPort                       int
+	BeaconAPIPort                 int
+	MetricsPort                   int
+	ExecutionClientEndpoint       string   // May reference $(POD_INDEX) to pair each replica with an execution replica
+	Network                       string   // Optional: name of a network preset to join, see the network package
+	CheckpointSyncURL             string   // Optional: defaults to the network preset's, or mainnet's without a network
+	FeeRecipient                  string   // Optional: suggested fee recipient, defaults to the zero address
+	Bootnodes                     []string // Optional: defaults to the client's built-in list
+	AdditionalArgs                []string
+	Command                       []string                      // Optional: replaces the generated client command
+	Snapshots                     *storage.SnapshotArgs         // Optional: take scheduled snapshots of the data volume
+	DataSnapshot                  *storage.VolumeSnapshotSource // Optional: restore a new data volume from an existing snapshot
+}
+
+// SyncReadinessArgs configures a sidecar that keeps a replica unready while /eth/v1/node/syncing
+// reports it behind the head, its execution client offline or it lacks peers
+type SyncReadinessArgs struct {
+	Image      string // Optional: runs the check and needs sh, wget and sed, defaults to busybox
+	MaxHeadLag int    // Optional: how many slots a ready replica may trail the head
+	MinPeers   int    // Optional: the fewest peers a ready replica has, defaults to 1
 }
 
 // Internal structs with Pulumi types
 
 type consensusClientArgsInternal struct {
-	Name                    pulumi.StringInput
-	Namespace               pulumi.StringInput
-	StorageSize             pulumi.StringInput
-	StorageClass            pulumi.StringInput
-	Image                   pulumi.StringInput
-	ImagePullPolicy         pulumi.StringInput
-	JWTSecret               pulumi.StringInput
-	NodeSelector            pulumi.StringMap
-	Tolerations             corev1.TolerationArray
-	P2PPort                 pulumi.IntInput
-	BeaconAPIPort           pulumi.IntInput
-	MetricsPort             pulumi.IntInput
-	ExecutionClientEndpoint pulumi.StringInput
-	Bootnodes               pulumi.StringArray
-	AdditionalArgs          pulumi.StringArray
+	Name                          pulumi.StringInput
+	Namespace                     pulumi.StringInput
+	StorageSize                   pulumi.StringInput
+	StorageClass                  pulumi.StringInput
+	Image                         pulumi.StringInput
+	ImagePullPolicy               pulumi.StringInput
+	Replicas                      pulumi.IntInput
+	JWTSecret                     pulumi.StringInput
+	Resources                     corev1.ResourceRequirementsPtrInput
+	NodeSelector                  pulumi.StringMap
+	Tolerations                   corev1.TolerationArray
+	Affinity                      corev1.AffinityPtrInput
+	PriorityClassName             pulumi.StringPtrInput
+	StartupProbe                  corev1.ProbePtrInput
+	LivenessProbe                 corev1.ProbePtrInput
+	ReadinessProbe                corev1.ProbePtrInput
+	TerminationGracePeriodSeconds pulumi.IntInput
+	Lifecycle                     corev1.LifecyclePtrInput
+	P2PPort                       pulumi.IntInput
+	BeaconAPIPort                 pulumi.IntInput
+	MetricsPort                   pulumi.IntInput
+	ExecutionClientEndpoint       pulumi.StringInput
+	Bootnodes                     pulumi.StringArray
+	AdditionalArgs                pulumi.StringArray
+	DataSnapshotName              string
 }
 
 // Conversion functions
 
 // toInternal converts public args to internal args for use with Pulumi
 func (args ConsensusClientArgs) toInternal() consensusClientArgsInternal {
-	return consensusClientArgsInternal{
-		Name:                    pulumi.String(args.Name),
-		Namespace:               pulumi.String(args.Namespace),
-		StorageSize:             pulumi.String(args.StorageSize),
-		StorageClass:            pulumi.String(args.StorageClass),
-		Image:                   pulumi.String(args.Image),
-		ImagePullPolicy:         pulumi.String(args.ImagePullPolicy),
-		JWTSecret:               pulumi.String(args.JWTSecret),
-		NodeSelector:            args.NodeSelector,
-		Tolerations:             args.Tolerations,
-		P2PPort:                 pulumi.Int(args.P2PPort),
-		BeaconAPIPort:           pulumi.Int(args.BeaconAPIPort),
-		MetricsPort:             pulumi.Int(args.MetricsPort),
-		ExecutionClientEndpoint: pulumi.String(args.ExecutionClientEndpoint),
-		Bootnodes:               pulumi.ToStringArray(args.Bootnodes),
-		AdditionalArgs:          pulumi.ToStringArray(args.AdditionalArgs),
+	internal := consensusClientArgsInternal{
+		Name:                          pulumi.String(args.Name),
+		Namespace:                     pulumi.String(args.Namespace),
+		StorageSize:                   pulumi.String(args.StorageSize),
+		StorageClass:                  args.StorageClass.Name,
+		Image:                         pulumi.String(args.Image),
+		ImagePullPolicy:               pulumi.String(args.ImagePullPolicy),
+		Replicas:                      pulumi.Int(args.replicas()),
+		JWTSecret:                     utils.SecretString(args.JWTSecret),
+		Resources:                     utils.ResourceRequirements(args.Resources),
+		NodeSelector:                  args.NodeSelector,
+		Tolerations:                   args.Tolerations,
+		StartupProbe:                  args.startupProbe(),
+		LivenessProbe:                 args.livenessProbe(),
+		ReadinessProbe:                args.readinessProbe(),
+		TerminationGracePeriodSeconds: pulumi.Int(args.terminationGracePeriodSeconds()),
+		Lifecycle:                     utils.PreStopLifecycle(args.PreStopCommand),
+		P2PPort:                       pulumi.Int(args.P2PPort),
+		BeaconAPIPort:                 pulumi.Int(args.BeaconAPIPort),
+		MetricsPort:                   pulumi.Int(args.MetricsPort),
+		ExecutionClientEndpoint:       pulumi.String(args.ExecutionClientEndpoint),
+		Bootnodes:                     pulumi.ToStringArray(args.Bootnodes),
+		AdditionalArgs:                pulumi.ToStringArray(args.AdditionalArgs),
+	}
+	if args.Affinity != nil {
+		internal.Affinity = args.Affinity
+	}
+	if args.PriorityClassName != "" {
+		internal.PriorityClassName = pulumi.StringPtr(args.PriorityClassName)
+	}
+	if args.DataSnapshot != nil {
+		internal.DataSnapshotName = args.DataSnapshot.Name
+	}
+	return internal
+}
+
+// replicas returns the number of replicas, defaulting to DefaultReplicas
+func (args ConsensusClientArgs) replicas() int {
+	if args.Replicas == 0 {
+		return DefaultReplicas
+	}
+	return args.Replicas
+}
+
+// terminationGracePeriodSeconds returns the shutdown grace period, defaulting to
+// DefaultTerminationGracePeriodSeconds
+func (args ConsensusClientArgs) terminationGracePeriodSeconds() int {
+	if args.TerminationGracePeriodSeconds == 0 {
+		return DefaultTerminationGracePeriodSeconds
+	}
+	return args.TerminationGracePeriodSeconds
+}
+
+// startupProbe returns the startup probe, defaulting to waiting for the beacon API health endpoint
+func (args ConsensusClientArgs) startupProbe() *corev1.ProbeArgs {
+	if args.StartupProbe != nil {
+		return args.StartupProbe
+	}
+	return utils.HTTPProbe(HealthPath, args.BeaconAPIPort, StartupProbePeriodSeconds, StartupProbeFailureThreshold)
+}
+
+// livenessProbe returns the liveness probe, defaulting to checking the beacon API port
+func (args ConsensusClientArgs) livenessProbe() *corev1.ProbeArgs {
+	if args.LivenessProbe != nil {
+		return args.LivenessProbe
+	}
+	return utils.TCPProbe(args.BeaconAPIPort, LivenessProbePeriodSeconds, LivenessProbeFailureThreshold)
+}
+
+// readinessProbe returns the readiness probe, defaulting to the beacon API health endpoint
+func (args ConsensusClientArgs) readinessProbe() *corev1.ProbeArgs {
+	if args.ReadinessProbe != nil {
+		return args.ReadinessProbe
+	}
+	return utils.HTTPProbe(HealthPath, args.BeaconAPIPort, ReadinessProbePeriodSeconds, ReadinessProbeFailureThreshold)
+}
+
+// flavor returns the consensus client flavor, defaulting to Lighthouse
+func (args ConsensusClientArgs) flavor() ConsensusClientFlavor {
+	if args.Flavor == nil {
+		return Lighthouse
+	}
+	return args.Flavor
+}
+
+// checkpointSyncURL returns the checkpoint sync URL, defaulting to the network preset's and then
+// to DefaultCheckpointSyncURL
+func (args ConsensusClientArgs) checkpointSyncURL() string {
+	if args.CheckpointSyncURL != "" {
+		return args.CheckpointSyncURL
+	}
+	if preset, err := network.Lookup(args.Network); err == nil {
+		return preset.CheckpointSyncURL
+	}
+	return DefaultCheckpointSyncURL
+}
+
+// feeRecipient returns the suggested fee recipient, defaulting to DefaultFeeRecipient
+func (args ConsensusClientArgs) feeRecipient() string {
+	if args.FeeRecipient == "" {
+		return DefaultFeeRecipient
 	}
+	return args.FeeRecipient
 }
 
 // ConsensusClientComponent represents a consensus client deployment
@@ -78,14 +203,18 @@ type ConsensusClientComponent struct {
 	Name string
 	// Namespace is the Kubernetes namespace
 	Namespace string
-	// PVC is the persistent volume claim
-	PVC *corev1.PersistentVolumeClaim
-	// JWTSecret is the JWT secret
+	// PvcNames are the names of the data volume claims, one per replica
+	PvcNames pulumi.StringArray
+	// JWTSecret is the JWT secret, nil when JWTSecretName mounts an existing one
 	JWTSecret *corev1.Secret
 	// P2PService is the P2P service
 	P2PService *corev1.Service
 	// BeaconAPIService is the beacon API service
 	BeaconAPIService *corev1.Service
+	// HeadlessService governs the stateful set, giving each replica a stable DNS name
+	HeadlessService *corev1.Service
 	// StatefulSet is the stateful set
 	StatefulSet *appsv1.StatefulSet
+	// SnapshotSchedule snapshots the data volume, if configured
+	SnapshotSchedule *storage.VolumeSnapshotScheduleComponent
 }
@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestConsensusClientArgs_Probes(t *testing.T) {
+	args := ConsensusClientArgs{BeaconAPIPort: 5052}
+
+	for name, probe := range map[string]*corev1.ProbeArgs{
+		"startupProbe":   args.startupProbe(),
+		"readinessProbe": args.readinessProbe(),
+	} {
+		httpGet := probe.HttpGet.(*corev1.HTTPGetActionArgs)
+		if httpGet.Path != pulumi.String(HealthPath) || httpGet.Port != pulumi.Int(5052) {
+			t.Errorf("%s() = %v %v, want the beacon API health endpoint", name, httpGet.Path, httpGet.Port)
+		}
+	}
+	if port := args.livenessProbe().TcpSocket.(*corev1.TCPSocketActionArgs).Port; port != pulumi.Int(5052) {
+		t.Errorf("livenessProbe() port = %v, want the beacon API port", port)
+	}
+	if got := args.terminationGracePeriodSeconds(); got != DefaultTerminationGracePeriodSeconds {
+		t.Errorf("terminationGracePeriodSeconds() = %d, want %d", got, DefaultTerminationGracePeriodSeconds)
+	}
+
+	override := &corev1.ProbeArgs{PeriodSeconds: pulumi.Int(60)}
+	args.StartupProbe = override
+	if args.startupProbe() != override {
+		t.Errorf("startupProbe() did not return the override")
+	}
+}
+
+func TestConsensusClientArgs_SyncReadinessContainers(t *testing.T) {
+	args := ConsensusClientArgs{BeaconAPIPort: 5052}
+	if containers := args.syncReadinessContainers(); containers != nil {
+		t.Errorf("syncReadinessContainers() = %v, want none without sync readiness", containers)
+	}
+
+	args.SyncReadiness = &SyncReadinessArgs{MaxHeadLag: 4, MinPeers: 10}
+	containers := args.syncReadinessContainers()
+	if len(containers) != 1 {
+		t.Fatalf("syncReadinessContainers() returned %d containers, want 1", len(containers))
+	}
+	probe := containers[0].(corev1.ContainerArgs).ReadinessProbe.(*corev1.ProbeArgs)
+	command := probe.Exec.(*corev1.ExecActionArgs).Command.(pulumi.StringArray)
+	if command[len(command)-1] != pulumi.String(syncCheckScript) {
+		t.Errorf("sidecar readiness probe does not run the sync check script")
+	}
+}
+
+func TestConsensusClientArgs_SyncReadinessRollout(t *testing.T) {
+	args := ConsensusClientArgs{}
+	if got := args.statefulSetAnnotations(); got != nil {
+		t.Errorf("statefulSetAnnotations() = %v, want none without sync readiness", got)
+	}
+	if got := args.podManagementPolicy(); got != nil {
+		t.Errorf("podManagementPolicy() = %v, want the default without sync readiness", got)
+	}
+
+	args.SyncReadiness = &SyncReadinessArgs{MaxHeadLag: 2}
+	annotations := args.statefulSetAnnotations().(pulumi.StringMap)
+	if got := annotations[utils.SkipAwaitAnnotation]; got != pulumi.String("true") {
+		t.Errorf("skipAwait annotation = %v, want true", got)
+	}
+	if got := args.podManagementPolicy(); !reflect.DeepEqual(got, pulumi.StringPtr(utils.PodManagementPolicyParallel)) {
+		t.Errorf("podManagementPolicy() = %v, want Parallel", got)
+	}
+}
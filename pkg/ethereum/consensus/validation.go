@@ -1,7 +1,12 @@
 package consensus
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
+
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/network"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 )
 
 // Validate validates the consensus client arguments
@@ -15,7 +20,7 @@ func (args *ConsensusClientArgs) Validate() error {
 	if args.StorageSize == "" {
 		return fmt.Errorf("storageSize is required")
 	}
-	if args.StorageClass == "" {
+	if args.StorageClass == nil {
 		return fmt.Errorf("storageClass is required")
 	}
 	if args.Image == "" {
@@ -24,8 +29,16 @@ func (args *ConsensusClientArgs) Validate() error {
 	if args.ImagePullPolicy == "" {
 		return fmt.Errorf("imagePullPolicy is required")
 	}
-	if args.JWTSecret == "" {
-		return fmt.Errorf("jwtSecret is required")
+	if args.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative")
+	}
+	if args.JWTSecret == "" && args.JWTSecretName == "" {
+		return fmt.Errorf("jwtSecret or jwtSecretName is required")
+	}
+	if args.JWTSecret != "" {
+		if err := utils.ValidateJWTSecret(args.JWTSecret); err != nil {
+			return fmt.Errorf("invalid jwtSecret: %w", err)
+		}
 	}
 	if args.P2PPort == 0 {
 		return fmt.Errorf("p2pPort is required")
@@ -49,5 +62,55 @@ func (args *ConsensusClientArgs) Validate() error {
 	if args.MetricsPort <= 0 {
 		return fmt.Errorf("metricsPort must be greater than zero")
 	}
+	if args.TerminationGracePeriodSeconds < 0 {
+		return fmt.Errorf("terminationGracePeriodSeconds must not be negative")
+	}
+	if args.SyncReadiness != nil {
+		if err := args.SyncReadiness.Validate(); err != nil {
+			return fmt.Errorf("invalid syncReadiness: %w", err)
+		}
+	}
+	if args.Network != "" {
+		if _, err := network.Lookup(args.Network); err != nil {
+			return err
+		}
+	}
+	if args.CheckpointSyncURL != "" && !strings.HasPrefix(args.CheckpointSyncURL, "http://") && !strings.HasPrefix(args.CheckpointSyncURL, "https://") {
+		return fmt.Errorf("checkpointSyncURL must be an http or https URL")
+	}
+	if args.FeeRecipient != "" && !isAddress(args.FeeRecipient) {
+		return fmt.Errorf("feeRecipient must be a 0x prefixed 20 byte hex address")
+	}
+	if args.DataSnapshot != nil {
+		if err := args.DataSnapshot.ValidateSize(args.StorageSize); err != nil {
+			return fmt.Errorf("invalid dataSnapshot: %w", err)
+		}
+	}
+	if args.Snapshots != nil {
+		if err := args.Snapshots.Validate(); err != nil {
+			return fmt.Errorf("invalid snapshots: %w", err)
+		}
+	}
 	return nil
 }
+
+// Validate validates the SyncReadinessArgs struct
+func (readiness *SyncReadinessArgs) Validate() error {
+	if readiness.MaxHeadLag < 0 {
+		return fmt.Errorf("maxHeadLag must not be negative")
+	}
+	if readiness.MinPeers < 0 {
+		return fmt.Errorf("minPeers must not be negative")
+	}
+	return nil
+}
+
+// isAddress reports whether address is a 0x prefixed 20 byte hex address
+func isAddress(address string) bool {
+	digits, ok := strings.CutPrefix(address, "0x")
+	if !ok || len(digits) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(digits)
+	return err == nil
+}
@@ -2,8 +2,13 @@ package consensus
 
 import (
 	"testing"
+
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 )
 
+// testJWTSecret is a well-formed Engine API JWT secret
+const testJWTSecret = "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718"
+
 func TestConsensusClientArgs_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -16,10 +21,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -32,10 +37,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 			args: ConsensusClientArgs{
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -48,10 +53,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 			args: ConsensusClientArgs{
 				Name:                    "test",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -64,10 +69,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 			args: ConsensusClientArgs{
 				Name:                    "test",
 				Namespace:               "default",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -83,7 +88,7 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				StorageSize:             "100Gi",
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -97,9 +102,9 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -113,9 +118,9 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -129,7 +134,7 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
 				P2PPort:                 30303,
@@ -145,10 +150,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
 				ExecutionClientEndpoint: "http://execution:8551",
@@ -161,10 +166,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				MetricsPort:             9090,
 				ExecutionClientEndpoint: "http://execution:8551",
@@ -177,10 +182,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				ExecutionClientEndpoint: "http://execution:8551",
@@ -193,10 +198,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:            "test",
 				Namespace:       "default",
 				StorageSize:     "100Gi",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
 				Image:           "test-image",
 				ImagePullPolicy: "IfNotPresent",
-				JWTSecret:       "test-secret",
+				JWTSecret:       testJWTSecret,
 				P2PPort:         30303,
 				BeaconAPIPort:   5052,
 				MetricsPort:     9090,
@@ -209,10 +214,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 0,
 				BeaconAPIPort:           5052,
 				MetricsPort:             9090,
@@ -226,10 +231,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           0,
 				MetricsPort:             9090,
@@ -243,10 +248,10 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 				Name:                    "test",
 				Namespace:               "default",
 				StorageSize:             "100Gi",
-				StorageClass:            "standard",
+				StorageClass:            &storage.StorageClassComponent{},
 				Image:                   "test-image",
 				ImagePullPolicy:         "IfNotPresent",
-				JWTSecret:               "test-secret",
+				JWTSecret:               testJWTSecret,
 				P2PPort:                 30303,
 				BeaconAPIPort:           5052,
 				MetricsPort:             0,
@@ -254,6 +259,274 @@ func TestConsensusClientArgs_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid args with snapshots",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				Snapshots:               &storage.SnapshotArgs{SnapshotClass: &storage.VolumeSnapshotClassComponent{}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "snapshots without snapshot class",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				Snapshots:               &storage.SnapshotArgs{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid args with replicas",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				Replicas:                3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative replicas",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				Replicas:                -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "network preset",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				Network:                 "sepolia",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown network",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				Network:                 "goerli",
+			},
+			wantErr: true,
+		},
+		{
+			name: "checkpoint sync url override",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				CheckpointSyncURL:       "https://checkpoint.example.com",
+				FeeRecipient:            "0x1111111111111111111111111111111111111111",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid checkpoint sync url",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				CheckpointSyncURL:       "checkpoint.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid fee recipient",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				FeeRecipient:            "0x1234",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative termination grace period",
+			args: ConsensusClientArgs{
+				Name:                          "test",
+				Namespace:                     "default",
+				StorageSize:                   "100Gi",
+				StorageClass:                  &storage.StorageClassComponent{},
+				Image:                         "test-image",
+				ImagePullPolicy:               "IfNotPresent",
+				JWTSecret:                     testJWTSecret,
+				P2PPort:                       30303,
+				BeaconAPIPort:                 5052,
+				MetricsPort:                   9090,
+				ExecutionClientEndpoint:       "http://execution:8551",
+				TerminationGracePeriodSeconds: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync readiness",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				SyncReadiness:           &SyncReadinessArgs{MaxHeadLag: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative sync readiness head lag",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				JWTSecret:               testJWTSecret,
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				SyncReadiness:           &SyncReadinessArgs{MaxHeadLag: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "existing jwt secret",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				JWTSecretName:           "node-jwt",
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed jwt secret",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				JWTSecret:               "test-secret",
+			},
+			wantErr: true,
+		},
+		{
+			name: "jwt secret with a 0x prefix",
+			args: ConsensusClientArgs{
+				Name:                    "test",
+				Namespace:               "default",
+				StorageSize:             "100Gi",
+				StorageClass:            &storage.StorageClassComponent{},
+				Image:                   "test-image",
+				ImagePullPolicy:         "IfNotPresent",
+				P2PPort:                 30303,
+				BeaconAPIPort:           5052,
+				MetricsPort:             9090,
+				ExecutionClientEndpoint: "http://execution:8551",
+				JWTSecret:               "0x" + testJWTSecret,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
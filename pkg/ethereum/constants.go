@@ -0,0 +1,4 @@
+package ethereum
+
+// JWTSecretSuffix names the Secret holding the Engine API JWT both clients mount
+const JWTSecretSuffix = "-jwt"
@@ -5,6 +5,7 @@ import (
 
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/consensus"
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
@@ -19,6 +20,21 @@ func NewEthereumNodeComponent(ctx *pulumi.Context, args *EthereumNodeArgs, opts
 		Namespace: args.Namespace,
 	}
 
+	// Both clients join the node's network
+	if args.Network != "" {
+		args.ExecutionClient.Network = args.Network
+		args.ConsensusClient.Network = args.Network
+	}
+
+	// Store the Engine API JWT once, generating it when none is given, and mount it in both clients
+	jwtSecret, err := utils.NewSharedJWTSecret(ctx, args.jwtSecretName(), pulumi.String(args.Namespace), args.Name, args.jwtSecret(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jwt secret: %w", err)
+	}
+	component.JWTSecret = jwtSecret
+	args.ExecutionClient.JWTSecretName = args.jwtSecretName()
+	args.ConsensusClient.JWTSecretName = args.jwtSecretName()
+
 	// Create the execution client
 	execClient, err := execution.NewExecutionClient(ctx, args.ExecutionClient, opts...)
 	if err != nil {
@@ -26,10 +42,8 @@ func NewEthereumNodeComponent(ctx *pulumi.Context, args *EthereumNodeArgs, opts
 	}
 	component.ExecutionClient = execClient
 
-	// Create the consensus client
-	// Set the execution client endpoint in the consensus client args
-	args.ConsensusClient.ExecutionClientEndpoint = fmt.Sprintf("http://%s-rpc.%s.svc.cluster.local:%d",
-		args.Name, args.Namespace, args.ExecutionClient.RPCPort)
+	// Create the consensus client, pairing each replica with the execution replica of the same ordinal
+	args.ConsensusClient.ExecutionClientEndpoint = args.executionClientEndpoint()
 
 	consClient, err := consensus.NewConsensusClient(ctx, args.ConsensusClient, opts...)
 	if err != nil {
@@ -2,7 +2,9 @@ package execution
 
 import (
 	"fmt"
+	"path"
 
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
@@ -29,44 +31,23 @@ func NewExecutionClient(ctx *pulumi.Context, args *ExecutionClientArgs, opts ...
 		return nil, fmt.Errorf("failed to register component resource: %w", err)
 	}
 
-	// Create PVC for data storage
-	pvcName := fmt.Sprintf("%s-data", args.Name)
-	component.PVC, err = corev1.NewPersistentVolumeClaim(ctx, pvcName, &corev1.PersistentVolumeClaimArgs{
-		Metadata: &metav1.ObjectMetaArgs{
-			Name:      pulumi.String(pvcName),
-			Namespace: internalArgs.Namespace,
-			Labels:    utils.CreateResourceLabels(args.Name, pvcName, args.Name, nil),
-		},
-		Spec: &corev1.PersistentVolumeClaimSpecArgs{
-			AccessModes: pulumi.StringArray{
-				pulumi.String("ReadWriteOnce"),
+	// Mount the given JWT secret, or create one from JWTSecret
+	jwtSecretName := args.JWTSecretName
+	if jwtSecretName == "" {
+		jwtSecretName = fmt.Sprintf("%s-jwt", args.Name)
+		component.JWTSecret, err = corev1.NewSecret(ctx, jwtSecretName, &corev1.SecretArgs{
+			StringData: pulumi.StringMap{
+				JWTFileName: internalArgs.JWTSecret,
 			},
-			Resources: &corev1.VolumeResourceRequirementsArgs{
-				Requests: pulumi.StringMap{
-					"storage": internalArgs.StorageSize,
-				},
+			Metadata: &metav1.ObjectMetaArgs{
+				Name:      pulumi.String(jwtSecretName),
+				Namespace: internalArgs.Namespace,
+				Labels:    utils.CreateResourceLabels(args.Name, jwtSecretName, args.Name, nil),
 			},
-			StorageClassName: internalArgs.StorageClass,
-		},
-	}, pulumi.Parent(component))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create PVC: %w", err)
-	}
-
-	// Create JWT secret
-	jwtSecretName := fmt.Sprintf("%s-jwt", args.Name)
-	component.JWTSecret, err = corev1.NewSecret(ctx, jwtSecretName, &corev1.SecretArgs{
-		StringData: pulumi.StringMap{
-			"jwt.hex": internalArgs.JWTSecret,
-		},
-		Metadata: &metav1.ObjectMetaArgs{
-			Name:      pulumi.String(jwtSecretName),
-			Namespace: internalArgs.Namespace,
-			Labels:    utils.CreateResourceLabels(args.Name, jwtSecretName, args.Name, nil),
-		},
-	}, pulumi.Parent(component))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create JWT secret: %w", err)
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create JWT secret: %w", err)
+		}
 	}
 
 	// Create P2P service
@@ -113,7 +94,7 @@ func NewExecutionClient(ctx *pulumi.Context, args *ExecutionClientArgs, opts ...
 			Selector: pulumi.StringMap{
 				"app": pulumi.String(args.Name),
 			},
-			Ports: corev1.ServicePortArray{
+			Ports: append(corev1.ServicePortArray{
 				corev1.ServicePortArgs{
 					Name:       pulumi.String("rpc"),
 					Port:       internalArgs.RPCPort,
@@ -134,7 +115,7 @@ func NewExecutionClient(ctx *pulumi.Context, args *ExecutionClientArgs, opts ...
 					Port:       internalArgs.AuthRPCPort,
 					TargetPort: internalArgs.AuthRPCPort,
 				},
-			},
+			}, args.additionalServicePorts()...),
 		},
 	}, pulumi.Parent(component))
 	if err != nil {
@@ -157,18 +138,85 @@ func NewExecutionClient(ctx *pulumi.Context, args *ExecutionClientArgs, opts ...
 			return nil, fmt.Errorf("failed to create ConfigMap: %w", err)
 		}
 		component.ConfigMap = configMap
+
+		// Route sensitive values into a Secret when the env provides them,
+		// unless they are synced from an external store instead
+		if secretEnv, ok := args.ExecutionClientEnv.(utils.SecretEnvProvider); ok && args.ExternalSecretRef == nil {
+			secretName := fmt.Sprintf("%s-secret-env", args.Name)
+			component.EnvSecret, err = corev1.NewSecret(ctx, secretName, &corev1.SecretArgs{
+				StringData: secretEnv.GetSecretEnvMap(),
+				Metadata: &metav1.ObjectMetaArgs{
+					Name:      pulumi.String(secretName),
+					Namespace: internalArgs.Namespace,
+					Labels:    utils.CreateResourceLabels(args.Name, secretName, args.Name, nil),
+				},
+			}, pulumi.Parent(component))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create env Secret: %w", err)
+			}
+		}
+	}
+
+	// Create ExternalSecret for sensitive environment variables if configured
+	if args.ExternalSecretRef != nil {
+		externalSecretName := fmt.Sprintf("%s-external-secret", args.Name)
+		component.ExternalSecret, err = utils.CreateExternalSecret(
+			ctx,
+			externalSecretName,
+			internalArgs.Namespace,
+			utils.CreateResourceLabels(args.Name, externalSecretName, args.Name, nil),
+			*args.ExternalSecretRef,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ExternalSecret: %w", err)
+		}
 	}
 
 	// Create StatefulSet
 	statefulSetName := args.Name
 
+	// Create the headless service governing the StatefulSet, giving each replica a stable DNS name
+	headlessServiceName := fmt.Sprintf("%s%s", statefulSetName, utils.HeadlessServiceSuffix)
+	component.HeadlessService, err = utils.CreateHeadlessService(
+		ctx,
+		headlessServiceName,
+		internalArgs.Namespace,
+		pulumi.StringMap{
+			"app": pulumi.String(args.Name),
+		},
+		corev1.ServicePortArray{
+			corev1.ServicePortArgs{
+				Name:       pulumi.String("p2p"),
+				Port:       internalArgs.P2PPort,
+				TargetPort: internalArgs.P2PPort,
+				Protocol:   pulumi.String("TCP"),
+			},
+			corev1.ServicePortArgs{
+				Name:       pulumi.String("rpc"),
+				Port:       internalArgs.RPCPort,
+				TargetPort: internalArgs.RPCPort,
+			},
+			corev1.ServicePortArgs{
+				Name:       pulumi.String("auth-rpc"),
+				Port:       internalArgs.AuthRPCPort,
+				TargetPort: internalArgs.AuthRPCPort,
+			},
+		},
+		utils.CreateResourceLabels(args.Name, headlessServiceName, args.Name, nil),
+		component,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prepare container spec
 	containerSpec := corev1.ContainerArgs{
 		Name:            pulumi.String("execution"),
 		Image:           internalArgs.Image,
 		ImagePullPolicy: internalArgs.ImagePullPolicy,
 		Command:         createExecutionClientCommand(args),
-		Ports: corev1.ContainerPortArray{
+		Ports: append(corev1.ContainerPortArray{
 			corev1.ContainerPortArgs{
 				Name:          pulumi.String("p2p"),
 				ContainerPort: internalArgs.P2PPort,
@@ -195,39 +243,70 @@ func NewExecutionClient(ctx *pulumi.Context, args *ExecutionClientArgs, opts ...
 				Name:          pulumi.String("auth-rpc"),
 				ContainerPort: internalArgs.AuthRPCPort,
 			},
-		},
-		VolumeMounts: corev1.VolumeMountArray{
+		}, args.additionalContainerPorts()...),
+		VolumeMounts: append(corev1.VolumeMountArray{
 			corev1.VolumeMountArgs{
-				Name:      pulumi.String("data"),
-				MountPath: pulumi.String("/data"),
+				Name:      pulumi.String(DataVolumeName),
+				MountPath: internalArgs.DataMountPath,
 			},
 			corev1.VolumeMountArgs{
-				Name:      pulumi.String("jwt"),
-				MountPath: pulumi.String("/jwt"),
+				Name:      pulumi.String(JWTVolumeName),
+				MountPath: internalArgs.JWTMountPath,
 			},
-		},
-		Resources: nil,
+		}, args.additionalVolumeMounts()...),
+		Resources:      internalArgs.Resources,
+		StartupProbe:   internalArgs.StartupProbe,
+		LivenessProbe:  internalArgs.LivenessProbe,
+		ReadinessProbe: internalArgs.ReadinessProbe,
+		Lifecycle:      internalArgs.Lifecycle,
 	}
 
-	// Add EnvFrom only if ConfigMap exists
+	// Add EnvFrom only if ConfigMap, Secret or ExternalSecret exists
+	envFrom := corev1.EnvFromSourceArray{}
 	if configMap != nil {
-		containerSpec.EnvFrom = corev1.EnvFromSourceArray{
-			&corev1.EnvFromSourceArgs{
-				ConfigMapRef: &corev1.ConfigMapEnvSourceArgs{
-					Name: configMap.Metadata.Name(),
-				},
+		envFrom = append(envFrom, &corev1.EnvFromSourceArgs{
+			ConfigMapRef: &corev1.ConfigMapEnvSourceArgs{
+				Name: configMap.Metadata.Name(),
+			},
+		})
+	}
+	if component.EnvSecret != nil {
+		envFrom = append(envFrom, &corev1.EnvFromSourceArgs{
+			SecretRef: &corev1.SecretEnvSourceArgs{
+				Name: component.EnvSecret.Metadata.Name(),
 			},
+		})
+	}
+	if component.ExternalSecret != nil {
+		envFrom = append(envFrom, &corev1.EnvFromSourceArgs{
+			SecretRef: &corev1.SecretEnvSourceArgs{
+				Name: component.ExternalSecret.Metadata.Name(),
+			},
+		})
+	}
+	if len(envFrom) > 0 {
+		containerSpec.EnvFrom = envFrom
+	}
+
+	// Bootstrap an empty data volume from an S3 archive when configured
+	var initContainers corev1.ContainerArrayInput
+	if args.DataArchive != nil {
+		initContainers = corev1.ContainerArray{
+			args.DataArchive.InitContainer(DataVolumeName, internalArgs.DataMountPath),
 		}
 	}
 
 	component.StatefulSet, err = appsv1.NewStatefulSet(ctx, statefulSetName, &appsv1.StatefulSetArgs{
 		Metadata: &metav1.ObjectMetaArgs{
-			Name:      pulumi.String(statefulSetName),
-			Namespace: internalArgs.Namespace,
-			Labels:    utils.CreateResourceLabels(args.Name, statefulSetName, args.Name, nil),
+			Name:        pulumi.String(statefulSetName),
+			Namespace:   internalArgs.Namespace,
+			Labels:      utils.CreateResourceLabels(args.Name, statefulSetName, args.Name, nil),
+			Annotations: args.statefulSetAnnotations(),
 		},
 		Spec: &appsv1.StatefulSetSpecArgs{
-			Replicas: pulumi.Int(1),
+			Replicas:            internalArgs.Replicas,
+			PodManagementPolicy: args.podManagementPolicy(),
+			ServiceName:         component.HeadlessService.Metadata.Name(),
 			Selector: &metav1.LabelSelectorArgs{
 				MatchLabels: pulumi.StringMap{
 					"app": pulumi.String(args.Name),
@@ -240,69 +319,184 @@ func NewExecutionClient(ctx *pulumi.Context, args *ExecutionClientArgs, opts ...
 					},
 				},
 				Spec: &corev1.PodSpecArgs{
-					Containers: corev1.ContainerArray{
-						containerSpec,
-					},
+					ServiceAccountName: internalArgs.ServiceAccountName,
+					InitContainers:     initContainers,
+					Containers:         append(corev1.ContainerArray{containerSpec}, args.syncReadinessContainers()...),
 					Volumes: corev1.VolumeArray{
 						corev1.VolumeArgs{
-							Name: pulumi.String("data"),
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSourceArgs{
-								ClaimName: pulumi.String(pvcName),
-							},
-						},
-						corev1.VolumeArgs{
-							Name: pulumi.String("jwt"),
+							Name: pulumi.String(JWTVolumeName),
 							Secret: &corev1.SecretVolumeSourceArgs{
 								SecretName: pulumi.String(jwtSecretName),
 							},
 						},
 					},
-					NodeSelector: args.NodeSelector,
-					Tolerations:  args.Tolerations,
+					NodeSelector:                  args.NodeSelector,
+					Tolerations:                   args.Tolerations,
+					Affinity:                      internalArgs.Affinity,
+					PriorityClassName:             internalArgs.PriorityClassName,
+					TerminationGracePeriodSeconds: internalArgs.TerminationGracePeriodSeconds,
 				},
 			},
+			// Each replica gets its own data volumes
+			VolumeClaimTemplates: append(corev1.PersistentVolumeClaimTypeArray{
+				utils.VolumeClaimTemplate(
+					DataVolumeName,
+					internalArgs.StorageSize,
+					internalArgs.StorageClass,
+					internalArgs.DataSnapshotName,
+					utils.CreateResourceLabels(args.Name, DataVolumeName, args.Name, nil),
+				),
+			}, args.additionalClaimTemplates(internalArgs.StorageClass)...),
 		},
-	}, pulumi.Parent(component))
+	}, pulumi.Parent(component), utils.IgnoreClaimTemplateDataSourceChanges())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create StatefulSet: %w", err)
 	}
+	statefulSetOutputName := component.StatefulSet.Metadata.Name().Elem()
+	component.PvcNames = utils.StatefulSetClaimNames(DataVolumeName, statefulSetOutputName, args.replicas())
+	snapshotPvcNames := append(pulumi.StringArray{}, component.PvcNames...)
+	if len(args.AdditionalVolumes) > 0 {
+		component.AdditionalPvcNames = make(map[string]pulumi.StringArray, len(args.AdditionalVolumes))
+		for _, volume := range args.AdditionalVolumes {
+			pvcNames := utils.StatefulSetClaimNames(volume.Name, statefulSetOutputName, args.replicas())
+			component.AdditionalPvcNames[volume.Name] = pvcNames
+			snapshotPvcNames = append(snapshotPvcNames, pvcNames...)
+		}
+	}
+
+	// Snapshot each replica's data volumes on a schedule when configured
+	if args.Snapshots != nil {
+		component.SnapshotSchedule, err = storage.NewVolumeSnapshotScheduleComponent(ctx, &storage.VolumeSnapshotScheduleArgs{
+			Name:      args.Name,
+			Namespace: args.Namespace,
+			PvcNames:  snapshotPvcNames,
+			Snapshots: *args.Snapshots,
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot schedule: %w", err)
+		}
+	}
 
 	return component, nil
 }
 
-// createExecutionClientCommand creates the command array for the execution client
+// createExecutionClientCommand creates the command array for the execution client from its
+// flavor, unless the args replace it with their own command
 func createExecutionClientCommand(args *ExecutionClientArgs) pulumi.StringArray {
-	cmd := pulumi.StringArray{
-		pulumi.String("pylon"),
-		pulumi.String("node"),
-		pulumi.String("--datadir=/data/execution"),
-		pulumi.String("--http"),
-		pulumi.Sprintf("--http.port=%d", args.RPCPort),
-		pulumi.String("--http.addr=0.0.0.0"),
-		pulumi.String("--http.corsdomain=*"),
-		pulumi.String("--http.api=admin,net,eth,web3,debug,txpool,trace"),
-		pulumi.String("--ws"),
-		pulumi.Sprintf("--ws.port=%d", args.WSPort),
-		pulumi.String("--ws.addr=0.0.0.0"),
-		pulumi.String("--ws.api=net,eth"),
-		pulumi.String("--ws.origins=*"),
-		pulumi.Sprintf("--authrpc.port=%d", args.AuthRPCPort),
-		pulumi.String("--authrpc.jwtsecret=/etc/execution/jwt/jwt.hex"),
-		pulumi.String("--authrpc.addr=0.0.0.0"),
-		pulumi.Sprintf("--metrics=0.0.0.0:%d", args.MetricsPort),
-		pulumi.Sprintf("--discovery.port=%d", args.DiscoveryPort),
-		pulumi.Sprintf("--port=%d", args.P2PPort),
+	if len(args.Command) > 0 {
+		return pulumi.ToStringArray(args.Command)
 	}
 
-	// Add bootnodes if provided
-	for _, bootnode := range args.Bootnodes {
-		cmd = append(cmd, pulumi.Sprintf("--bootnodes=%s", bootnode))
-	}
+	cmd := args.flavor().Command(ClientSettings{
+		DataMountPath: args.dataMountPath(),
+		JWTPath:       path.Join(args.jwtMountPath(), JWTFileName),
+		Network:       args.Network,
+		P2PPort:       args.P2PPort,
+		DiscoveryPort: args.DiscoveryPort,
+		RPCPort:       args.RPCPort,
+		WSPort:        args.WSPort,
+		AuthRPCPort:   args.AuthRPCPort,
+		MetricsPort:   args.MetricsPort,
+		Bootnodes:     args.Bootnodes,
+	})
 
 	// Add additional args if provided
-	for _, arg := range args.AdditionalArgs {
-		cmd = append(cmd, pulumi.String(arg))
+	cmd = append(cmd, args.AdditionalArgs...)
+
+	return pulumi.ToStringArray(cmd)
+}
+
+// additionalContainerPorts returns the container ports of the additional ports
+func (args *ExecutionClientArgs) additionalContainerPorts() corev1.ContainerPortArray {
+	ports := corev1.ContainerPortArray{}
+	for _, port := range args.AdditionalPorts {
+		ports = append(ports, corev1.ContainerPortArgs{
+			Name:          pulumi.String(port.Name),
+			ContainerPort: pulumi.Int(port.Port),
+			Protocol:      pulumi.String(port.protocol()),
+		})
+	}
+	return ports
+}
+
+// additionalServicePorts returns the service ports of the additional ports
+func (args *ExecutionClientArgs) additionalServicePorts() corev1.ServicePortArray {
+	ports := corev1.ServicePortArray{}
+	for _, port := range args.AdditionalPorts {
+		ports = append(ports, corev1.ServicePortArgs{
+			Name:       pulumi.String(port.Name),
+			Port:       pulumi.Int(port.Port),
+			TargetPort: pulumi.Int(port.Port),
+			Protocol:   pulumi.String(port.protocol()),
+		})
 	}
+	return ports
+}
 
-	return cmd
+// additionalVolumeMounts returns the mounts of the additional data volumes
+func (args *ExecutionClientArgs) additionalVolumeMounts() corev1.VolumeMountArray {
+	mounts := corev1.VolumeMountArray{}
+	for _, volume := range args.AdditionalVolumes {
+		mounts = append(mounts, corev1.VolumeMountArgs{
+			Name:      pulumi.String(volume.Name),
+			MountPath: pulumi.String(volume.MountPath),
+		})
+	}
+	return mounts
+}
+
+// additionalClaimTemplates returns the claim templates of the additional data volumes
+func (args *ExecutionClientArgs) additionalClaimTemplates(storageClass pulumi.StringInput) corev1.PersistentVolumeClaimTypeArray {
+	templates := corev1.PersistentVolumeClaimTypeArray{}
+	for _, volume := range args.AdditionalVolumes {
+		var snapshotName string
+		if volume.Snapshot != nil {
+			snapshotName = volume.Snapshot.Name
+		}
+		templates = append(templates, utils.VolumeClaimTemplate(
+			volume.Name,
+			pulumi.String(volume.StorageSize),
+			storageClass,
+			snapshotName,
+			utils.CreateResourceLabels(args.Name, volume.Name, args.Name, nil),
+		))
+	}
+	return templates
+}
+
+// statefulSetAnnotations stops pulumi up from waiting for replicas the sync check holds
+// unready, since syncing can take hours
+func (args *ExecutionClientArgs) statefulSetAnnotations() pulumi.StringMapInput {
+	if args.SyncReadiness == nil {
+		return nil
+	}
+	return utils.SkipAwaitAnnotations()
+}
+
+// podManagementPolicy starts replicas in parallel when the sync check holds them unready,
+// so they sync side by side rather than each waiting for the previous one. The policy cannot
+// be changed in place, so toggling SyncReadiness replaces the StatefulSet; its claims are kept
+func (args *ExecutionClientArgs) podManagementPolicy() pulumi.StringPtrInput {
+	if args.SyncReadiness == nil {
+		return nil
+	}
+	return pulumi.StringPtr(utils.PodManagementPolicyParallel)
+}
+
+// syncReadinessContainers returns the sidecar checking the client's sync state, if configured
+func (args *ExecutionClientArgs) syncReadinessContainers() corev1.ContainerArray {
+	if args.SyncReadiness == nil {
+		return nil
+	}
+	minPeers := args.SyncReadiness.MinPeers
+	if minPeers == 0 {
+		minPeers = DefaultSyncReadinessMinPeers
+	}
+	return corev1.ContainerArray{
+		utils.ReadinessSidecar(args.SyncReadiness.Image, syncCheckScript, corev1.EnvVarArray{
+			corev1.EnvVarArgs{Name: pulumi.String("RPC_URL"), Value: pulumi.Sprintf("http://localhost:%d", args.RPCPort)},
+			corev1.EnvVarArgs{Name: pulumi.String("MAX_HEAD_LAG"), Value: pulumi.Sprintf("%d", args.SyncReadiness.MaxHeadLag)},
+			corev1.EnvVarArgs{Name: pulumi.String("MIN_PEERS"), Value: pulumi.Sprintf("%d", minPeers)},
+		}),
+	}
 }
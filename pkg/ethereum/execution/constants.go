@@ -0,0 +1,72 @@
+package execution
+
+import "github.com/init4tech/signet-infra-components/pkg/utils"
+
+// StatefulSet defaults
+const (
+	DefaultReplicas = 1
+	// DataVolumeName names the data volume and the claim template it is created from
+	DataVolumeName = "data"
+)
+
+// Container defaults
+const (
+	DefaultDataMountPath = "/data"
+	DefaultJWTMountPath  = "/etc/execution/jwt"
+	JWTVolumeName        = "jwt"
+	// JWTFileName is the key of the JWT in its secret and the file name it is mounted as
+	JWTFileName = utils.JWTSecretKey
+	// DefaultPortProtocol is the protocol of additional ports that do not set one
+	DefaultPortProtocol = "TCP"
+)
+
+// Probe and shutdown defaults. The startup probe allows ten minutes for the database to open,
+// and the grace period gives the client time to flush it rather than be killed mid-write.
+const (
+	StartupProbePeriodSeconds            = 10
+	StartupProbeFailureThreshold         = 60
+	LivenessProbePeriodSeconds           = 30
+	LivenessProbeFailureThreshold        = 5
+	ReadinessProbePeriodSeconds          = 10
+	ReadinessProbeFailureThreshold       = 3
+	DefaultTerminationGracePeriodSeconds = 300
+)
+
+// Execution client flavor names
+const (
+	FlavorReth       = "reth"
+	FlavorPylon      = "pylon"
+	FlavorSignetNode = "signet-node"
+	FlavorGeth       = "geth"
+	FlavorNethermind = "nethermind"
+)
+
+// Sync readiness defaults
+const (
+	DefaultSyncReadinessMinPeers = 1
+)
+
+// syncCheckScript fails while the client at $RPC_URL trails the head it syncs to by more than
+// $MAX_HEAD_LAG blocks or has fewer than $MIN_PEERS peers
+const syncCheckScript = `set -eu
+rpc() {
+  wget -q -T 5 -O - --header 'Content-Type: application/json' \
+    --post-data "{\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"$1\",\"params\":[]}" "${RPC_URL}"
+}
+hex() {
+  sed -n "s/.*\"$1\": *\"0x\([0-9a-fA-F]*\)\".*/\1/p"
+}
+syncing=$(rpc eth_syncing)
+case "${syncing}" in
+  *'"result":false'* | *'"result": false'*) ;;
+  *)
+    current=$(echo "${syncing}" | hex currentBlock)
+    highest=$(echo "${syncing}" | hex highestBlock)
+    [ -n "${current}" ] && [ -n "${highest}" ] && [ $((0x${highest})) -gt 0 ] || { echo "syncing"; exit 1; }
+    lag=$((0x${highest} - 0x${current}))
+    [ "${lag}" -le "${MAX_HEAD_LAG}" ] || { echo "syncing, ${lag} blocks behind"; exit 1; }
+    ;;
+esac
+peers=$(rpc net_peerCount | hex result)
+[ -n "${peers}" ] && [ $((0x${peers})) -ge "${MIN_PEERS}" ] || { echo "fewer than ${MIN_PEERS} peers"; exit 1; }
+`
@@ -0,0 +1,158 @@
+package execution
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ExecutionClientFlavor is an execution client implementation. It owns the client's binary,
+// flag syntax and data directory layout.
+type ExecutionClientFlavor interface {
+	// Name identifies the client
+	Name() string
+	// Command returns the command starting the client with the given settings
+	Command(settings ClientSettings) []string
+}
+
+// ClientSettings are the paths and ports an execution client is started with
+type ClientSettings struct {
+	// DataMountPath is where the data volume is mounted, the flavor picks its data directory below it
+	DataMountPath string
+	// JWTPath is the path of the Engine API JWT file
+	JWTPath string
+	// Network is the name of the network to join, empty leaves the client's default
+	Network       string
+	P2PPort       int
+	DiscoveryPort int
+	RPCPort       int
+	WSPort        int
+	AuthRPCPort   int
+	MetricsPort   int
+	Bootnodes     []string
+}
+
+// Execution client flavors
+var (
+	Reth       ExecutionClientFlavor = rethFlavor{name: FlavorReth, binary: []string{"reth", "node"}, dataDir: "reth"}
+	Pylon      ExecutionClientFlavor = rethFlavor{name: FlavorPylon, binary: []string{"pylon", "node"}, dataDir: "execution"}
+	SignetNode ExecutionClientFlavor = rethFlavor{name: FlavorSignetNode, binary: []string{"signet", "node"}, dataDir: "signet"}
+	Geth       ExecutionClientFlavor = gethFlavor{}
+	Nethermind ExecutionClientFlavor = nethermindFlavor{}
+)
+
+// rethFlavor runs reth or a client built on it, which share reth's flags
+type rethFlavor struct {
+	name    string
+	binary  []string
+	dataDir string
+}
+
+func (f rethFlavor) Name() string {
+	return f.name
+}
+
+func (f rethFlavor) Command(settings ClientSettings) []string {
+	cmd := append([]string{}, f.binary...)
+	cmd = append(cmd,
+		fmt.Sprintf("--datadir=%s", path.Join(settings.DataMountPath, f.dataDir)),
+		"--http",
+		fmt.Sprintf("--http.port=%d", settings.RPCPort),
+		"--http.addr=0.0.0.0",
+		"--http.corsdomain=*",
+		"--http.api=admin,net,eth,web3,debug,txpool,trace",
+		"--ws",
+		fmt.Sprintf("--ws.port=%d", settings.WSPort),
+		"--ws.addr=0.0.0.0",
+		"--ws.api=net,eth",
+		"--ws.origins=*",
+		fmt.Sprintf("--authrpc.port=%d", settings.AuthRPCPort),
+		fmt.Sprintf("--authrpc.jwtsecret=%s", settings.JWTPath),
+		"--authrpc.addr=0.0.0.0",
+		fmt.Sprintf("--metrics=0.0.0.0:%d", settings.MetricsPort),
+		fmt.Sprintf("--discovery.port=%d", settings.DiscoveryPort),
+		fmt.Sprintf("--port=%d", settings.P2PPort),
+	)
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--chain=%s", settings.Network))
+	}
+	if len(settings.Bootnodes) > 0 {
+		cmd = append(cmd, fmt.Sprintf("--bootnodes=%s", strings.Join(settings.Bootnodes, ",")))
+	}
+	return cmd
+}
+
+// gethFlavor runs go-ethereum
+type gethFlavor struct{}
+
+func (gethFlavor) Name() string {
+	return FlavorGeth
+}
+
+func (gethFlavor) Command(settings ClientSettings) []string {
+	cmd := []string{
+		"geth",
+		fmt.Sprintf("--datadir=%s", path.Join(settings.DataMountPath, "geth")),
+		"--http",
+		fmt.Sprintf("--http.port=%d", settings.RPCPort),
+		"--http.addr=0.0.0.0",
+		"--http.corsdomain=*",
+		"--http.vhosts=*",
+		"--http.api=admin,net,eth,web3,debug,txpool",
+		"--ws",
+		fmt.Sprintf("--ws.port=%d", settings.WSPort),
+		"--ws.addr=0.0.0.0",
+		"--ws.api=net,eth",
+		"--ws.origins=*",
+		fmt.Sprintf("--authrpc.port=%d", settings.AuthRPCPort),
+		fmt.Sprintf("--authrpc.jwtsecret=%s", settings.JWTPath),
+		"--authrpc.addr=0.0.0.0",
+		"--authrpc.vhosts=*",
+		"--metrics",
+		"--metrics.addr=0.0.0.0",
+		fmt.Sprintf("--metrics.port=%d", settings.MetricsPort),
+		fmt.Sprintf("--discovery.port=%d", settings.DiscoveryPort),
+		fmt.Sprintf("--port=%d", settings.P2PPort),
+	}
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--%s", settings.Network))
+	}
+	if len(settings.Bootnodes) > 0 {
+		cmd = append(cmd, fmt.Sprintf("--bootnodes=%s", strings.Join(settings.Bootnodes, ",")))
+	}
+	return cmd
+}
+
+// nethermindFlavor runs Nethermind, which takes its settings as <Module>.<Option> flags
+type nethermindFlavor struct{}
+
+func (nethermindFlavor) Name() string {
+	return FlavorNethermind
+}
+
+func (nethermindFlavor) Command(settings ClientSettings) []string {
+	cmd := []string{
+		"/nethermind/nethermind",
+		fmt.Sprintf("--data-dir=%s", path.Join(settings.DataMountPath, "nethermind")),
+		"--JsonRpc.Enabled=true",
+		"--JsonRpc.Host=0.0.0.0",
+		fmt.Sprintf("--JsonRpc.Port=%d", settings.RPCPort),
+		"--Init.WebSocketsEnabled=true",
+		fmt.Sprintf("--JsonRpc.WebSocketsPort=%d", settings.WSPort),
+		"--JsonRpc.EngineHost=0.0.0.0",
+		fmt.Sprintf("--JsonRpc.EnginePort=%d", settings.AuthRPCPort),
+		fmt.Sprintf("--JsonRpc.JwtSecretFile=%s", settings.JWTPath),
+		"--Metrics.Enabled=true",
+		"--Metrics.ExposeHost=0.0.0.0",
+		fmt.Sprintf("--Metrics.ExposePort=%d", settings.MetricsPort),
+		fmt.Sprintf("--Network.P2PPort=%d", settings.P2PPort),
+		fmt.Sprintf("--Network.DiscoveryPort=%d", settings.DiscoveryPort),
+	}
+	if settings.Network != "" {
+		cmd = append(cmd, fmt.Sprintf("--config=%s", settings.Network))
+	}
+	if len(settings.Bootnodes) > 0 {
+		cmd = append(cmd, fmt.Sprintf("--Discovery.Bootnodes=%s", strings.Join(settings.Bootnodes, ",")))
+	}
+	return cmd
+}
@@ -0,0 +1,169 @@
+package execution
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestExecutionClientFlavor_Command(t *testing.T) {
+	settings := ClientSettings{
+		DataMountPath: "/data",
+		JWTPath:       "/etc/execution/jwt/jwt.hex",
+		P2PPort:       30303,
+		DiscoveryPort: 30304,
+		RPCPort:       8545,
+		WSPort:        8546,
+		AuthRPCPort:   8551,
+		MetricsPort:   9001,
+		Bootnodes:     []string{"enode://a@10.0.0.1:30303", "enode://b@10.0.0.2:30303"},
+	}
+	rethFlags := func(dataDir string) []string {
+		return []string{
+			"--datadir=" + dataDir,
+			"--http",
+			"--http.port=8545",
+			"--http.addr=0.0.0.0",
+			"--http.corsdomain=*",
+			"--http.api=admin,net,eth,web3,debug,txpool,trace",
+			"--ws",
+			"--ws.port=8546",
+			"--ws.addr=0.0.0.0",
+			"--ws.api=net,eth",
+			"--ws.origins=*",
+			"--authrpc.port=8551",
+			"--authrpc.jwtsecret=/etc/execution/jwt/jwt.hex",
+			"--authrpc.addr=0.0.0.0",
+			"--metrics=0.0.0.0:9001",
+			"--discovery.port=30304",
+			"--port=30303",
+			"--bootnodes=enode://a@10.0.0.1:30303,enode://b@10.0.0.2:30303",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		flavor ExecutionClientFlavor
+		want   []string
+	}{
+		{
+			name:   FlavorReth,
+			flavor: Reth,
+			want:   append([]string{"reth", "node"}, rethFlags("/data/reth")...),
+		},
+		{
+			name:   FlavorPylon,
+			flavor: Pylon,
+			want:   append([]string{"pylon", "node"}, rethFlags("/data/execution")...),
+		},
+		{
+			name:   FlavorSignetNode,
+			flavor: SignetNode,
+			want:   append([]string{"signet", "node"}, rethFlags("/data/signet")...),
+		},
+		{
+			name:   FlavorGeth,
+			flavor: Geth,
+			want: []string{
+				"geth",
+				"--datadir=/data/geth",
+				"--http",
+				"--http.port=8545",
+				"--http.addr=0.0.0.0",
+				"--http.corsdomain=*",
+				"--http.vhosts=*",
+				"--http.api=admin,net,eth,web3,debug,txpool",
+				"--ws",
+				"--ws.port=8546",
+				"--ws.addr=0.0.0.0",
+				"--ws.api=net,eth",
+				"--ws.origins=*",
+				"--authrpc.port=8551",
+				"--authrpc.jwtsecret=/etc/execution/jwt/jwt.hex",
+				"--authrpc.addr=0.0.0.0",
+				"--authrpc.vhosts=*",
+				"--metrics",
+				"--metrics.addr=0.0.0.0",
+				"--metrics.port=9001",
+				"--discovery.port=30304",
+				"--port=30303",
+				"--bootnodes=enode://a@10.0.0.1:30303,enode://b@10.0.0.2:30303",
+			},
+		},
+		{
+			name:   FlavorNethermind,
+			flavor: Nethermind,
+			want: []string{
+				"/nethermind/nethermind",
+				"--data-dir=/data/nethermind",
+				"--JsonRpc.Enabled=true",
+				"--JsonRpc.Host=0.0.0.0",
+				"--JsonRpc.Port=8545",
+				"--Init.WebSocketsEnabled=true",
+				"--JsonRpc.WebSocketsPort=8546",
+				"--JsonRpc.EngineHost=0.0.0.0",
+				"--JsonRpc.EnginePort=8551",
+				"--JsonRpc.JwtSecretFile=/etc/execution/jwt/jwt.hex",
+				"--Metrics.Enabled=true",
+				"--Metrics.ExposeHost=0.0.0.0",
+				"--Metrics.ExposePort=9001",
+				"--Network.P2PPort=30303",
+				"--Network.DiscoveryPort=30304",
+				"--Discovery.Bootnodes=enode://a@10.0.0.1:30303,enode://b@10.0.0.2:30303",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flavor.Name(); got != tt.name {
+				t.Errorf("Name() = %q, want %q", got, tt.name)
+			}
+			if got := tt.flavor.Command(settings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Command() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateExecutionClientCommand(t *testing.T) {
+	args := &ExecutionClientArgs{
+		Flavor:         Geth,
+		RPCPort:        8545,
+		AdditionalArgs: []string{"--syncmode=snap"},
+	}
+	cmd := createExecutionClientCommand(args)
+	if len(cmd) == 0 || cmd[0] != pulumi.String("geth") || cmd[len(cmd)-1] != pulumi.String("--syncmode=snap") {
+		t.Errorf("createExecutionClientCommand() did not run geth with the additional args: %v", cmd)
+	}
+
+	args.Command = []string{"./start"}
+	if cmd := createExecutionClientCommand(args); len(cmd) != 1 || cmd[0] != pulumi.String("./start") {
+		t.Errorf("createExecutionClientCommand() did not use the command override: %v", cmd)
+	}
+}
+
+func TestCreateExecutionClientCommand_Network(t *testing.T) {
+	tests := []struct {
+		flavor ExecutionClientFlavor
+		want   string
+	}{
+		{flavor: Pylon, want: "--chain=sepolia"},
+		{flavor: Geth, want: "--sepolia"},
+		{flavor: Nethermind, want: "--config=sepolia"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flavor.Name(), func(t *testing.T) {
+			cmd := createExecutionClientCommand(&ExecutionClientArgs{Flavor: tt.flavor, Network: "sepolia"})
+			found := false
+			for _, arg := range cmd {
+				found = found || arg == pulumi.String(tt.want)
+			}
+			if !found {
+				t.Errorf("createExecutionClientCommand() = %v, missing %s", cmd, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package execution
 
 import (
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
+	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
@@ -17,20 +19,43 @@ type ExecutionClientArgs struct {
 	Namespace string
 	// StorageSize is the size of the persistent volume claim
 	StorageSize string
-	// StorageClass is the Kubernetes storage class to use
-	StorageClass string
+	// StorageClass is the storage class for the data volume
+	StorageClass *storage.StorageClassComponent
+	// Flavor is the execution client implementation the image runs, defaults to Pylon
+	Flavor ExecutionClientFlavor
 	// Image is the container image to use
 	Image string
 	// ImagePullPolicy is the Kubernetes image pull policy
 	ImagePullPolicy string
+	// Replicas is the number of replicas, each with its own data volume, defaults to 1
+	Replicas int
 	// Resources contains the resource requests and limits
 	Resources *corev1.ResourceRequirements
 	// NodeSelector is the Kubernetes node selector
 	NodeSelector pulumi.StringMap
 	// Tolerations are the Kubernetes tolerations
 	Tolerations corev1.TolerationArray
-	// JWTSecret is the JWT secret for authentication
+	// Affinity holds the Kubernetes affinity rules
+	Affinity *corev1.AffinityArgs
+	// PriorityClassName is the Kubernetes priority class of the pods
+	PriorityClassName string
+	// StartupProbe optionally replaces the default probe waiting for the authrpc port to open
+	StartupProbe *corev1.ProbeArgs
+	// LivenessProbe optionally replaces the default probe checking the authrpc port
+	LivenessProbe *corev1.ProbeArgs
+	// ReadinessProbe optionally replaces the default probe checking the rpc port
+	ReadinessProbe *corev1.ProbeArgs
+	// SyncReadiness optionally holds replicas out of their services while they sync
+	SyncReadiness *SyncReadinessArgs
+	// TerminationGracePeriodSeconds is how long the client may take to shut down, defaults to 300
+	TerminationGracePeriodSeconds int
+	// PreStopCommand optionally runs in the container before it is stopped
+	PreStopCommand []string
+	// JWTSecret is the hex encoded 32 byte Engine API JWT secret, required unless JWTSecretName is set
 	JWTSecret string
+	// JWTSecretName optionally mounts an existing Secret holding the JWT under "jwt.hex" instead of
+	// creating one from JWTSecret
+	JWTSecretName string
 	// P2PPort is the port for P2P communication
 	P2PPort int
 	// RPCPort is the port for RPC communication
@@ -43,12 +68,77 @@ type ExecutionClientArgs struct {
 	AuthRPCPort int
 	// DiscoveryPort is the port for node discovery
 	DiscoveryPort int
-	// Bootnodes is a list of bootnode URLs
+	// Network is the name of a network preset to join, see the network package. Empty leaves
+	// the client's default network.
+	Network string
+	// Bootnodes is a list of bootnode URLs, empty keeps the client's built-in list
 	Bootnodes []string
 	// AdditionalArgs are additional command line arguments
 	AdditionalArgs []string
+	// Command optionally replaces the generated client command
+	Command []string
+	// DataMountPath is where the data volume is mounted, defaults to "/data"
+	DataMountPath string
+	// JWTMountPath is where the JWT secret is mounted, defaults to "/etc/execution/jwt"
+	JWTMountPath string
+	// AdditionalVolumes are further per-replica data volumes, mounted next to the data volume
+	AdditionalVolumes []DataVolume
+	// AdditionalPorts are further ports exposed by the container and the RPC service
+	AdditionalPorts []ServicePort
 	// Environment variables for the execution client, accepts a generic type that implements the utils.EnvProvider interface
 	ExecutionClientEnv utils.EnvProvider
+	// ExternalSecretRef optionally syncs sensitive env from AWS Secrets Manager instead of ExecutionClientEnv
+	ExternalSecretRef *utils.ExternalSecretRef
+	// ServiceAccountName optionally runs the pod under an existing Kubernetes service account
+	ServiceAccountName string
+	// Snapshots optionally takes scheduled snapshots of the data volume
+	Snapshots *storage.SnapshotArgs
+	// DataSnapshot optionally restores a new data volume from an existing snapshot
+	DataSnapshot *storage.VolumeSnapshotSource
+	// DataArchive optionally bootstraps an empty data volume from an archive in S3, read with
+	// the IAM role of ServiceAccountName
+	DataArchive *storage.ArchiveSource
+}
+
+// DataVolume describes an additional per-replica data volume
+type DataVolume struct {
+	// Name names the volume and the claim template it is created from
+	Name string
+	// StorageSize is the size of each claim
+	StorageSize string
+	// MountPath is where the volume is mounted in the container
+	MountPath string
+	// Snapshot optionally restores new claims from an existing snapshot
+	Snapshot *storage.VolumeSnapshotSource
+}
+
+// SyncReadinessArgs configures a sidecar that keeps a replica unready while eth_syncing reports
+// it behind the chain head or it lacks peers
+type SyncReadinessArgs struct {
+	// Image runs the check and needs sh, wget and sed, defaults to busybox
+	Image string
+	// MaxHeadLag is how many blocks a ready replica may trail the head it syncs to
+	MaxHeadLag int
+	// MinPeers is the fewest peers a ready replica has, defaults to 1
+	MinPeers int
+}
+
+// ServicePort describes an additional port of the execution client
+type ServicePort struct {
+	// Name is the port name, unique within the pod
+	Name string
+	// Port is both the container and the service port
+	Port int
+	// Protocol is the port protocol, defaults to TCP
+	Protocol string
+}
+
+// protocol returns the port protocol, defaulting to TCP
+func (port ServicePort) protocol() string {
+	if port.Protocol == "" {
+		return DefaultPortProtocol
+	}
+	return port.Protocol
 }
 
 // Internal structs with Pulumi types
@@ -60,18 +150,34 @@ type executionClientArgsInternal struct {
 	Namespace pulumi.StringInput
 	// StorageSize is the size of the persistent volume claim
 	StorageSize pulumi.StringInput
-	// StorageClass is the Kubernetes storage class to use
+	// StorageClass is the name of the storage class for the data volume
 	StorageClass pulumi.StringInput
 	// Image is the container image to use
 	Image pulumi.StringInput
 	// ImagePullPolicy is the Kubernetes image pull policy
 	ImagePullPolicy pulumi.StringInput
+	// Replicas is the number of replicas
+	Replicas pulumi.IntInput
 	// Resources contains the resource requests and limits
-	Resources *corev1.ResourceRequirements
+	Resources corev1.ResourceRequirementsPtrInput
 	// NodeSelector is the Kubernetes node selector
 	NodeSelector pulumi.StringMap
 	// Tolerations are the Kubernetes tolerations
 	Tolerations corev1.TolerationArray
+	// Affinity holds the Kubernetes affinity rules
+	Affinity corev1.AffinityPtrInput
+	// PriorityClassName is the Kubernetes priority class of the pods
+	PriorityClassName pulumi.StringPtrInput
+	// StartupProbe waits for the client to start
+	StartupProbe corev1.ProbePtrInput
+	// LivenessProbe restarts a client that stopped responding
+	LivenessProbe corev1.ProbePtrInput
+	// ReadinessProbe removes a client from its services while it is not serving
+	ReadinessProbe corev1.ProbePtrInput
+	// TerminationGracePeriodSeconds is how long the client may take to shut down
+	TerminationGracePeriodSeconds pulumi.IntInput
+	// Lifecycle holds the preStop hook, if configured
+	Lifecycle corev1.LifecyclePtrInput
 	// JWTSecret is the JWT secret for authentication
 	JWTSecret pulumi.StringInput
 	// P2PPort is the port for P2P communication
@@ -90,35 +196,132 @@ type executionClientArgsInternal struct {
 	Bootnodes pulumi.StringArray
 	// AdditionalArgs are additional command line arguments
 	AdditionalArgs pulumi.StringArray
+	// DataMountPath is where the data volume is mounted
+	DataMountPath pulumi.StringInput
+	// JWTMountPath is where the JWT secret is mounted
+	JWTMountPath pulumi.StringInput
 	// Environment variables for the execution client, accepts a generic type that implements the utils.EnvProvider interface
 	ExecutionClientEnv utils.EnvProvider
+	// ExternalSecretRef optionally syncs sensitive env from AWS Secrets Manager instead of ExecutionClientEnv
+	ExternalSecretRef *utils.ExternalSecretRef
+	// ServiceAccountName optionally runs the pod under an existing Kubernetes service account
+	ServiceAccountName pulumi.StringPtrInput
+	// DataSnapshotName is the snapshot new data volumes are restored from, if configured
+	DataSnapshotName string
 }
 
 // Conversion functions
 
 // toInternal converts public args to internal args for use with Pulumi
 func (args ExecutionClientArgs) toInternal() executionClientArgsInternal {
-	return executionClientArgsInternal{
-		Name:               pulumi.String(args.Name),
-		Namespace:          pulumi.String(args.Namespace),
-		StorageSize:        pulumi.String(args.StorageSize),
-		StorageClass:       pulumi.String(args.StorageClass),
-		Image:              pulumi.String(args.Image),
-		ImagePullPolicy:    pulumi.String(args.ImagePullPolicy),
-		Resources:          args.Resources,
-		NodeSelector:       args.NodeSelector,
-		Tolerations:        args.Tolerations,
-		JWTSecret:          pulumi.String(args.JWTSecret),
-		P2PPort:            pulumi.Int(args.P2PPort),
-		RPCPort:            pulumi.Int(args.RPCPort),
-		WSPort:             pulumi.Int(args.WSPort),
-		MetricsPort:        pulumi.Int(args.MetricsPort),
-		AuthRPCPort:        pulumi.Int(args.AuthRPCPort),
-		DiscoveryPort:      pulumi.Int(args.DiscoveryPort),
-		Bootnodes:          pulumi.ToStringArray(args.Bootnodes),
-		AdditionalArgs:     pulumi.ToStringArray(args.AdditionalArgs),
-		ExecutionClientEnv: args.ExecutionClientEnv,
+	internal := executionClientArgsInternal{
+		Name:                          pulumi.String(args.Name),
+		Namespace:                     pulumi.String(args.Namespace),
+		StorageSize:                   pulumi.String(args.StorageSize),
+		StorageClass:                  args.StorageClass.Name,
+		Image:                         pulumi.String(args.Image),
+		ImagePullPolicy:               pulumi.String(args.ImagePullPolicy),
+		Replicas:                      pulumi.Int(args.replicas()),
+		TerminationGracePeriodSeconds: pulumi.Int(args.terminationGracePeriodSeconds()),
+		Resources:                     utils.ResourceRequirements(args.Resources),
+		NodeSelector:                  args.NodeSelector,
+		Tolerations:                   args.Tolerations,
+		StartupProbe:                  args.startupProbe(),
+		LivenessProbe:                 args.livenessProbe(),
+		ReadinessProbe:                args.readinessProbe(),
+		Lifecycle:                     utils.PreStopLifecycle(args.PreStopCommand),
+		JWTSecret:                     utils.SecretString(args.JWTSecret),
+		P2PPort:                       pulumi.Int(args.P2PPort),
+		RPCPort:                       pulumi.Int(args.RPCPort),
+		WSPort:                        pulumi.Int(args.WSPort),
+		MetricsPort:                   pulumi.Int(args.MetricsPort),
+		AuthRPCPort:                   pulumi.Int(args.AuthRPCPort),
+		DiscoveryPort:                 pulumi.Int(args.DiscoveryPort),
+		Bootnodes:                     pulumi.ToStringArray(args.Bootnodes),
+		AdditionalArgs:                pulumi.ToStringArray(args.AdditionalArgs),
+		DataMountPath:                 pulumi.String(args.dataMountPath()),
+		JWTMountPath:                  pulumi.String(args.jwtMountPath()),
+		ExecutionClientEnv:            args.ExecutionClientEnv,
+		ExternalSecretRef:             args.ExternalSecretRef,
+	}
+	if args.Affinity != nil {
+		internal.Affinity = args.Affinity
+	}
+	if args.PriorityClassName != "" {
+		internal.PriorityClassName = pulumi.StringPtr(args.PriorityClassName)
+	}
+	if args.ServiceAccountName != "" {
+		internal.ServiceAccountName = pulumi.StringPtr(args.ServiceAccountName)
+	}
+	if args.DataSnapshot != nil {
+		internal.DataSnapshotName = args.DataSnapshot.Name
+	}
+	return internal
+}
+
+// replicas returns the number of replicas, defaulting to DefaultReplicas
+func (args ExecutionClientArgs) replicas() int {
+	if args.Replicas == 0 {
+		return DefaultReplicas
+	}
+	return args.Replicas
+}
+
+// terminationGracePeriodSeconds returns the shutdown grace period, defaulting to
+// DefaultTerminationGracePeriodSeconds
+func (args ExecutionClientArgs) terminationGracePeriodSeconds() int {
+	if args.TerminationGracePeriodSeconds == 0 {
+		return DefaultTerminationGracePeriodSeconds
+	}
+	return args.TerminationGracePeriodSeconds
+}
+
+// startupProbe returns the startup probe, defaulting to waiting for the authrpc port to open
+func (args ExecutionClientArgs) startupProbe() *corev1.ProbeArgs {
+	if args.StartupProbe != nil {
+		return args.StartupProbe
+	}
+	return utils.TCPProbe(args.AuthRPCPort, StartupProbePeriodSeconds, StartupProbeFailureThreshold)
+}
+
+// livenessProbe returns the liveness probe, defaulting to checking the authrpc port
+func (args ExecutionClientArgs) livenessProbe() *corev1.ProbeArgs {
+	if args.LivenessProbe != nil {
+		return args.LivenessProbe
+	}
+	return utils.TCPProbe(args.AuthRPCPort, LivenessProbePeriodSeconds, LivenessProbeFailureThreshold)
+}
+
+// readinessProbe returns the readiness probe, defaulting to checking the rpc port
+func (args ExecutionClientArgs) readinessProbe() *corev1.ProbeArgs {
+	if args.ReadinessProbe != nil {
+		return args.ReadinessProbe
+	}
+	return utils.TCPProbe(args.RPCPort, ReadinessProbePeriodSeconds, ReadinessProbeFailureThreshold)
+}
+
+// flavor returns the execution client flavor, defaulting to Pylon
+func (args ExecutionClientArgs) flavor() ExecutionClientFlavor {
+	if args.Flavor == nil {
+		return Pylon
+	}
+	return args.Flavor
+}
+
+// dataMountPath returns where the data volume is mounted, defaulting to DefaultDataMountPath
+func (args ExecutionClientArgs) dataMountPath() string {
+	if args.DataMountPath == "" {
+		return DefaultDataMountPath
+	}
+	return args.DataMountPath
+}
+
+// jwtMountPath returns where the JWT secret is mounted, defaulting to DefaultJWTMountPath
+func (args ExecutionClientArgs) jwtMountPath() string {
+	if args.JWTMountPath == "" {
+		return DefaultJWTMountPath
 	}
+	return args.JWTMountPath
 }
 
 // ExecutionClientComponent represents an execution client deployment
@@ -131,14 +334,24 @@ type ExecutionClientComponent struct {
 	Namespace string
 	// ConfigMap is the shared config map
 	ConfigMap *corev1.ConfigMap
-	// PVC is the persistent volume claim
-	PVC *corev1.PersistentVolumeClaim
-	// JWTSecret is the JWT secret
+	// EnvSecret holds the sensitive environment variables, if any
+	EnvSecret *corev1.Secret
+	// ExternalSecret syncs sensitive environment variables from AWS Secrets Manager, if configured
+	ExternalSecret *crd.CustomResource
+	// PvcNames are the names of the data volume claims, one per replica
+	PvcNames pulumi.StringArray
+	// AdditionalPvcNames are the claim names of each additional volume, one per replica
+	AdditionalPvcNames map[string]pulumi.StringArray
+	// JWTSecret is the JWT secret, nil when JWTSecretName mounts an existing one
 	JWTSecret *corev1.Secret
 	// P2PService is the P2P service
 	P2PService *corev1.Service
 	// RPCService is the RPC service
 	RPCService *corev1.Service
+	// HeadlessService governs the stateful set, giving each replica a stable DNS name
+	HeadlessService *corev1.Service
 	// StatefulSet is the stateful set
 	StatefulSet *appsv1.StatefulSet
+	// SnapshotSchedule snapshots the data volume, if configured
+	SnapshotSchedule *storage.VolumeSnapshotScheduleComponent
 }
@@ -0,0 +1,79 @@
+package execution
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestExecutionClientArgs_Probes(t *testing.T) {
+	args := ExecutionClientArgs{RPCPort: 8545, AuthRPCPort: 8551}
+
+	if port := args.startupProbe().TcpSocket.(*corev1.TCPSocketActionArgs).Port; port != pulumi.Int(8551) {
+		t.Errorf("startupProbe() port = %v, want the authrpc port", port)
+	}
+	if port := args.livenessProbe().TcpSocket.(*corev1.TCPSocketActionArgs).Port; port != pulumi.Int(8551) {
+		t.Errorf("livenessProbe() port = %v, want the authrpc port", port)
+	}
+	if port := args.readinessProbe().TcpSocket.(*corev1.TCPSocketActionArgs).Port; port != pulumi.Int(8545) {
+		t.Errorf("readinessProbe() port = %v, want the rpc port", port)
+	}
+	if got := args.terminationGracePeriodSeconds(); got != DefaultTerminationGracePeriodSeconds {
+		t.Errorf("terminationGracePeriodSeconds() = %d, want %d", got, DefaultTerminationGracePeriodSeconds)
+	}
+
+	override := &corev1.ProbeArgs{PeriodSeconds: pulumi.Int(60)}
+	args.ReadinessProbe = override
+	args.TerminationGracePeriodSeconds = 600
+	if args.readinessProbe() != override {
+		t.Errorf("readinessProbe() did not return the override")
+	}
+	if got := args.terminationGracePeriodSeconds(); got != 600 {
+		t.Errorf("terminationGracePeriodSeconds() = %d, want 600", got)
+	}
+}
+
+func TestExecutionClientArgs_SyncReadinessContainers(t *testing.T) {
+	args := ExecutionClientArgs{RPCPort: 8545}
+	if containers := args.syncReadinessContainers(); containers != nil {
+		t.Errorf("syncReadinessContainers() = %v, want none without sync readiness", containers)
+	}
+
+	args.SyncReadiness = &SyncReadinessArgs{MaxHeadLag: 2}
+	containers := args.syncReadinessContainers()
+	if len(containers) != 1 {
+		t.Fatalf("syncReadinessContainers() returned %d containers, want 1", len(containers))
+	}
+	env := containers[0].(corev1.ContainerArgs).Env.(corev1.EnvVarArray)
+	want := []string{"RPC_URL", "MAX_HEAD_LAG", "MIN_PEERS"}
+	if len(env) != len(want) {
+		t.Fatalf("sidecar env = %v, want %v", env, want)
+	}
+	for i, v := range env {
+		if name := v.(corev1.EnvVarArgs).Name; name != pulumi.String(want[i]) {
+			t.Errorf("sidecar env %d = %v, want %s", i, name, want[i])
+		}
+	}
+}
+
+func TestExecutionClientArgs_SyncReadinessRollout(t *testing.T) {
+	args := ExecutionClientArgs{}
+	if got := args.statefulSetAnnotations(); got != nil {
+		t.Errorf("statefulSetAnnotations() = %v, want none without sync readiness", got)
+	}
+	if got := args.podManagementPolicy(); got != nil {
+		t.Errorf("podManagementPolicy() = %v, want the default without sync readiness", got)
+	}
+
+	args.SyncReadiness = &SyncReadinessArgs{MaxHeadLag: 2}
+	annotations := args.statefulSetAnnotations().(pulumi.StringMap)
+	if got := annotations[utils.SkipAwaitAnnotation]; got != pulumi.String("true") {
+		t.Errorf("skipAwait annotation = %v, want true", got)
+	}
+	if got := args.podManagementPolicy(); !reflect.DeepEqual(got, pulumi.StringPtr(utils.PodManagementPolicyParallel)) {
+		t.Errorf("podManagementPolicy() = %v, want Parallel", got)
+	}
+}
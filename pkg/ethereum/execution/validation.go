@@ -2,6 +2,9 @@ package execution
 
 import (
 	"fmt"
+
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/network"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 )
 
 // Validate validates the execution client arguments
@@ -16,7 +19,7 @@ func (args *ExecutionClientArgs) Validate() error {
 	if args.StorageSize == "" {
 		return fmt.Errorf("storageSize is required")
 	}
-	if args.StorageClass == "" {
+	if args.StorageClass == nil {
 		return fmt.Errorf("storageClass is required")
 	}
 	if args.Image == "" {
@@ -25,8 +28,16 @@ func (args *ExecutionClientArgs) Validate() error {
 	if args.ImagePullPolicy == "" {
 		return fmt.Errorf("imagePullPolicy is required")
 	}
-	if args.JWTSecret == "" {
-		return fmt.Errorf("jwtSecret is required")
+	if args.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative")
+	}
+	if args.JWTSecret == "" && args.JWTSecretName == "" {
+		return fmt.Errorf("jwtSecret or jwtSecretName is required")
+	}
+	if args.JWTSecret != "" {
+		if err := utils.ValidateJWTSecret(args.JWTSecret); err != nil {
+			return fmt.Errorf("invalid jwtSecret: %w", err)
+		}
 	}
 	if args.P2PPort == 0 {
 		return fmt.Errorf("p2pPort is required")
@@ -65,5 +76,107 @@ func (args *ExecutionClientArgs) Validate() error {
 	if args.DiscoveryPort <= 0 {
 		return fmt.Errorf("discoveryPort must be greater than zero")
 	}
+	if args.TerminationGracePeriodSeconds < 0 {
+		return fmt.Errorf("terminationGracePeriodSeconds must not be negative")
+	}
+	if args.SyncReadiness != nil {
+		if err := args.SyncReadiness.Validate(); err != nil {
+			return fmt.Errorf("invalid syncReadiness: %w", err)
+		}
+	}
+	if args.Network != "" {
+		if _, err := network.Lookup(args.Network); err != nil {
+			return err
+		}
+	}
+	if args.ExternalSecretRef != nil {
+		if err := args.ExternalSecretRef.Validate(); err != nil {
+			return fmt.Errorf("invalid externalSecretRef: %w", err)
+		}
+	}
+	if args.DataSnapshot != nil {
+		if err := args.DataSnapshot.ValidateSize(args.StorageSize); err != nil {
+			return fmt.Errorf("invalid dataSnapshot: %w", err)
+		}
+	}
+	if args.DataArchive != nil {
+		if err := args.DataArchive.Validate(); err != nil {
+			return fmt.Errorf("invalid dataArchive: %w", err)
+		}
+		if args.ServiceAccountName == "" {
+			return fmt.Errorf("dataArchive requires serviceAccountName")
+		}
+	}
+	if args.Snapshots != nil {
+		if err := args.Snapshots.Validate(); err != nil {
+			return fmt.Errorf("invalid snapshots: %w", err)
+		}
+	}
+	volumeNames := map[string]bool{DataVolumeName: true, JWTVolumeName: true}
+	for _, volume := range args.AdditionalVolumes {
+		if err := volume.Validate(); err != nil {
+			return fmt.Errorf("invalid additionalVolume %s: %w", volume.Name, err)
+		}
+		if volumeNames[volume.Name] {
+			return fmt.Errorf("duplicate volume name %s", volume.Name)
+		}
+		volumeNames[volume.Name] = true
+	}
+	portNames := map[string]bool{"p2p": true, "discovery": true, "rpc": true, "ws": true, "metrics": true, "auth-rpc": true}
+	for _, port := range args.AdditionalPorts {
+		if err := port.Validate(); err != nil {
+			return fmt.Errorf("invalid additionalPort %s: %w", port.Name, err)
+		}
+		if portNames[port.Name] {
+			return fmt.Errorf("duplicate port name %s", port.Name)
+		}
+		portNames[port.Name] = true
+	}
+	return nil
+}
+
+// Validate validates the DataVolume struct
+func (volume DataVolume) Validate() error {
+	if volume.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if volume.StorageSize == "" {
+		return fmt.Errorf("storageSize is required")
+	}
+	if volume.MountPath == "" {
+		return fmt.Errorf("mountPath is required")
+	}
+	if volume.Snapshot != nil {
+		if err := volume.Snapshot.ValidateSize(volume.StorageSize); err != nil {
+			return fmt.Errorf("invalid snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the ServicePort struct
+func (port ServicePort) Validate() error {
+	if port.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if port.Port <= 0 || port.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	switch port.protocol() {
+	case "TCP", "UDP", "SCTP":
+	default:
+		return fmt.Errorf("protocol must be TCP, UDP or SCTP")
+	}
+	return nil
+}
+
+// Validate validates the SyncReadinessArgs struct
+func (readiness *SyncReadinessArgs) Validate() error {
+	if readiness.MaxHeadLag < 0 {
+		return fmt.Errorf("maxHeadLag must not be negative")
+	}
+	if readiness.MinPeers < 0 {
+		return fmt.Errorf("minPeers must not be negative")
+	}
 	return nil
 }
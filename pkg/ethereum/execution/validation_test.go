@@ -2,8 +2,13 @@ package execution
 
 import (
 	"testing"
+
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 )
 
+// testJWTSecret is a well-formed Engine API JWT secret
+const testJWTSecret = "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718"
+
 func TestExecutionClientArgs_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -15,10 +20,10 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 			args: ExecutionClientArgs{
 				Namespace:       "default",
 				StorageSize:     "100Gi",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
 				Image:           "test-image",
 				ImagePullPolicy: "Always",
-				JWTSecret:       "test-secret",
+				JWTSecret:       testJWTSecret,
 				P2PPort:         30303,
 				RPCPort:         8545,
 				WSPort:          8546,
@@ -33,10 +38,10 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 			args: ExecutionClientArgs{
 				Name:            "test",
 				StorageSize:     "100Gi",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
 				Image:           "test-image",
 				ImagePullPolicy: "Always",
-				JWTSecret:       "test-secret",
+				JWTSecret:       testJWTSecret,
 				P2PPort:         30303,
 				RPCPort:         8545,
 				WSPort:          8546,
@@ -51,10 +56,10 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 			args: ExecutionClientArgs{
 				Name:            "test",
 				Namespace:       "default",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
 				Image:           "test-image",
 				ImagePullPolicy: "Always",
-				JWTSecret:       "test-secret",
+				JWTSecret:       testJWTSecret,
 				P2PPort:         30303,
 				RPCPort:         8545,
 				WSPort:          8546,
@@ -72,7 +77,7 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 				StorageSize:     "100Gi",
 				Image:           "test-image",
 				ImagePullPolicy: "Always",
-				JWTSecret:       "test-secret",
+				JWTSecret:       testJWTSecret,
 				P2PPort:         30303,
 				RPCPort:         8545,
 				WSPort:          8546,
@@ -88,9 +93,9 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 				Name:            "test",
 				Namespace:       "default",
 				StorageSize:     "100Gi",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
 				ImagePullPolicy: "Always",
-				JWTSecret:       "test-secret",
+				JWTSecret:       testJWTSecret,
 				P2PPort:         30303,
 				RPCPort:         8545,
 				WSPort:          8546,
@@ -106,9 +111,9 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 				Name:          "test",
 				Namespace:     "default",
 				StorageSize:   "100Gi",
-				StorageClass:  "standard",
+				StorageClass:  &storage.StorageClassComponent{},
 				Image:         "test-image",
-				JWTSecret:     "test-secret",
+				JWTSecret:     testJWTSecret,
 				P2PPort:       30303,
 				RPCPort:       8545,
 				WSPort:        8546,
@@ -124,7 +129,7 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 				Name:            "test",
 				Namespace:       "default",
 				StorageSize:     "100Gi",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
 				Image:           "test-image",
 				ImagePullPolicy: "Always",
 				P2PPort:         30303,
@@ -142,10 +147,10 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 				Name:            "test",
 				Namespace:       "default",
 				StorageSize:     "100Gi",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
 				Image:           "test-image",
 				ImagePullPolicy: "Always",
-				JWTSecret:       "test-secret",
+				JWTSecret:       testJWTSecret,
 				P2PPort:         0,
 				RPCPort:         8545,
 				WSPort:          8546,
@@ -161,16 +166,461 @@ func TestExecutionClientArgs_Validate(t *testing.T) {
 				Name:            "test",
 				Namespace:       "default",
 				StorageSize:     "100Gi",
-				StorageClass:    "standard",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+			},
+			wantErr: false,
+		},
+		{
+			name: "snapshots without snapshot class",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				Snapshots:       &storage.SnapshotArgs{Retention: 7},
+			},
+			wantErr: true,
+		},
+		{
+			name: "data snapshot larger than storage size",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				DataSnapshot:    &storage.VolumeSnapshotSource{Name: "test-data-snap", RestoreSize: "120Gi"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid args with data snapshot",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				DataSnapshot:    &storage.VolumeSnapshotSource{Name: "test-data-snap", RestoreSize: "80Gi"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "data archive without service account",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				DataArchive:     &storage.ArchiveSource{Bucket: "archives", Key: "data.tar.zst", Region: "us-east-1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid data archive",
+			args: ExecutionClientArgs{
+				Name:               "test",
+				Namespace:          "default",
+				StorageSize:        "100Gi",
+				StorageClass:       &storage.StorageClassComponent{},
+				Image:              "test-image",
+				ImagePullPolicy:    "Always",
+				JWTSecret:          testJWTSecret,
+				P2PPort:            30303,
+				RPCPort:            8545,
+				WSPort:             8546,
+				MetricsPort:        9090,
+				AuthRPCPort:        8551,
+				DiscoveryPort:      30303,
+				ServiceAccountName: "test-sa",
+				DataArchive:        &storage.ArchiveSource{Bucket: "archives", Key: "data.zip", Region: "us-east-1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid args with data archive",
+			args: ExecutionClientArgs{
+				Name:               "test",
+				Namespace:          "default",
+				StorageSize:        "100Gi",
+				StorageClass:       &storage.StorageClassComponent{},
+				Image:              "test-image",
+				ImagePullPolicy:    "Always",
+				JWTSecret:          testJWTSecret,
+				P2PPort:            30303,
+				RPCPort:            8545,
+				WSPort:             8546,
+				MetricsPort:        9090,
+				AuthRPCPort:        8551,
+				DiscoveryPort:      30303,
+				ServiceAccountName: "test-sa",
+				DataArchive:        &storage.ArchiveSource{Bucket: "archives", Key: "data.tar.zst", Region: "us-east-1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid args with replicas",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				Replicas:        3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative replicas",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				Replicas:        -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid args with additional volumes and ports",
+			args: ExecutionClientArgs{
+				Name:              "test",
+				Namespace:         "default",
+				StorageSize:       "100Gi",
+				StorageClass:      &storage.StorageClassComponent{},
+				Image:             "test-image",
+				ImagePullPolicy:   "Always",
+				JWTSecret:         testJWTSecret,
+				P2PPort:           30303,
+				RPCPort:           8545,
+				WSPort:            8546,
+				MetricsPort:       9090,
+				AuthRPCPort:       8551,
+				DiscoveryPort:     30303,
+				AdditionalVolumes: []DataVolume{{Name: "rollup-data", StorageSize: "50Gi", MountPath: "/rollup"}},
+				AdditionalPorts:   []ServicePort{{Name: "rollup-http", Port: 8645}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "additional volume without mount path",
+			args: ExecutionClientArgs{
+				Name:              "test",
+				Namespace:         "default",
+				StorageSize:       "100Gi",
+				StorageClass:      &storage.StorageClassComponent{},
+				Image:             "test-image",
+				ImagePullPolicy:   "Always",
+				JWTSecret:         testJWTSecret,
+				P2PPort:           30303,
+				RPCPort:           8545,
+				WSPort:            8546,
+				MetricsPort:       9090,
+				AuthRPCPort:       8551,
+				DiscoveryPort:     30303,
+				AdditionalVolumes: []DataVolume{{Name: "rollup-data", StorageSize: "50Gi"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional volume named like the data volume",
+			args: ExecutionClientArgs{
+				Name:              "test",
+				Namespace:         "default",
+				StorageSize:       "100Gi",
+				StorageClass:      &storage.StorageClassComponent{},
+				Image:             "test-image",
+				ImagePullPolicy:   "Always",
+				JWTSecret:         testJWTSecret,
+				P2PPort:           30303,
+				RPCPort:           8545,
+				WSPort:            8546,
+				MetricsPort:       9090,
+				AuthRPCPort:       8551,
+				DiscoveryPort:     30303,
+				AdditionalVolumes: []DataVolume{{Name: DataVolumeName, StorageSize: "50Gi", MountPath: "/rollup"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional volume snapshot larger than its size",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				AdditionalVolumes: []DataVolume{{
+					Name:        "rollup-data",
+					StorageSize: "50Gi",
+					MountPath:   "/rollup",
+					Snapshot:    &storage.VolumeSnapshotSource{Name: "rollup-data-snap", RestoreSize: "60Gi"},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional port with a duplicate name",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				AdditionalPorts: []ServicePort{{Name: "rpc", Port: 8645}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional port with an unknown protocol",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				AdditionalPorts: []ServicePort{{Name: "rollup-http", Port: 8645, Protocol: "HTTP"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "network preset",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
 				Image:           "test-image",
 				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				Network:         "holesky",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown network",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				Network:         "goerli",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative termination grace period",
+			args: ExecutionClientArgs{
+				Name:                          "test",
+				Namespace:                     "default",
+				StorageSize:                   "100Gi",
+				StorageClass:                  &storage.StorageClassComponent{},
+				Image:                         "test-image",
+				ImagePullPolicy:               "Always",
+				JWTSecret:                     testJWTSecret,
+				P2PPort:                       30303,
+				RPCPort:                       8545,
+				WSPort:                        8546,
+				MetricsPort:                   9090,
+				AuthRPCPort:                   8551,
+				DiscoveryPort:                 30303,
+				TerminationGracePeriodSeconds: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync readiness",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				SyncReadiness:   &SyncReadinessArgs{MaxHeadLag: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative sync readiness head lag",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				JWTSecret:       testJWTSecret,
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				SyncReadiness:   &SyncReadinessArgs{MaxHeadLag: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "existing jwt secret",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
+				JWTSecretName:   "node-jwt",
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed jwt secret",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
+				P2PPort:         30303,
+				RPCPort:         8545,
+				WSPort:          8546,
+				MetricsPort:     9090,
+				AuthRPCPort:     8551,
+				DiscoveryPort:   30303,
 				JWTSecret:       "test-secret",
+			},
+			wantErr: true,
+		},
+		{
+			name: "jwt secret with a 0x prefix",
+			args: ExecutionClientArgs{
+				Name:            "test",
+				Namespace:       "default",
+				StorageSize:     "100Gi",
+				StorageClass:    &storage.StorageClassComponent{},
+				Image:           "test-image",
+				ImagePullPolicy: "Always",
 				P2PPort:         30303,
 				RPCPort:         8545,
 				WSPort:          8546,
 				MetricsPort:     9090,
 				AuthRPCPort:     8551,
 				DiscoveryPort:   30303,
+				JWTSecret:       "0x" + testJWTSecret,
 			},
 			wantErr: false,
 		},
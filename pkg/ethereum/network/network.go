@@ -0,0 +1,77 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Network names
+const (
+	Mainnet = "mainnet"
+	Sepolia = "sepolia"
+	Holesky = "holesky"
+	Hoodi   = "hoodi"
+)
+
+// Preset describes a public Ethereum network the execution and consensus clients can join.
+// Presets carry no bootnodes on purpose: every client ships the published list for each of
+// these networks and keeps it current with its releases, so clients use their built-in list
+// unless bootnodes are given explicitly.
+type Preset struct {
+	// Name is the network name the clients take in their network flag
+	Name string
+	// ChainID is the execution layer chain id
+	ChainID int64
+	// CheckpointSyncURL is the beacon API consensus clients checkpoint sync from
+	CheckpointSyncURL string
+	// GenesisTime is the unix timestamp of the beacon chain genesis
+	GenesisTime int64
+}
+
+// presets is the registry of known networks
+var presets = map[string]Preset{
+	Mainnet: {
+		Name:              Mainnet,
+		ChainID:           1,
+		CheckpointSyncURL: "https://mainnet.checkpoint.sigp.io",
+		GenesisTime:       1606824023,
+	},
+	Sepolia: {
+		Name:              Sepolia,
+		ChainID:           11155111,
+		CheckpointSyncURL: "https://sepolia.checkpoint.sigp.io",
+		GenesisTime:       1655733600,
+	},
+	Holesky: {
+		Name:              Holesky,
+		ChainID:           17000,
+		CheckpointSyncURL: "https://holesky.checkpoint.sigp.io",
+		GenesisTime:       1695902400,
+	},
+	Hoodi: {
+		Name:              Hoodi,
+		ChainID:           560048,
+		CheckpointSyncURL: "https://hoodi.checkpoint.sigp.io",
+		GenesisTime:       1742213400,
+	},
+}
+
+// Lookup returns the preset of the named network
+func Lookup(name string) (Preset, error) {
+	preset, ok := presets[name]
+	if !ok {
+		return Preset{}, fmt.Errorf("unknown network %q, must be one of %s", name, strings.Join(Names(), ", "))
+	}
+	return preset, nil
+}
+
+// Names returns the names of the known networks in sorted order
+func Names() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
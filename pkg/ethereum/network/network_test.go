@@ -0,0 +1,34 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		chainID int64
+	}{
+		{name: Mainnet, chainID: 1},
+		{name: Sepolia, chainID: 11155111},
+		{name: Holesky, chainID: 17000},
+		{name: Hoodi, chainID: 560048},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset, err := Lookup(tt.name)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.name, preset.Name)
+			assert.Equal(t, tt.chainID, preset.ChainID)
+			assert.Contains(t, preset.CheckpointSyncURL, tt.name)
+			assert.NotZero(t, preset.GenesisTime)
+		})
+	}
+
+	_, err := Lookup("goerli")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be one of holesky, hoodi, mainnet, sepolia")
+}
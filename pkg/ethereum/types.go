@@ -1,8 +1,12 @@
 package ethereum
 
 import (
+	"fmt"
+
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/consensus"
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
@@ -14,6 +18,12 @@ type EthereumNodeArgs struct {
 	Name string
 	// Namespace is the Kubernetes namespace to deploy resources in
 	Namespace string
+	// Network is the name of the network preset both clients join, see the network package.
+	// Empty leaves the network of each client to its own args.
+	Network string
+	// JWTSecret is the hex encoded 32 byte Engine API JWT secret both clients share. When neither
+	// it nor the clients' JWTSecret is set a random one is generated.
+	JWTSecret string
 	// ExecutionClient contains the configuration for the execution client
 	ExecutionClient *execution.ExecutionClientArgs
 	// ConsensusClient contains the configuration for the consensus client
@@ -45,6 +55,33 @@ func (args EthereumNodeArgs) toInternal() ethereumNodeArgsInternal {
 	}
 }
 
+// executionClientEndpoint returns the Engine API endpoint each consensus replica connects to: the
+// execution replica of the same ordinal, addressed through the execution client's headless service
+func (args EthereumNodeArgs) executionClientEndpoint() string {
+	name := args.ExecutionClient.Name
+	return fmt.Sprintf("http://%s-$(%s).%s%s:%d",
+		name, utils.PodIndexEnvName, name, utils.HeadlessServiceSuffix, args.ExecutionClient.AuthRPCPort)
+}
+
+// jwtSecret returns the JWT secret given for the node or either client, empty when none is
+func (args EthereumNodeArgs) jwtSecret() string {
+	if args.JWTSecret != "" {
+		return args.JWTSecret
+	}
+	if args.ExecutionClient != nil && args.ExecutionClient.JWTSecret != "" {
+		return args.ExecutionClient.JWTSecret
+	}
+	if args.ConsensusClient != nil {
+		return args.ConsensusClient.JWTSecret
+	}
+	return ""
+}
+
+// jwtSecretName returns the name of the Secret holding the shared JWT
+func (args EthereumNodeArgs) jwtSecretName() string {
+	return fmt.Sprintf("%s%s", args.Name, JWTSecretSuffix)
+}
+
 // EthereumNodeComponent represents a complete Ethereum node deployment
 type EthereumNodeComponent struct {
 	pulumi.ResourceState
@@ -53,6 +90,8 @@ type EthereumNodeComponent struct {
 	Name string
 	// Namespace is the Kubernetes namespace
 	Namespace string
+	// JWTSecret holds the Engine API JWT both clients mount
+	JWTSecret *corev1.Secret
 	// ExecutionClient is the execution client component
 	ExecutionClient *execution.ExecutionClientComponent
 	// ConsensusClient is the consensus client component
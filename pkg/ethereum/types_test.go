@@ -0,0 +1,22 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumNodeArgsExecutionClientEndpoint(t *testing.T) {
+	args := EthereumNodeArgs{
+		Name: "test-node",
+		ExecutionClient: &execution.ExecutionClientArgs{
+			Name:        "test-execution",
+			RPCPort:     8545,
+			AuthRPCPort: 8551,
+		},
+	}
+
+	// Each consensus replica reaches the Engine API of its own execution replica
+	assert.Equal(t, "http://test-execution-$(POD_INDEX).test-execution-headless:8551", args.executionClientEndpoint())
+}
@@ -2,6 +2,11 @@ package ethereum
 
 import (
 	"fmt"
+
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/consensus"
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/network"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 )
 
 // Validate validates the EthereumNodeArgs struct
@@ -22,13 +27,53 @@ func (args *EthereumNodeArgs) Validate() error {
 		return fmt.Errorf("consensusClient is required")
 	}
 
-	// Validate execution client
-	if err := args.ExecutionClient.Validate(); err != nil {
+	if args.Network != "" {
+		if _, err := network.Lookup(args.Network); err != nil {
+			return err
+		}
+		if args.ExecutionClient.Network != "" && args.ExecutionClient.Network != args.Network {
+			return fmt.Errorf("execution client network %s does not match network %s", args.ExecutionClient.Network, args.Network)
+		}
+		if args.ConsensusClient.Network != "" && args.ConsensusClient.Network != args.Network {
+			return fmt.Errorf("consensus client network %s does not match network %s", args.ConsensusClient.Network, args.Network)
+		}
+	}
+
+	// Each consensus replica is paired with the execution replica of the same ordinal
+	executionReplicas, consensusReplicas := args.ExecutionClient.Replicas, args.ConsensusClient.Replicas
+	if executionReplicas == 0 {
+		executionReplicas = execution.DefaultReplicas
+	}
+	if consensusReplicas == 0 {
+		consensusReplicas = consensus.DefaultReplicas
+	}
+	if executionReplicas != consensusReplicas {
+		return fmt.Errorf("execution client replicas %d and consensus client replicas %d must match", executionReplicas, consensusReplicas)
+	}
+
+	// Both clients share one JWT
+	jwtSecret := args.jwtSecret()
+	if jwtSecret != "" {
+		if err := utils.ValidateJWTSecret(jwtSecret); err != nil {
+			return fmt.Errorf("invalid jwtSecret: %w", err)
+		}
+	}
+	for _, clientSecret := range []string{args.ExecutionClient.JWTSecret, args.ConsensusClient.JWTSecret} {
+		if clientSecret != "" && clientSecret != jwtSecret {
+			return fmt.Errorf("execution and consensus clients must share the jwt secret")
+		}
+	}
+
+	// Validate the clients as they are created, mounting the shared JWT secret
+	executionClient := *args.ExecutionClient
+	executionClient.JWTSecretName = args.jwtSecretName()
+	if err := executionClient.Validate(); err != nil {
 		return fmt.Errorf("execution client validation failed: %w", err)
 	}
 
-	// Validate consensus client
-	if err := args.ConsensusClient.Validate(); err != nil {
+	consensusClient := *args.ConsensusClient
+	consensusClient.JWTSecretName = args.jwtSecretName()
+	if err := consensusClient.Validate(); err != nil {
 		return fmt.Errorf("consensus client validation failed: %w", err)
 	}
 
@@ -1,13 +1,18 @@
 package ethereum
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/consensus"
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 	"github.com/stretchr/testify/assert"
 )
 
+// testJWTSecret is a well-formed Engine API JWT secret
+const testJWTSecret = "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718"
+
 func TestEthereumNodeArgsValidate(t *testing.T) {
 	// Test with valid args
 	validArgs := EthereumNodeArgs{
@@ -17,10 +22,10 @@ func TestEthereumNodeArgsValidate(t *testing.T) {
 			Name:            "test-execution",
 			Namespace:       "default",
 			StorageSize:     "100Gi",
-			StorageClass:    "standard",
+			StorageClass:    &storage.StorageClassComponent{},
 			Image:           "test-execution-image",
 			ImagePullPolicy: "Always",
-			JWTSecret:       "test-jwt-secret",
+			JWTSecret:       testJWTSecret,
 			P2PPort:         30303,
 			RPCPort:         8545,
 			WSPort:          8546,
@@ -32,10 +37,10 @@ func TestEthereumNodeArgsValidate(t *testing.T) {
 			Name:                    "test-consensus",
 			Namespace:               "default",
 			StorageSize:             "100Gi",
-			StorageClass:            "standard",
+			StorageClass:            &storage.StorageClassComponent{},
 			Image:                   "test-consensus-image",
 			ImagePullPolicy:         "Always",
-			JWTSecret:               "test-jwt-secret",
+			JWTSecret:               testJWTSecret,
 			P2PPort:                 30303,
 			BeaconAPIPort:           5052,
 			MetricsPort:             9090,
@@ -119,4 +124,64 @@ func TestEthereumNodeArgsValidate(t *testing.T) {
 	err = invalidArgs6.Validate()
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "consensus client validation failed")
+
+	// Test with a network preset
+	networkArgs := validArgs
+	networkArgs.Network = "sepolia"
+	assert.NoError(t, networkArgs.Validate())
+
+	// Test with an unknown network
+	networkArgs.Network = "goerli"
+	err = networkArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown network")
+
+	// Test with a client on another network
+	consensusClient := *validArgs.ConsensusClient
+	consensusClient.Network = "holesky"
+	networkArgs.Network = "sepolia"
+	networkArgs.ConsensusClient = &consensusClient
+	err = networkArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "consensus client network holesky does not match network sepolia")
+
+	// Test generating the jwt secret when none is given
+	generatedArgs := validArgs
+	executionClient := *validArgs.ExecutionClient
+	executionClient.JWTSecret = ""
+	consensusClient = *validArgs.ConsensusClient
+	consensusClient.JWTSecret = ""
+	generatedArgs.ExecutionClient = &executionClient
+	generatedArgs.ConsensusClient = &consensusClient
+	assert.NoError(t, generatedArgs.Validate())
+
+	// Test with a malformed jwt secret
+	generatedArgs.JWTSecret = "test-jwt-secret"
+	err = generatedArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid jwtSecret: jwt secret must be 64 hex characters")
+
+	// Test with clients holding different jwt secrets
+	mismatchedArgs := validArgs
+	mismatchedArgs.JWTSecret = strings.Repeat("ab", 32)
+	err = mismatchedArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "execution and consensus clients must share the jwt secret")
+
+	// Test with differing replica counts, treating zero as the default of one
+	replicaArgs := validArgs
+	executionClient = *validArgs.ExecutionClient
+	executionClient.Replicas = 1
+	replicaArgs.ExecutionClient = &executionClient
+	assert.NoError(t, replicaArgs.Validate())
+
+	consensusClient = *validArgs.ConsensusClient
+	consensusClient.Replicas = 2
+	replicaArgs.ConsensusClient = &consensusClient
+	err = replicaArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "execution client replicas 1 and consensus client replicas 2 must match")
+
+	executionClient.Replicas = 2
+	assert.NoError(t, replicaArgs.Validate())
 }
@@ -4,7 +4,6 @@ package pylon
 const (
 	ExecutionClientStorageSize = "150Gi"
 	ConsensusClientStorageSize = "100Gi"
-	StorageClassAWSGP3         = "aws-gp3"
 )
 
 // Port constants
@@ -18,6 +17,26 @@ const (
 	ConsensusMetricsPort   = 5054
 )
 
+// Resource name suffixes
+const (
+	ServiceAccountSuffix = "-sa"
+	BlobBucketSuffix     = "-blob-bucket"
+)
+
+// Env names of the sensitive PylonEnv fields, which an ExternalSecretRef has to sync
+const (
+	AwsSecretAccessKeyEnvName = "AWS_SECRET_ACCESS_KEY"
+	PylonDbUrlEnvName         = "PYLON_DB_URL"
+)
+
+// S3 constants
+const (
+	// LegacyBlobBucketResourceName is the unparented name the blob bucket was created under
+	LegacyBlobBucketResourceName = "pylon-blob-bucket"
+	// S3EndpointFormat formats the regional S3 endpoint for a region
+	S3EndpointFormat = "https://s3.%s.amazonaws.com"
+)
+
 // Image constants
 const (
 	ConsensusClientImage  = "sigp/lighthouse:latest"
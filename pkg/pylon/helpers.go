@@ -1 +1,21 @@
 package pylon
+
+import (
+	"fmt"
+
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+)
+
+// blobBucketArgs returns the S3 bucket args for the pylon blob bucket. Versioning is
+// always enabled so overwritten or deleted blobs can be recovered.
+func (args PylonComponentArgs) blobBucketArgs() *aws.S3BucketArgs {
+	return &aws.S3BucketArgs{
+		Name:               fmt.Sprintf("%s%s", args.Name, BlobBucketSuffix),
+		BucketName:         args.PylonBlobBucketName,
+		KmsKeyArn:          args.BlobBucketKmsKeyArn,
+		Versioning:         true,
+		Lifecycle:          args.BlobBucketLifecycle,
+		Replication:        args.BlobBucketReplication,
+		LegacyResourceName: LegacyBlobBucketResourceName,
+	}
+}
@@ -3,10 +3,12 @@ package pylon
 import (
 	"fmt"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/ethereum"
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/consensus"
 	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
-	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/s3"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
@@ -25,16 +27,92 @@ func NewPylonComponent(ctx *pulumi.Context, args *PylonComponentArgs, opts ...pu
 	// Convert public args to internal args
 	internalArgs := args.toInternal()
 
-	// Create the S3 bucket for blob storage (unused for now but needed for future implementation)
-	_, err = s3.NewBucketV2(ctx, "pylon-blob-bucket", &s3.BucketV2Args{
-		Bucket: internalArgs.PylonBlobBucketName,
-	})
+	// Create the S3 bucket for blob storage, adopting the bucket created before it
+	// was managed by the component
+	blobBucket, err := aws.NewS3BucketComponent(ctx, args.blobBucketArgs(), pulumi.Parent(component))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create blob bucket: %w", err)
+	}
+	component.BlobBucket = blobBucket
+
+	// Create the pylon database when requested
+	if args.PostgresDbArgs != nil {
+		database, err := aws.NewPostgresDbComponent(ctx, args.PostgresDbArgs, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pylon database: %w", err)
+		}
+		component.Database = database
+	}
+
+	// Run the execution client under a service account bound to an IAM role when using EKS Pod Identity
+	var serviceAccountName string
+	if internalArgs.PodIdentity != nil {
+		serviceAccountName = fmt.Sprintf("%s%s", args.Name, ServiceAccountSuffix)
+		serviceAccount, err := corev1.NewServiceAccount(ctx, serviceAccountName, &corev1.ServiceAccountArgs{
+			Metadata: &metav1.ObjectMetaArgs{
+				Name:      pulumi.String(serviceAccountName),
+				Namespace: internalArgs.Namespace,
+			},
+		}, pulumi.Parent(component))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service account: %w", err)
+		}
+		component.ServiceAccount = serviceAccount
+
+		podIdentity, err := aws.CreatePodIdentityResources(
+			ctx,
+			args.Name,
+			args.Name,
+			*internalArgs.PodIdentity,
+			internalArgs.Namespace,
+			serviceAccount.Metadata.Name().Elem(),
+			nil,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pod identity resources: %w", err)
+		}
+		component.PodIdentity = podIdentity
+
+		// Allow pylon to read and write blobs, and nothing else in S3
+		blobBucketAccess, err := blobBucket.GrantAccess(ctx, args.Name, podIdentity.Role, component)
+		if err != nil {
+			return nil, fmt.Errorf("failed to grant blob bucket access: %w", err)
+		}
+		component.BlobBucketAccess = blobBucketAccess
+
+		// Allow the execution client to read its bootstrap archive
+		if args.ExecutionDataArchive != nil {
+			archiveAccess, err := aws.GrantS3ObjectRead(
+				ctx,
+				fmt.Sprintf("%s-archive", args.Name),
+				podIdentity.Role,
+				args.ExecutionDataArchive.Bucket,
+				args.ExecutionDataArchive.Key,
+				component,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to grant archive access: %w", err)
+			}
+			component.ArchiveAccess = archiveAccess
+		}
 	}
 
-	// Convert environment to internal type for use with ethereum components
-	internalEnv := args.Env.toInternal()
+	// Use the converted environment for the ethereum components, filling in
+	// settings derived from the bucket and database
+	internalEnv := internalArgs.Env
+	if args.Env.PylonS3BucketName == "" {
+		internalEnv.PylonS3BucketName = blobBucket.BucketName
+	}
+	if args.Env.PylonS3Region == "" {
+		internalEnv.PylonS3Region = blobBucket.Region
+	}
+	if args.Env.PylonS3Url == "" {
+		internalEnv.PylonS3Url = pulumi.Sprintf(S3EndpointFormat, blobBucket.Region)
+	}
+	if component.Database != nil {
+		internalEnv.PylonDbUrl = component.Database.ConnectionUrl
+	}
 	elName := fmt.Sprintf("%s-execution-client", args.Name)
 	clName := fmt.Sprintf("%s-consensus-client", args.Name)
 	// Create Ethereum node component
@@ -45,7 +123,7 @@ func NewPylonComponent(ctx *pulumi.Context, args *PylonComponentArgs, opts ...pu
 			Name:               elName,
 			Namespace:          args.Namespace,
 			StorageSize:        ExecutionClientStorageSize,
-			StorageClass:       StorageClassAWSGP3,
+			StorageClass:       args.StorageClass,
 			Image:              args.PylonImage,
 			ImagePullPolicy:    ImagePullPolicyAlways,
 			JWTSecret:          args.ExecutionJwt,
@@ -56,19 +134,23 @@ func NewPylonComponent(ctx *pulumi.Context, args *PylonComponentArgs, opts ...pu
 			AuthRPCPort:        ExecutionAuthRPCPort,
 			DiscoveryPort:      ExecutionP2PPort,
 			ExecutionClientEnv: internalEnv,
+			ExternalSecretRef:  args.ExternalSecretRef,
+			ServiceAccountName: serviceAccountName,
+			DataSnapshot:       args.ExecutionDataSnapshot,
+			DataArchive:        args.ExecutionDataArchive,
 		},
 		ConsensusClient: &consensus.ConsensusClientArgs{
-			Name:                    clName,
-			Namespace:               args.Namespace,
-			StorageSize:             ConsensusClientStorageSize,
-			StorageClass:            StorageClassAWSGP3,
-			JWTSecret:               args.ExecutionJwt,
-			P2PPort:                 ExecutionP2PPort,
-			Image:                   ConsensusClientImage,
-			ImagePullPolicy:         ImagePullPolicyAlways,
-			BeaconAPIPort:           ConsensusBeaconAPIPort,
-			MetricsPort:             ConsensusMetricsPort,
-			ExecutionClientEndpoint: fmt.Sprintf("http://%s-service.%s.svc.cluster.local:%d", args.Name, args.Namespace, ExecutionRPCPort),
+			Name:            clName,
+			Namespace:       args.Namespace,
+			StorageSize:     ConsensusClientStorageSize,
+			StorageClass:    args.StorageClass,
+			JWTSecret:       args.ExecutionJwt,
+			P2PPort:         ExecutionP2PPort,
+			Image:           ConsensusClientImage,
+			ImagePullPolicy: ImagePullPolicyAlways,
+			BeaconAPIPort:   ConsensusBeaconAPIPort,
+			MetricsPort:     ConsensusMetricsPort,
+			DataSnapshot:    args.ConsensusDataSnapshot,
 		},
 	}
 
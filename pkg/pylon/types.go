@@ -3,6 +3,7 @@ package pylon
 import (
 	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/ethereum"
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
@@ -10,13 +11,22 @@ import (
 
 // Public-facing structs with base Go types
 type PylonComponentArgs struct {
-	Namespace           string
-	Name                string
-	ExecutionJwt        string
-	PylonImage          string
-	PylonBlobBucketName string
-	Env                 PylonEnv
-	PostgresDbArgs      aws.PostgresDbArgs
+	Namespace             string
+	Name                  string
+	ExecutionJwt          string // Optional: hex encoded 32 byte Engine API JWT secret, generated when empty
+	PylonImage            string
+	PylonBlobBucketName   string
+	StorageClass          *storage.StorageClassComponent // Storage class for the execution and consensus client volumes
+	ExecutionDataSnapshot *storage.VolumeSnapshotSource  // Optional: restore the execution client volume from a snapshot
+	ConsensusDataSnapshot *storage.VolumeSnapshotSource  // Optional: restore the consensus client volume from a snapshot
+	ExecutionDataArchive  *storage.ArchiveSource         // Optional: bootstrap the execution client volume from an S3 archive, requires PodIdentity
+	Env                   PylonEnv
+	PostgresDbArgs        *aws.PostgresDbArgs      // Optional: create the pylon database and derive PylonDbUrl from it
+	BlobBucketKmsKeyArn   string                   // Optional: encrypt blobs with a customer managed KMS key
+	BlobBucketLifecycle   *aws.S3LifecycleArgs     // Optional: move aging blobs to cheaper storage classes
+	BlobBucketReplication *aws.S3ReplicationArgs   // Optional: replicate blobs to a bucket in another region
+	ExternalSecretRef     *utils.ExternalSecretRef // Optional: sync sensitive env from AWS Secrets Manager instead of Env
+	PodIdentity           *aws.PodIdentityConfig   // Optional: use EKS Pod Identity instead of static AWS access keys
 }
 
 // Internal structs with Pulumi types for use within the component
@@ -27,13 +37,14 @@ type pylonComponentArgsInternal struct {
 	PylonImage          pulumi.StringInput
 	PylonBlobBucketName pulumi.StringInput
 	Env                 pylonEnvInternal
+	PodIdentity         *aws.PodIdentityConfig
 }
 
 // Public-facing environment struct with base Go types
 type PylonEnv struct {
 	PylonStartBlock            string `pulumi:"pylonStartBlock" validate:"required"`
-	PylonS3Url                 string `pulumi:"pylonS3Url" validate:"required"`
-	PylonS3Region              string `pulumi:"pylonS3Region" validate:"required"`
+	PylonS3Url                 string `pulumi:"pylonS3Url"`    // Defaults to the regional endpoint of the blob bucket
+	PylonS3Region              string `pulumi:"pylonS3Region"` // Defaults to the region of the blob bucket
 	PylonSenders               string `pulumi:"pylonSenders" validate:"required"`
 	PylonNetworkSlotDuration   string `pulumi:"pylonNetworkSlotDuration" validate:"required"`
 	PylonNetworkSlotOffset     string `pulumi:"pylonNetworkSlotOffset" validate:"required"`
@@ -41,20 +52,20 @@ type PylonEnv struct {
 	PylonRustLog               string `pulumi:"pylonRustLog"`
 	PylonPort                  string `pulumi:"pylonPort" validate:"required"`
 	AwsAccessKeyId             string `pulumi:"awsAccessKeyId" validate:"required"`
-	AwsSecretAccessKey         string `pulumi:"awsSecretAccessKey" validate:"required"`
+	AwsSecretAccessKey         string `pulumi:"awsSecretAccessKey" validate:"required" secret:"true"`
 	AwsRegion                  string `pulumi:"awsRegion" validate:"required"`
-	PylonDbUrl                 string `pulumi:"pylonDbUrl" validate:"required"`
+	PylonDbUrl                 string `pulumi:"pylonDbUrl" validate:"required" secret:"true"`
 	PylonClUrl                 string `pulumi:"pylonClUrl" validate:"required"`
 	PylonBlobscanBaseUrl       string `pulumi:"pylonBlobscanBaseUrl" validate:"required"`
 	PylonNetworkStartTimestamp string `pulumi:"pylonNetworkStartTimestamp" validate:"required"`
-	PylonS3BucketName          string `pulumi:"pylonS3BucketName" validate:"required"`
+	PylonS3BucketName          string `pulumi:"pylonS3BucketName"` // Defaults to the blob bucket created by the component
 }
 
 // Internal environment struct with Pulumi types
 type pylonEnvInternal struct {
 	PylonStartBlock            pulumi.StringInput `pulumi:"pylonStartBlock" validate:"required"`
-	PylonS3Url                 pulumi.StringInput `pulumi:"pylonS3Url" validate:"required"`
-	PylonS3Region              pulumi.StringInput `pulumi:"pylonS3Region" validate:"required"`
+	PylonS3Url                 pulumi.StringInput `pulumi:"pylonS3Url"`
+	PylonS3Region              pulumi.StringInput `pulumi:"pylonS3Region"`
 	PylonSenders               pulumi.StringInput `pulumi:"pylonSenders" validate:"required"`
 	PylonNetworkSlotDuration   pulumi.StringInput `pulumi:"pylonNetworkSlotDuration" validate:"required"`
 	PylonNetworkSlotOffset     pulumi.StringInput `pulumi:"pylonNetworkSlotOffset" validate:"required"`
@@ -62,25 +73,31 @@ type pylonEnvInternal struct {
 	PylonRustLog               pulumi.StringInput `pulumi:"pylonRustLog"`
 	PylonPort                  pulumi.StringInput `pulumi:"pylonPort" validate:"required"`
 	AwsAccessKeyId             pulumi.StringInput `pulumi:"awsAccessKeyId" validate:"required"`
-	AwsSecretAccessKey         pulumi.StringInput `pulumi:"awsSecretAccessKey" validate:"required"`
+	AwsSecretAccessKey         pulumi.StringInput `pulumi:"awsSecretAccessKey" validate:"required" secret:"true"`
 	AwsRegion                  pulumi.StringInput `pulumi:"awsRegion" validate:"required"`
-	PylonDbUrl                 pulumi.StringInput `pulumi:"pylonDbUrl" validate:"required"`
+	PylonDbUrl                 pulumi.StringInput `pulumi:"pylonDbUrl" validate:"required" secret:"true"`
 	PylonClUrl                 pulumi.StringInput `pulumi:"pylonClUrl" validate:"required"`
 	PylonBlobscanBaseUrl       pulumi.StringInput `pulumi:"pylonBlobscanBaseUrl" validate:"required"`
 	PylonNetworkStartTimestamp pulumi.StringInput `pulumi:"pylonNetworkStartTimestamp" validate:"required"`
-	PylonS3BucketName          pulumi.StringInput `pulumi:"pylonS3BucketName" validate:"required"`
+	PylonS3BucketName          pulumi.StringInput `pulumi:"pylonS3BucketName"`
 }
 
 // Conversion function to convert public args to internal args
 func (args PylonComponentArgs) toInternal() pylonComponentArgsInternal {
-	return pylonComponentArgsInternal{
+	internal := pylonComponentArgsInternal{
 		Namespace:           pulumi.String(args.Namespace),
 		Name:                pulumi.String(args.Name),
-		ExecutionJwt:        pulumi.String(args.ExecutionJwt),
+		ExecutionJwt:        utils.SecretString(args.ExecutionJwt),
 		PylonImage:          pulumi.String(args.PylonImage),
 		PylonBlobBucketName: pulumi.String(args.PylonBlobBucketName),
 		Env:                 args.Env.toInternal(),
+		PodIdentity:         args.PodIdentity,
 	}
+	if args.PodIdentity != nil {
+		internal.Env.AwsAccessKeyId = nil
+		internal.Env.AwsSecretAccessKey = nil
+	}
+	return internal
 }
 
 // Conversion function to convert public env to internal env
@@ -96,9 +113,9 @@ func (e PylonEnv) toInternal() pylonEnvInternal {
 		PylonRustLog:               pulumi.String(e.PylonRustLog),
 		PylonPort:                  pulumi.String(e.PylonPort),
 		AwsAccessKeyId:             pulumi.String(e.AwsAccessKeyId),
-		AwsSecretAccessKey:         pulumi.String(e.AwsSecretAccessKey),
+		AwsSecretAccessKey:         utils.SecretString(e.AwsSecretAccessKey),
 		AwsRegion:                  pulumi.String(e.AwsRegion),
-		PylonDbUrl:                 pulumi.String(e.PylonDbUrl),
+		PylonDbUrl:                 utils.SecretString(e.PylonDbUrl),
 		PylonClUrl:                 pulumi.String(e.PylonClUrl),
 		PylonBlobscanBaseUrl:       pulumi.String(e.PylonBlobscanBaseUrl),
 		PylonNetworkStartTimestamp: pulumi.String(e.PylonNetworkStartTimestamp),
@@ -108,11 +125,24 @@ func (e PylonEnv) toInternal() pylonEnvInternal {
 
 // GetEnvMap implements the utils.EnvProvider interface for internal env
 func (e pylonEnvInternal) GetEnvMap() pulumi.StringMap {
-	return utils.CreateEnvMap(e)
+	envMap, _ := utils.CreateEnvMap(e)
+	return envMap
+}
+
+// GetSecretEnvMap implements the utils.SecretEnvProvider interface for internal env
+func (e pylonEnvInternal) GetSecretEnvMap() pulumi.StringMap {
+	_, secretMap := utils.CreateEnvMap(e)
+	return secretMap
 }
 
 type PylonComponent struct {
 	pulumi.ResourceState
 	EthereumNode      *ethereum.EthereumNodeComponent
 	PylonEnvConfigMap *corev1.ConfigMap
+	ServiceAccount    *corev1.ServiceAccount
+	PodIdentity       *aws.PodIdentityResources
+	BlobBucket        *aws.S3BucketComponent
+	BlobBucketAccess  *aws.IAMResources        // Set only when PodIdentity is given
+	ArchiveAccess     *aws.IAMResources        // Set only when ExecutionDataArchive is given
+	Database          *aws.PostgresDbComponent // Set only when PostgresDbArgs is given
 }
@@ -2,6 +2,8 @@ package pylon
 
 import (
 	"fmt"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 )
 
 // Validate validates the PylonComponentArgs struct
@@ -14,8 +16,10 @@ func (args *PylonComponentArgs) Validate() error {
 		return fmt.Errorf("name is required")
 	}
 
-	if args.ExecutionJwt == "" {
-		return fmt.Errorf("executionJwt is required")
+	if args.ExecutionJwt != "" {
+		if err := utils.ValidateJWTSecret(args.ExecutionJwt); err != nil {
+			return fmt.Errorf("invalid executionJwt: %w", err)
+		}
 	}
 
 	if args.PylonImage == "" {
@@ -26,14 +30,83 @@ func (args *PylonComponentArgs) Validate() error {
 		return fmt.Errorf("pylonBlobBucketName is required")
 	}
 
-	if err := validateEnv(args.Env); err != nil {
+	if args.StorageClass == nil {
+		return fmt.Errorf("storageClass is required")
+	}
+
+	if args.ExecutionDataSnapshot != nil {
+		if err := args.ExecutionDataSnapshot.ValidateSize(ExecutionClientStorageSize); err != nil {
+			return fmt.Errorf("invalid executionDataSnapshot: %w", err)
+		}
+	}
+
+	if args.ConsensusDataSnapshot != nil {
+		if err := args.ConsensusDataSnapshot.ValidateSize(ConsensusClientStorageSize); err != nil {
+			return fmt.Errorf("invalid consensusDataSnapshot: %w", err)
+		}
+	}
+
+	if args.ExecutionDataArchive != nil {
+		if err := args.ExecutionDataArchive.Validate(); err != nil {
+			return fmt.Errorf("invalid executionDataArchive: %w", err)
+		}
+		if args.PodIdentity == nil {
+			return fmt.Errorf("executionDataArchive requires podIdentity")
+		}
+	}
+
+	if err := args.blobBucketArgs().Validate(); err != nil {
+		return fmt.Errorf("invalid blob bucket: %w", err)
+	}
+
+	if args.ExternalSecretRef != nil {
+		if err := args.ExternalSecretRef.Validate(); err != nil {
+			return fmt.Errorf("invalid externalSecretRef: %w", err)
+		}
+		names := []string{PylonDbUrlEnvName}
+		if args.PodIdentity == nil {
+			names = append(names, AwsSecretAccessKeyEnvName)
+		}
+		if err := args.ExternalSecretRef.RequireEnvNames(names...); err != nil {
+			return fmt.Errorf("invalid externalSecretRef: %w", err)
+		}
+	}
+
+	if args.PodIdentity != nil {
+		if err := args.PodIdentity.Validate(); err != nil {
+			return fmt.Errorf("invalid podIdentity: %w", err)
+		}
+	}
+
+	if args.PostgresDbArgs != nil {
+		if args.ExternalSecretRef != nil {
+			return fmt.Errorf("postgresDbArgs cannot be combined with externalSecretRef")
+		}
+		if args.Env.PylonDbUrl != "" {
+			return fmt.Errorf("pylonDbUrl must be empty when postgresDbArgs is set")
+		}
+		// The connection URL is copied into pylon's env once per deployment, so it would go
+		// stale when RDS rotates a managed password
+		if args.PostgresDbArgs.ManageMasterUserPassword {
+			return fmt.Errorf("postgresDbArgs.manageMasterUserPassword is not supported by pylon")
+		}
+	}
+
+	// Sensitive values are not required when they are synced from an external store,
+	// static AWS keys are not required when using EKS Pod Identity, and the database
+	// URL is not required when the component creates the database
+	if err := validateEnv(args.Env, args.ExternalSecretRef == nil, args.PodIdentity == nil, args.PostgresDbArgs == nil); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func validateEnv(env PylonEnv) error {
+// validateEnv validates the PylonEnv struct
+// Sensitive fields are only checked when requireSecrets is set, static AWS keys
+// only when requireStaticCredentials is set, and the database URL only when
+// requireDbUrl is also set. S3 settings default to the component's blob bucket.
+func validateEnv(env PylonEnv, requireSecrets, requireStaticCredentials, requireDbUrl bool) error {
 	if env.PylonStartBlock == "" {
 		return fmt.Errorf("pylonStartBlock is required")
 	}
@@ -42,14 +115,6 @@ func validateEnv(env PylonEnv) error {
 		return fmt.Errorf("pylonSenders is required")
 	}
 
-	if env.PylonS3Url == "" {
-		return fmt.Errorf("pylonS3Url is required")
-	}
-
-	if env.PylonS3Region == "" {
-		return fmt.Errorf("pylonS3Region is required")
-	}
-
 	if env.PylonClUrl == "" {
 		return fmt.Errorf("pylonClUrl is required")
 	}
@@ -78,11 +143,11 @@ func validateEnv(env PylonEnv) error {
 		return fmt.Errorf("pylonPort is required")
 	}
 
-	if env.AwsAccessKeyId == "" {
+	if requireStaticCredentials && env.AwsAccessKeyId == "" {
 		return fmt.Errorf("awsAccessKeyId is required")
 	}
 
-	if env.AwsSecretAccessKey == "" {
+	if requireSecrets && requireStaticCredentials && env.AwsSecretAccessKey == "" {
 		return fmt.Errorf("awsSecretAccessKey is required")
 	}
 
@@ -90,7 +155,7 @@ func validateEnv(env PylonEnv) error {
 		return fmt.Errorf("awsRegion is required")
 	}
 
-	if env.PylonDbUrl == "" {
+	if requireSecrets && requireDbUrl && env.PylonDbUrl == "" {
 		return fmt.Errorf("pylonDbUrl is required")
 	}
 
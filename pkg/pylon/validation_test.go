@@ -3,6 +3,9 @@ package pylon
 import (
 	"testing"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+	"github.com/init4tech/signet-infra-components/pkg/storage"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -11,9 +14,10 @@ func TestPylonComponentArgsValidate(t *testing.T) {
 	validArgs := PylonComponentArgs{
 		Name:                "test-pylon",
 		Namespace:           "default",
-		ExecutionJwt:        "test-jwt",
+		ExecutionJwt:        "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718",
 		PylonImage:          "test-image:latest",
 		PylonBlobBucketName: "test-bucket",
+		StorageClass:        &storage.StorageClassComponent{},
 		Env: PylonEnv{
 			PylonStartBlock:            "1000",
 			PylonS3Url:                 "https://s3.example.com",
@@ -39,7 +43,7 @@ func TestPylonComponentArgsValidate(t *testing.T) {
 	// Test with missing name
 	invalidArgs1 := PylonComponentArgs{
 		Namespace:           "default",
-		ExecutionJwt:        "test-jwt",
+		ExecutionJwt:        "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718",
 		PylonImage:          "test-image:latest",
 		PylonBlobBucketName: "test-bucket",
 		Env:                 validArgs.Env,
@@ -52,7 +56,7 @@ func TestPylonComponentArgsValidate(t *testing.T) {
 	// Test with missing namespace
 	invalidArgs2 := PylonComponentArgs{
 		Name:                "test-pylon",
-		ExecutionJwt:        "test-jwt",
+		ExecutionJwt:        "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718",
 		PylonImage:          "test-image:latest",
 		PylonBlobBucketName: "test-bucket",
 		Env:                 validArgs.Env,
@@ -62,24 +66,22 @@ func TestPylonComponentArgsValidate(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "namespace is required")
 
-	// Test with missing executionJwt
-	invalidArgs3 := PylonComponentArgs{
-		Name:                "test-pylon",
-		Namespace:           "default",
-		PylonImage:          "test-image:latest",
-		PylonBlobBucketName: "test-bucket",
-		Env:                 validArgs.Env,
-	}
+	// Test executionJwt, generated when missing
+	invalidArgs3 := validArgs
+	invalidArgs3.ExecutionJwt = ""
+	err = invalidArgs3.Validate()
+	assert.NoError(t, err)
 
+	invalidArgs3.ExecutionJwt = "test-jwt"
 	err = invalidArgs3.Validate()
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "executionJwt is required")
+	assert.Contains(t, err.Error(), "invalid executionJwt: jwt secret must be 64 hex characters")
 
 	// Test with missing pylonImage
 	invalidArgs4 := PylonComponentArgs{
 		Name:                "test-pylon",
 		Namespace:           "default",
-		ExecutionJwt:        "test-jwt",
+		ExecutionJwt:        "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718",
 		PylonBlobBucketName: "test-bucket",
 		Env:                 validArgs.Env,
 	}
@@ -92,7 +94,7 @@ func TestPylonComponentArgsValidate(t *testing.T) {
 	invalidArgs5 := PylonComponentArgs{
 		Name:         "test-pylon",
 		Namespace:    "default",
-		ExecutionJwt: "test-jwt",
+		ExecutionJwt: "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718",
 		PylonImage:   "test-image:latest",
 		Env:          validArgs.Env,
 	}
@@ -100,4 +102,124 @@ func TestPylonComponentArgsValidate(t *testing.T) {
 	err = invalidArgs5.Validate()
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "pylonBlobBucketName is required")
+
+	// Test with missing storageClass
+	invalidArgs6 := validArgs
+	invalidArgs6.StorageClass = nil
+
+	err = invalidArgs6.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "storageClass is required")
+
+	// Test that sensitive env may be omitted when synced from an external store
+	externalArgs := validArgs
+	externalArgs.Env.AwsSecretAccessKey = ""
+	externalArgs.Env.PylonDbUrl = ""
+
+	err = externalArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "awsSecretAccessKey is required")
+
+	externalArgs.ExternalSecretRef = &utils.ExternalSecretRef{
+		SecretStoreName: "aws-secrets-manager",
+		Data: []utils.ExternalSecretData{
+			{EnvName: "AWS_SECRET_ACCESS_KEY", RemoteKey: "signet/pylon", Property: "aws_secret_access_key"},
+			{EnvName: "PYLON_DB_URL", RemoteKey: "signet/pylon", Property: "db_url"},
+		},
+	}
+	err = externalArgs.Validate()
+	assert.NoError(t, err)
+
+	// Test that the external secret ref has to sync every sensitive env name
+	missingArgs := externalArgs
+	missingArgs.ExternalSecretRef = &utils.ExternalSecretRef{
+		SecretStoreName: "aws-secrets-manager",
+		Data: []utils.ExternalSecretData{
+			{EnvName: "AWS_SECRET_ACCESS_KEY", RemoteKey: "signet/pylon", Property: "aws_secret_access_key"},
+		},
+	}
+	err = missingArgs.Validate()
+	assert.EqualError(t, err, "invalid externalSecretRef: no data entry for env name PYLON_DB_URL")
+
+	// Test that static AWS keys may be omitted when using EKS pod identity
+	podIdentityArgs := validArgs
+	podIdentityArgs.Env.AwsAccessKeyId = ""
+	podIdentityArgs.Env.AwsSecretAccessKey = ""
+
+	err = podIdentityArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "awsAccessKeyId is required")
+
+	podIdentityArgs.PodIdentity = &aws.PodIdentityConfig{ClusterName: "signet-cluster"}
+	err = podIdentityArgs.Validate()
+	assert.NoError(t, err)
+
+	podIdentityArgs.PodIdentity = &aws.PodIdentityConfig{}
+	err = podIdentityArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid podIdentity")
+
+	// Test that the execution data archive is read with the pod identity role
+	archiveArgs := validArgs
+	archiveArgs.ExecutionDataArchive = &storage.ArchiveSource{Bucket: "signet-archives", Key: "pylon.tar.zst", Region: "us-east-1"}
+	err = archiveArgs.Validate()
+	assert.EqualError(t, err, "executionDataArchive requires podIdentity")
+
+	archiveArgs.PodIdentity = &aws.PodIdentityConfig{ClusterName: "signet-cluster"}
+	err = archiveArgs.Validate()
+	assert.NoError(t, err)
+
+	archiveArgs.ExecutionDataArchive.Key = "pylon.zip"
+	err = archiveArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid executionDataArchive")
+
+	// Test that S3 settings default to the blob bucket
+	bucketArgs := validArgs
+	bucketArgs.Env.PylonS3Url = ""
+	bucketArgs.Env.PylonS3Region = ""
+	err = bucketArgs.Validate()
+	assert.NoError(t, err)
+
+	// Test that blob bucket settings are validated
+	lifecycleArgs := validArgs
+	lifecycleArgs.BlobBucketLifecycle = &aws.S3LifecycleArgs{InfrequentAccessAfterDays: 30, GlacierAfterDays: 180}
+	err = lifecycleArgs.Validate()
+	assert.NoError(t, err)
+
+	lifecycleArgs.BlobBucketLifecycle = &aws.S3LifecycleArgs{InfrequentAccessAfterDays: 10}
+	err = lifecycleArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid blob bucket")
+
+	// Test that the database URL is derived when the component creates the database
+	databaseArgs := validArgs
+	databaseArgs.PostgresDbArgs = &aws.PostgresDbArgs{
+		DbSubnetGroupName: "pylon-db-subnets",
+		DbUsername:        "pylon",
+		DbName:            "pylon",
+	}
+	err = databaseArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "pylonDbUrl must be empty when postgresDbArgs is set")
+
+	databaseArgs.Env.PylonDbUrl = ""
+	err = databaseArgs.Validate()
+	assert.NoError(t, err)
+
+	managedArgs := databaseArgs
+	managedArgs.PostgresDbArgs = &aws.PostgresDbArgs{
+		DbSubnetGroupName:        "pylon-db-subnets",
+		DbUsername:               "pylon",
+		DbName:                   "pylon",
+		ManageMasterUserPassword: true,
+	}
+	err = managedArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "manageMasterUserPassword is not supported by pylon")
+
+	databaseArgs.ExternalSecretRef = externalArgs.ExternalSecretRef
+	err = databaseArgs.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "postgresDbArgs cannot be combined with externalSecretRef")
 }
@@ -29,6 +29,8 @@ const (
 	DeploymentSuffix     = "-deployment"
 	ServiceAccountSuffix = "-sa"
 	ConfigMapSuffix      = "-configmap"
+	SecretSuffix         = "-secret"
+	ExternalSecretSuffix = "-external-secret"
 	VirtualServiceSuffix = "-vservice"
 	RequestAuthSuffix    = "-request-auth"
 	AuthPolicySuffix     = "-auth-policy"
@@ -39,6 +41,13 @@ const (
 	ServiceTypeClusterIP = "ClusterIP"
 )
 
+// Env names an ExternalSecretRef may have to sync. The key ID is not sensitive,
+// but may be kept in the external store alongside the AWS credentials
+const (
+	AwsSecretAccessKeyEnvName = "AWS_SECRET_ACCESS_KEY"
+	QuinceyKeyIdEnvName       = "QUINCEY_KEY_ID"
+)
+
 // Istio API versions and kinds
 const (
 	IstioNetworkingAPIVersion = "networking.istio.io/v1alpha3"
@@ -3,6 +3,7 @@ package quincey
 import (
 	"fmt"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
@@ -47,14 +48,50 @@ func NewQuinceyComponent(ctx *pulumi.Context, name string, args *QuinceyComponen
 		return nil, fmt.Errorf("failed to create service account: %w", err)
 	}
 
+	// Bind an IAM role to the service account when using EKS Pod Identity
+	if internalArgs.PodIdentity != nil {
+		podIdentity, err := aws.CreatePodIdentityResources(
+			ctx,
+			name,
+			ServiceName,
+			*internalArgs.PodIdentity,
+			internalArgs.Namespace,
+			serviceAccount.Metadata.Name().Elem(),
+			internalArgs.SigningKey,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pod identity resources: %w", err)
+		}
+		component.PodIdentity = podIdentity
+	}
+
 	// Create config map
 	configMap, err := createConfigMap(ctx, &internalArgs, component)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config map: %w", err)
 	}
 
+	// Create secret for sensitive environment variables, synced from AWS Secrets Manager when configured
+	var secretName pulumi.StringPtrInput
+	if internalArgs.ExternalSecretRef != nil {
+		externalSecret, err := createExternalSecret(ctx, &internalArgs, component)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create external secret: %w", err)
+		}
+		component.ExternalSecret = externalSecret
+		secretName = pulumi.String(externalSecretName())
+	} else {
+		secret, err := createSecret(ctx, &internalArgs, component)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create secret: %w", err)
+		}
+		component.Secret = secret
+		secretName = secret.Metadata.Name()
+	}
+
 	// Create deployment
-	deployment, err := createDeployment(ctx, &internalArgs, component)
+	deployment, err := createDeployment(ctx, &internalArgs, configMap, secretName, component)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create deployment: %w", err)
 	}
@@ -108,7 +145,7 @@ func createServiceAccount(ctx *pulumi.Context, namespace pulumi.StringInput, par
 }
 
 // createDeployment creates the Kubernetes deployment for the Quincey service
-func createDeployment(ctx *pulumi.Context, args *quinceyComponentArgsInternal, parent *QuinceyComponent) (*appsv1.Deployment, error) {
+func createDeployment(ctx *pulumi.Context, args *quinceyComponentArgsInternal, configMap *corev1.ConfigMap, secretName pulumi.StringPtrInput, parent *QuinceyComponent) (*appsv1.Deployment, error) {
 	labels := utils.CreateResourceLabels(ComponentName, ServiceName, DefaultAppSelector, nil)
 
 	containerPortInt := utils.ParsePortWithDefault(args.Env.QuinceyPort, DefaultQuinceyPort)
@@ -131,7 +168,7 @@ func createDeployment(ctx *pulumi.Context, args *quinceyComponentArgsInternal, p
 				Spec: &corev1.PodSpecArgs{
 					ServiceAccountName: pulumi.String(ServiceName),
 					Containers: corev1.ContainerArray{
-						createContainer(args, containerPortInt),
+						createContainer(args, containerPortInt, configMap.Metadata.Name(), secretName),
 					},
 				},
 			},
@@ -146,15 +183,40 @@ func createConfigMap(ctx *pulumi.Context, args *quinceyComponentArgsInternal, pa
 	return utils.CreateConfigMap(ctx, ServiceName, args.Namespace, labels, &args.Env)
 }
 
+// createSecret creates the Secret holding sensitive environment variables for the Quincey service
+func createSecret(ctx *pulumi.Context, args *quinceyComponentArgsInternal, parent *QuinceyComponent) (*corev1.Secret, error) {
+	labels := utils.CreateResourceLabels(ComponentName, ServiceName, DefaultAppSelector, nil)
+
+	return utils.CreateSecret(ctx, fmt.Sprintf("%s%s", ServiceName, SecretSuffix), args.Namespace, labels, &args.Env, parent)
+}
+
+// createExternalSecret creates the ExternalSecret that syncs sensitive environment variables
+// for the Quincey service from AWS Secrets Manager
+func createExternalSecret(ctx *pulumi.Context, args *quinceyComponentArgsInternal, parent *QuinceyComponent) (*crd.CustomResource, error) {
+	labels := utils.CreateResourceLabels(ComponentName, ServiceName, DefaultAppSelector, nil)
+
+	return utils.CreateExternalSecret(ctx, externalSecretName(), args.Namespace, labels, *args.ExternalSecretRef, parent)
+}
+
+// externalSecretName returns the name of the ExternalSecret and the Secret it syncs
+func externalSecretName() string {
+	return fmt.Sprintf("%s%s", ServiceName, ExternalSecretSuffix)
+}
+
 // createContainer creates the container specification for the Quincey service
-func createContainer(args *quinceyComponentArgsInternal, port pulumi.IntOutput) *corev1.ContainerArgs {
+func createContainer(args *quinceyComponentArgsInternal, port pulumi.IntOutput, configMapName pulumi.StringPtrInput, secretName pulumi.StringPtrInput) *corev1.ContainerArgs {
 	return &corev1.ContainerArgs{
 		Name:  pulumi.String(ServiceName),
 		Image: args.Image,
 		EnvFrom: corev1.EnvFromSourceArray{
 			&corev1.EnvFromSourceArgs{
 				ConfigMapRef: &corev1.ConfigMapEnvSourceArgs{
-					Name: pulumi.String(ServiceName),
+					Name: configMapName,
+				},
+			},
+			&corev1.EnvFromSourceArgs{
+				SecretRef: &corev1.SecretEnvSourceArgs{
+					Name: secretName,
 				},
 			},
 		},
@@ -1,9 +1,12 @@
 package quincey
 
 import (
+	"context"
 	"testing"
 
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,11 +48,17 @@ func TestCreateContainer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			container := createContainer(tt.args, tt.port)
+			container := createContainer(tt.args, tt.port, pulumi.String("quincey-env"), pulumi.String("quincey-secret"))
 			assert.NotNil(t, container)
 			assert.Equal(t, pulumi.String(tt.wantName), container.Name)
 			assert.Equal(t, tt.args.Image, container.Image)
 			assert.NotNil(t, container.EnvFrom)
+
+			// Both the ConfigMap and the Secret should be wired into EnvFrom
+			envFrom := container.EnvFrom.(corev1.EnvFromSourceArray)
+			assert.Len(t, envFrom, 2)
+			assert.NotNil(t, envFrom[0].(*corev1.EnvFromSourceArgs).ConfigMapRef)
+			assert.NotNil(t, envFrom[1].(*corev1.EnvFromSourceArgs).SecretRef)
 		})
 	}
 }
@@ -92,6 +101,31 @@ func TestQuinceyEnv(t *testing.T) {
 	assert.Equal(t, "info", env.RustLog)
 }
 
+// TestQuinceyEnvInternalSecretSplit tests that sensitive fields are routed into the secret map
+func TestQuinceyEnvInternalSecretSplit(t *testing.T) {
+	env := QuinceyEnv{
+		QuinceyPort:        "8080",
+		QuinceyKeyId:       "test-key-id",
+		AwsAccessKeyId:     "test-aws-key",
+		AwsSecretAccessKey: "test-aws-secret",
+		AwsDefaultRegion:   "us-west-2",
+	}.toInternal()
+
+	envMap := env.GetEnvMap()
+	secretMap := env.GetSecretEnvMap()
+
+	assert.NotContains(t, envMap, "AWS_SECRET_ACCESS_KEY")
+	assert.Contains(t, envMap, "AWS_ACCESS_KEY_ID")
+	assert.Len(t, secretMap, 1)
+	assert.Contains(t, secretMap, "AWS_SECRET_ACCESS_KEY")
+
+	// The AWS secret should be encrypted in stack state
+	result, err := internals.UnsafeAwaitOutput(context.Background(), secretMap["AWS_SECRET_ACCESS_KEY"].ToStringOutput())
+	assert.NoError(t, err)
+	assert.True(t, result.Secret)
+	assert.Equal(t, "test-aws-secret", result.Value)
+}
+
 // TestConstants tests the package constants
 func TestConstants(t *testing.T) {
 	assert.Equal(t, "quincey-server", ServiceName)
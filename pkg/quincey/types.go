@@ -13,6 +13,7 @@ package quincey
 import (
 	"strconv"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
@@ -30,6 +31,9 @@ type QuinceyComponent struct {
 	RequestAuthentication *crd.CustomResource
 	AuthorizationPolicy   *crd.CustomResource
 	ConfigMap             *corev1.ConfigMap
+	Secret                *corev1.Secret
+	ExternalSecret        *crd.CustomResource
+	PodIdentity           *aws.PodIdentityResources
 	pulumi.ResourceState
 }
 
@@ -45,6 +49,12 @@ type QuinceyComponentArgs struct {
 	Port int
 	// VirtualServiceHosts is the list of hosts for the virtual service
 	VirtualServiceHosts []string
+	// ExternalSecretRef optionally syncs sensitive env from AWS Secrets Manager instead of Env
+	ExternalSecretRef *utils.ExternalSecretRef
+	// PodIdentity optionally grants AWS access through EKS Pod Identity instead of static keys
+	PodIdentity *aws.PodIdentityConfig
+	// SigningKey optionally provides the KMS key quincey signs with instead of Env.QuinceyKeyId, requires PodIdentity
+	SigningKey *aws.SigningKeyComponent
 }
 
 // Internal structs with Pulumi types for use within the component
@@ -59,6 +69,12 @@ type quinceyComponentArgsInternal struct {
 	Port pulumi.StringInput
 	// VirtualServiceHosts is the list of hosts for the virtual service
 	VirtualServiceHosts pulumi.StringArrayInput
+	// ExternalSecretRef optionally syncs sensitive env from AWS Secrets Manager instead of Env
+	ExternalSecretRef *utils.ExternalSecretRef
+	// PodIdentity optionally grants AWS access through EKS Pod Identity instead of static keys
+	PodIdentity *aws.PodIdentityConfig
+	// SigningKey optionally provides the KMS key quincey signs with instead of Env.QuinceyKeyId
+	SigningKey *aws.SigningKeyComponent
 }
 
 // Public-facing environment struct with base Go types
@@ -66,7 +82,7 @@ type QuinceyEnv struct {
 	QuinceyPort              string `pulumi:"quinceyPort" validate:"required"`
 	QuinceyKeyId             string `pulumi:"quinceyKeyId" validate:"required"`
 	AwsAccessKeyId           string `pulumi:"awsAccessKeyId" validate:"required"`
-	AwsSecretAccessKey       string `pulumi:"awsSecretAccessKey" validate:"required"`
+	AwsSecretAccessKey       string `pulumi:"awsSecretAccessKey" validate:"required" secret:"true"`
 	AwsDefaultRegion         string `pulumi:"awsDefaultRegion" validate:"required"`
 	BlockQueryStart          string `pulumi:"blockQueryStart" validate:"required"`
 	BlockQueryCutoff         string `pulumi:"blockQueryCutoff" validate:"required"`
@@ -85,7 +101,7 @@ type quinceyEnvInternal struct {
 	QuinceyPort              pulumi.StringInput `pulumi:"quinceyPort" validate:"required"`
 	QuinceyKeyId             pulumi.StringInput `pulumi:"quinceyKeyId" validate:"required"`
 	AwsAccessKeyId           pulumi.StringInput `pulumi:"awsAccessKeyId" validate:"required"`
-	AwsSecretAccessKey       pulumi.StringInput `pulumi:"awsSecretAccessKey" validate:"required"`
+	AwsSecretAccessKey       pulumi.StringInput `pulumi:"awsSecretAccessKey" validate:"required" secret:"true"`
 	AwsDefaultRegion         pulumi.StringInput `pulumi:"awsDefaultRegion" validate:"required"`
 	BlockQueryStart          pulumi.StringInput `pulumi:"blockQueryStart" validate:"required"`
 	BlockQueryCutoff         pulumi.StringInput `pulumi:"blockQueryCutoff" validate:"required"`
@@ -101,13 +117,24 @@ type quinceyEnvInternal struct {
 
 // Conversion function to convert public args to internal args
 func (args QuinceyComponentArgs) toInternal() quinceyComponentArgsInternal {
-	return quinceyComponentArgsInternal{
+	internal := quinceyComponentArgsInternal{
 		Namespace:           pulumi.String(args.Namespace),
 		Image:               pulumi.String(args.Image),
 		Env:                 args.Env.toInternal(),
 		Port:                pulumi.String(strconv.Itoa(args.Port)),
 		VirtualServiceHosts: pulumi.ToStringArray(args.VirtualServiceHosts),
+		ExternalSecretRef:   args.ExternalSecretRef,
+		PodIdentity:         args.PodIdentity,
+		SigningKey:          args.SigningKey,
 	}
+	if args.SigningKey != nil {
+		internal.Env.QuinceyKeyId = args.SigningKey.KeyId
+	}
+	if args.PodIdentity != nil {
+		internal.Env.AwsAccessKeyId = nil
+		internal.Env.AwsSecretAccessKey = nil
+	}
+	return internal
 }
 
 // Conversion function to convert public env to internal env
@@ -116,7 +143,7 @@ func (e QuinceyEnv) toInternal() quinceyEnvInternal {
 		QuinceyPort:              pulumi.String(e.QuinceyPort),
 		QuinceyKeyId:             pulumi.String(e.QuinceyKeyId),
 		AwsAccessKeyId:           pulumi.String(e.AwsAccessKeyId),
-		AwsSecretAccessKey:       pulumi.String(e.AwsSecretAccessKey),
+		AwsSecretAccessKey:       utils.SecretString(e.AwsSecretAccessKey),
 		AwsDefaultRegion:         pulumi.String(e.AwsDefaultRegion),
 		BlockQueryStart:          pulumi.String(e.BlockQueryStart),
 		BlockQueryCutoff:         pulumi.String(e.BlockQueryCutoff),
@@ -133,7 +160,14 @@ func (e QuinceyEnv) toInternal() quinceyEnvInternal {
 
 // GetEnvMap implements the utils.EnvProvider interface for internal env
 func (e quinceyEnvInternal) GetEnvMap() pulumi.StringMap {
-	return utils.CreateEnvMap(e)
+	envMap, _ := utils.CreateEnvMap(e)
+	return envMap
+}
+
+// GetSecretEnvMap implements the utils.SecretEnvProvider interface for internal env
+func (e quinceyEnvInternal) GetSecretEnvMap() pulumi.StringMap {
+	_, secretMap := utils.CreateEnvMap(e)
+	return secretMap
 }
 
 // Quincey defines the interface for interacting with a Quincey deployment.
@@ -12,7 +12,40 @@ func (args *QuinceyComponentArgs) Validate() error {
 	if args.Image == "" {
 		return fmt.Errorf("image is required")
 	}
-	if err := args.Env.Validate(); err != nil {
+	if args.ExternalSecretRef != nil {
+		if err := args.ExternalSecretRef.Validate(); err != nil {
+			return fmt.Errorf("external secret ref is invalid: %w", err)
+		}
+	}
+	if args.PodIdentity != nil {
+		if err := args.PodIdentity.Validate(); err != nil {
+			return fmt.Errorf("pod identity config is invalid: %w", err)
+		}
+	}
+	// kms:Sign is granted to the pod identity role, so a signing key needs one
+	if args.SigningKey != nil && args.PodIdentity == nil {
+		return fmt.Errorf("signing key requires pod identity")
+	}
+	// Sensitive values are not required when they are synced from an external store,
+	// and static AWS keys are not required when using EKS Pod Identity
+	// A KMS signing key provides the quincey key ID
+	required := envRequirements{
+		secrets:           args.ExternalSecretRef == nil,
+		staticCredentials: args.PodIdentity == nil,
+		keyId:             args.SigningKey == nil,
+	}
+	if args.ExternalSecretRef != nil {
+		names := required.secretEnvNames()
+		if required.keyId && args.Env.QuinceyKeyId == "" {
+			// The key ID is synced from the external store instead
+			names = append(names, QuinceyKeyIdEnvName)
+			required.keyId = false
+		}
+		if err := args.ExternalSecretRef.RequireEnvNames(names...); err != nil {
+			return fmt.Errorf("external secret ref is invalid: %w", err)
+		}
+	}
+	if err := args.Env.validate(required); err != nil {
 		return fmt.Errorf("env is invalid: %w", err)
 	}
 	if args.VirtualServiceHosts == nil {
@@ -26,16 +59,39 @@ func (args *QuinceyComponentArgs) Validate() error {
 
 // Validate validates the QuinceyEnv struct, ensuring all required fields are set
 func (env *QuinceyEnv) Validate() error {
+	return env.validate(envRequirements{secrets: true, staticCredentials: true, keyId: true})
+}
+
+// envRequirements selects which QuinceyEnv fields are required, since some may be
+// provided by an external secret store, EKS Pod Identity or a KMS signing key
+type envRequirements struct {
+	secrets           bool
+	staticCredentials bool
+	keyId             bool
+}
+
+// secretEnvNames returns the env names of the required sensitive fields, ignoring
+// whether they come from QuinceyEnv or an external secret store
+func (required envRequirements) secretEnvNames() []string {
+	var names []string
+	if required.staticCredentials {
+		names = append(names, AwsSecretAccessKeyEnvName)
+	}
+	return names
+}
+
+// validate checks required QuinceyEnv fields, skipping those not in required
+func (env *QuinceyEnv) validate(required envRequirements) error {
 	if env.QuinceyPort == "" {
 		return fmt.Errorf("quincey port is required")
 	}
-	if env.QuinceyKeyId == "" {
+	if required.keyId && env.QuinceyKeyId == "" {
 		return fmt.Errorf("quincey key ID is required")
 	}
-	if env.AwsAccessKeyId == "" {
+	if required.staticCredentials && env.AwsAccessKeyId == "" {
 		return fmt.Errorf("AWS access key ID is required")
 	}
-	if env.AwsSecretAccessKey == "" {
+	if required.secrets && required.staticCredentials && env.AwsSecretAccessKey == "" {
 		return fmt.Errorf("AWS secret access key is required")
 	}
 	if env.AwsDefaultRegion == "" {
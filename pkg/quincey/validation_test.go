@@ -3,6 +3,8 @@ package quincey
 import (
 	"testing"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -100,4 +102,128 @@ func TestQuinceyComponentArgsValidate(t *testing.T) {
 		err := args.Validate()
 		assert.NoError(t, err)
 	})
+
+	// Test that the AWS secret may be omitted when synced from an external store
+	t.Run("external secret ref relaxes sensitive fields", func(t *testing.T) {
+		args := QuinceyComponentArgs{
+			Namespace:           "test-namespace",
+			Image:               "test-image:latest",
+			Port:                8080,
+			VirtualServiceHosts: []string{"example.com"},
+			Env: QuinceyEnv{
+				QuinceyPort:      "8080",
+				QuinceyKeyId:     "test-key-id",
+				AwsAccessKeyId:   "test-access-key",
+				AwsDefaultRegion: "us-west-2",
+				BlockQueryStart:  "1000",
+				BlockQueryCutoff: "2000",
+				ChainOffset:      "10",
+				HostRpcUrl:       "http://host-rpc",
+				OauthIssuer:      "https://issuer",
+				OauthJwksUri:     "https://jwks",
+				QuinceyBuilders:  "builder1,builder2",
+			},
+		}
+		err := args.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "AWS secret access key is required")
+
+		args.ExternalSecretRef = &utils.ExternalSecretRef{
+			SecretStoreName: "aws-secrets-manager",
+			Data: []utils.ExternalSecretData{
+				{EnvName: "AWS_SECRET_ACCESS_KEY", RemoteKey: "signet/quincey", Property: "aws_secret_access_key"},
+			},
+		}
+		err = args.Validate()
+		assert.NoError(t, err)
+
+		// The external secret ref has to sync the AWS secret
+		args.ExternalSecretRef.Data[0].EnvName = "OTHER_SECRET"
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "external secret ref is invalid: no data entry for env name AWS_SECRET_ACCESS_KEY", err.Error())
+
+		// A key ID left out of the env has to be synced as well
+		args.ExternalSecretRef.Data[0].EnvName = "AWS_SECRET_ACCESS_KEY"
+		args.Env.QuinceyKeyId = ""
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "external secret ref is invalid: no data entry for env name QUINCEY_KEY_ID", err.Error())
+
+		args.ExternalSecretRef.Data = append(args.ExternalSecretRef.Data,
+			utils.ExternalSecretData{EnvName: "QUINCEY_KEY_ID", RemoteKey: "signet/quincey", Property: "key_id"},
+		)
+		err = args.Validate()
+		assert.NoError(t, err)
+	})
+
+	// Test that static AWS keys may be omitted when using EKS pod identity
+	t.Run("pod identity relaxes static aws keys", func(t *testing.T) {
+		args := QuinceyComponentArgs{
+			Namespace:           "test-namespace",
+			Image:               "test-image:latest",
+			Port:                8080,
+			VirtualServiceHosts: []string{"example.com"},
+			Env: QuinceyEnv{
+				QuinceyPort:      "8080",
+				QuinceyKeyId:     "test-key-id",
+				AwsDefaultRegion: "us-west-2",
+				BlockQueryStart:  "1000",
+				BlockQueryCutoff: "2000",
+				ChainOffset:      "10",
+				HostRpcUrl:       "http://host-rpc",
+				OauthIssuer:      "https://issuer",
+				OauthJwksUri:     "https://jwks",
+				QuinceyBuilders:  "builder1,builder2",
+			},
+		}
+		err := args.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "AWS access key ID is required")
+
+		args.PodIdentity = &aws.PodIdentityConfig{ClusterName: "signet-cluster"}
+		err = args.Validate()
+		assert.NoError(t, err)
+
+		args.PodIdentity.ClusterName = ""
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "pod identity config is invalid")
+	})
+
+	// Test that the key ID may be omitted when a KMS signing key is provided
+	t.Run("signing key relaxes quincey key id", func(t *testing.T) {
+		args := QuinceyComponentArgs{
+			Namespace:           "test-namespace",
+			Image:               "test-image:latest",
+			Port:                8080,
+			VirtualServiceHosts: []string{"example.com"},
+			Env: QuinceyEnv{
+				QuinceyPort:        "8080",
+				AwsAccessKeyId:     "test-access-key",
+				AwsSecretAccessKey: "test-secret-key",
+				AwsDefaultRegion:   "us-west-2",
+				BlockQueryStart:    "1000",
+				BlockQueryCutoff:   "2000",
+				ChainOffset:        "10",
+				HostRpcUrl:         "http://host-rpc",
+				OauthIssuer:        "https://issuer",
+				OauthJwksUri:       "https://jwks",
+				QuinceyBuilders:    "builder1,builder2",
+			},
+		}
+		err := args.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "quincey key ID is required")
+
+		// A KMS signing key is rejected without pod identity, which grants kms:Sign
+		args.SigningKey = &aws.SigningKeyComponent{}
+		err = args.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "signing key requires pod identity", err.Error())
+
+		args.PodIdentity = &aws.PodIdentityConfig{ClusterName: "signet-cluster"}
+		err = args.Validate()
+		assert.NoError(t, err)
+	})
 }
@@ -2,10 +2,6 @@ package signet_node
 
 // Resource defaults
 const (
-	// Storage defaults
-	DefaultStorageSize  = "150Gi"
-	DefaultStorageClass = "aws-gp3"
-
 	// StatefulSet defaults
 	DefaultReplicas = 1
 
@@ -15,17 +11,22 @@ const (
 	DefaultCPURequest    = "2"
 	DefaultMemoryRequest = "4Gi"
 
+	// Image pull policies
+	DefaultImagePullPolicy      = ImagePullPolicyAlways
+	ImagePullPolicyAlways       = "Always"
+	ImagePullPolicyIfNotPresent = "IfNotPresent"
+	ImagePullPolicyNever        = "Never"
+
 	// Port defaults
 	MetricsPort          = 9001
 	RpcPort              = 8545
 	WsPort               = 8546
 	AuthRpcPort          = 8551
 	DiscoveryPort        = 30303
+	ConsensusP2PPort     = 9000
 	ConsensusHttpPort    = 4000
 	ConsensusMetricsPort = 5054
 	HostIpcPort          = 8547
-	RollupHttpPort       = 8645
-	RollupWsPort         = 8646
 
 	// SignetNode environment defaults
 	DefaultSignetRpcPort   = 8645
@@ -40,21 +41,45 @@ const (
 	DefaultExecutionJwtMountPath   = "/etc/reth/execution-jwt"
 )
 
+// RPC VirtualService defaults
+const (
+	VirtualServiceAPIVersion = "networking.istio.io/v1alpha3"
+	VirtualServiceKind       = "VirtualService"
+	DefaultGateway           = "default/init4-api-gateway"
+	DefaultRoutePrefix       = "/"
+	// Websocket requests are told apart from plain HTTP by their upgrade header, which is
+	// matched case-insensitively
+	UpgradeHeader           = "upgrade"
+	WebsocketUpgradePattern = "(?i)websocket"
+	HttpRouteName           = "http"
+	WsRouteName             = "ws"
+)
+
 // Resource name suffixes
 const (
-	ServiceSuffix        = "-service"
-	StatefulSetSuffix    = "-set"
-	ConfigMapSuffix      = "-configmap"
-	PvcSuffix            = "-data"
-	SecretSuffix         = "-secret"
 	VirtualServiceSuffix = "-vservice"
+	ServiceAccountSuffix = "-sa"
+	ArchiveAccessSuffix  = "-archive"
+	JWTSecretSuffix      = "-jwt"
+	// The execution and consensus clients, and all of their resources, are named after the node
+	ExecutionClientSuffix = "-execution"
+	ConsensusClientSuffix = "-consensus"
 )
 
-// Resource names
+// Volumes that can be selected for scheduled snapshots
+const (
+	SnapshotVolumeHostData       = "host-data"
+	SnapshotVolumeRollupData     = "rollup-data"
+	SnapshotVolumeLighthouseData = "lighthouse-data"
+)
+
+// RollupDataVolumeName names the execution client's rollup data volume and the claim template
+// it is created from
+const RollupDataVolumeName = "rollup-data"
+
+// Names of the execution client ports added for the signet node
 const (
-	SignetNodeName      = "signet-node"
-	LighthouseName      = "lighthouse"
-	ExecutionJwtName    = "execution-jwt"
-	RollupDataName      = "rollup-data"
-	ExecutionConfigName = "exex-configmap"
+	HostIpcPortName    = "host-ipc"
+	RollupHttpPortName = "rollup-http"
+	RollupWsPortName   = "rollup-ws"
 )
@@ -1,79 +1,91 @@
 package signet_node
 
 import (
-	"fmt"
-
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
-	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
-// CreatePersistentVolumeClaim creates a new PVC with the given name and size
-func CreatePersistentVolumeClaim(
-	ctx *pulumi.Context,
-	name string,
-	namespace pulumi.StringInput,
-	storageSize pulumi.StringInput,
-	storageClass string,
-	component pulumi.Resource,
-) (*corev1.PersistentVolumeClaim, error) {
-	if storageSize == nil {
-		storageSize = pulumi.String(DefaultStorageSize)
-	}
-
-	if storageClass == "" {
-		storageClass = DefaultStorageClass
+// requirements converts the container resources to resource requirements, using the
+// defaults for unset values
+func (resources *ContainerResources) requirements() *corev1.ResourceRequirements {
+	var set ContainerResources
+	if resources != nil {
+		set = *resources
 	}
-
-	pvc, err := corev1.NewPersistentVolumeClaim(ctx, name, &corev1.PersistentVolumeClaimArgs{
-		Metadata: &metav1.ObjectMetaArgs{
-			Labels: pulumi.StringMap{
-				"app.kubernetes.io/name":    pulumi.String(name),
-				"app.kubernetes.io/part-of": pulumi.String(name),
-			},
-			Namespace: namespace,
+	return &corev1.ResourceRequirements{
+		Limits: map[string]string{
+			"cpu":    valueOrDefault(set.CpuLimit, DefaultCPULimit),
+			"memory": valueOrDefault(set.MemoryLimit, DefaultMemoryLimit),
 		},
-		Spec: &corev1.PersistentVolumeClaimSpecArgs{
-			AccessModes: pulumi.StringArray{pulumi.String("ReadWriteOnce")},
-			Resources: &corev1.VolumeResourceRequirementsArgs{
-				Requests: pulumi.StringMap{
-					"storage": storageSize,
-				},
-			},
-			StorageClassName: pulumi.String(storageClass),
+		Requests: map[string]string{
+			"cpu":    valueOrDefault(set.CpuRequest, DefaultCPURequest),
+			"memory": valueOrDefault(set.MemoryRequest, DefaultMemoryRequest),
 		},
-	}, pulumi.Parent(component))
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create PVC %s: %w", name, err)
 	}
-
-	return pvc, nil
 }
 
-// ResourceRequirements returns consistent resource requirements for pods
-func NewResourceRequirements(cpuLimit, memoryLimit, cpuRequest, memoryRequest string) *corev1.ResourceRequirementsArgs {
-	if cpuLimit == "" {
-		cpuLimit = DefaultCPULimit
+// valueOrDefault returns value, or defaultValue when value is empty
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
 	}
-	if memoryLimit == "" {
-		memoryLimit = DefaultMemoryLimit
+	return value
+}
+
+// snapshotVolumes returns the volumes selected for scheduled snapshots, defaulting to all of them
+func (args *SignetNodeComponentArgs) snapshotVolumes() []string {
+	if len(args.SnapshotVolumes) > 0 {
+		return args.SnapshotVolumes
 	}
-	if cpuRequest == "" {
-		cpuRequest = DefaultCPURequest
+	return []string{SnapshotVolumeHostData, SnapshotVolumeRollupData, SnapshotVolumeLighthouseData}
+}
+
+// spec builds the VirtualService spec routing requests for the configured hosts to the rpc ports
+// of serviceHost. Websocket upgrades are matched first since the HTTP route usually matches
+// every path.
+func (vs *RpcVirtualServiceArgs) spec(serviceHost pulumi.StringInput) map[string]interface{} {
+	websocketHeaders := map[string]interface{}{
+		UpgradeHeader: map[string]interface{}{
+			"regex": WebsocketUpgradePattern,
+		},
 	}
-	if memoryRequest == "" {
-		memoryRequest = DefaultMemoryRequest
+	return map[string]interface{}{
+		"hosts":    vs.Hosts,
+		"gateways": vs.Gateways,
+		"http": []map[string]interface{}{
+			vs.WsRoute.httpRoute(WsRouteName, serviceHost, websocketHeaders),
+			vs.HttpRoute.httpRoute(HttpRouteName, serviceHost, nil),
+		},
 	}
+}
 
-	return &corev1.ResourceRequirementsArgs{
-		Limits: pulumi.StringMap{
-			"cpu":    pulumi.String(cpuLimit),
-			"memory": pulumi.String(memoryLimit),
-		},
-		Requests: pulumi.StringMap{
-			"cpu":    pulumi.String(cpuRequest),
-			"memory": pulumi.String(memoryRequest),
+// httpRoute builds a VirtualService HTTP route matching the route's prefixes and, when given,
+// headers
+func (route *RpcRoute) httpRoute(name string, serviceHost pulumi.StringInput, headers map[string]interface{}) map[string]interface{} {
+	matches := make([]map[string]interface{}, 0, len(route.Prefixes))
+	for _, prefix := range route.Prefixes {
+		match := map[string]interface{}{
+			"uri": map[string]interface{}{
+				"prefix": prefix,
+			},
+		}
+		if headers != nil {
+			match["headers"] = headers
+		}
+		matches = append(matches, match)
+	}
+	return map[string]interface{}{
+		"name":  name,
+		"match": matches,
+		"route": []map[string]interface{}{
+			{
+				"destination": map[string]interface{}{
+					"host": serviceHost,
+					"port": map[string]interface{}{
+						"number": route.Port,
+					},
+				},
+			},
 		},
 	}
 }
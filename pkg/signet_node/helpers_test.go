@@ -9,34 +9,61 @@ import (
 
 // TestCreateResourceLabels moved to pkg/utils/labels_test.go since the function was moved there
 
-func TestResourceRequirements(t *testing.T) {
-	// Test with default values
-	resources := NewResourceRequirements("", "", "", "")
-
-	// Check that resources is not nil
-	assert.NotNil(t, resources)
-
-	// Verify the structure indirectly by checking key presence
-	stringMap := resources.Limits.(pulumi.StringMap)
-	assert.Contains(t, stringMap, "cpu")
-	assert.Contains(t, stringMap, "memory")
-	assert.Equal(t, pulumi.String(DefaultCPULimit), stringMap["cpu"])
-	assert.Equal(t, pulumi.String(DefaultMemoryLimit), stringMap["memory"])
-
-	requestsMap := resources.Requests.(pulumi.StringMap)
-	assert.Contains(t, requestsMap, "cpu")
-	assert.Contains(t, requestsMap, "memory")
-	assert.Equal(t, pulumi.String(DefaultCPURequest), requestsMap["cpu"])
-	assert.Equal(t, pulumi.String(DefaultMemoryRequest), requestsMap["memory"])
-
-	// Test with custom values
-	customResources := NewResourceRequirements("4", "32Gi", "2", "16Gi")
-
-	customLimitsMap := customResources.Limits.(pulumi.StringMap)
-	assert.Equal(t, pulumi.String("4"), customLimitsMap["cpu"])
-	assert.Equal(t, pulumi.String("32Gi"), customLimitsMap["memory"])
-
-	customRequestsMap := customResources.Requests.(pulumi.StringMap)
-	assert.Equal(t, pulumi.String("2"), customRequestsMap["cpu"])
-	assert.Equal(t, pulumi.String("16Gi"), customRequestsMap["memory"])
+func TestContainerResourcesRequirements(t *testing.T) {
+	// Unset resources use the defaults
+	var unset *ContainerResources
+	requirements := unset.requirements()
+	assert.Equal(t, map[string]string{"cpu": DefaultCPULimit, "memory": DefaultMemoryLimit}, requirements.Limits)
+	assert.Equal(t, map[string]string{"cpu": DefaultCPURequest, "memory": DefaultMemoryRequest}, requirements.Requests)
+
+	// Set values override the defaults, the rest keep them
+	resources := &ContainerResources{MemoryLimit: "64Gi", MemoryRequest: "48Gi"}
+	requirements = resources.requirements()
+	assert.Equal(t, map[string]string{"cpu": DefaultCPULimit, "memory": "64Gi"}, requirements.Limits)
+	assert.Equal(t, map[string]string{"cpu": DefaultCPURequest, "memory": "48Gi"}, requirements.Requests)
+}
+
+func TestSnapshotVolumes(t *testing.T) {
+	args := SignetNodeComponentArgs{}
+	assert.Equal(t, []string{SnapshotVolumeHostData, SnapshotVolumeRollupData, SnapshotVolumeLighthouseData}, args.snapshotVolumes())
+
+	args.SnapshotVolumes = []string{SnapshotVolumeRollupData}
+	assert.Equal(t, []string{SnapshotVolumeRollupData}, args.snapshotVolumes())
+}
+
+func TestRpcVirtualServiceSpec(t *testing.T) {
+	vs := RpcVirtualServiceArgs{
+		Hosts:     []string{"rpc.pecorino.signet.sh"},
+		HttpRoute: RpcRoute{Prefixes: []string{"/", "/healthcheck"}},
+	}
+	vs.ApplyDefaults(SignetNodeEnv{RpcPort: 8645, WsRpcPort: 8646})
+
+	serviceHost := pulumi.String("signet-node.default.svc.cluster.local")
+	spec := vs.spec(serviceHost)
+	assert.Equal(t, []string{"rpc.pecorino.signet.sh"}, spec["hosts"])
+	assert.Equal(t, []string{DefaultGateway}, spec["gateways"])
+
+	routes := spec["http"].([]map[string]interface{})
+	assert.Len(t, routes, 2)
+
+	// Websocket upgrades are routed first, to the ws port
+	ws := routes[0]
+	assert.Equal(t, WsRouteName, ws["name"])
+	wsMatches := ws["match"].([]map[string]interface{})
+	assert.Len(t, wsMatches, 1)
+	assert.Equal(t, map[string]interface{}{"prefix": "/"}, wsMatches[0]["uri"])
+	assert.Equal(t, map[string]interface{}{UpgradeHeader: map[string]interface{}{"regex": WebsocketUpgradePattern}}, wsMatches[0]["headers"])
+	wsDestination := ws["route"].([]map[string]interface{})[0]["destination"].(map[string]interface{})
+	assert.Equal(t, serviceHost, wsDestination["host"])
+	assert.Equal(t, map[string]interface{}{"number": 8646}, wsDestination["port"])
+
+	// Everything else is routed to the http port
+	http := routes[1]
+	assert.Equal(t, HttpRouteName, http["name"])
+	httpMatches := http["match"].([]map[string]interface{})
+	assert.Len(t, httpMatches, 2)
+	assert.Equal(t, map[string]interface{}{"prefix": "/healthcheck"}, httpMatches[1]["uri"])
+	assert.NotContains(t, httpMatches[0], "headers")
+	httpDestination := http["route"].([]map[string]interface{})[0]["destination"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"number": 8645}, httpDestination["port"])
 }
@@ -1,9 +1,12 @@
 package signet_node
 
 import (
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/consensus"
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
-	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
@@ -17,55 +20,94 @@ type AppLabels struct {
 type SignetNodeComponentArgs struct {
 	Name                        string
 	Namespace                   string
+	Network                     string // Name of the host network preset both clients join, see the network package
 	Env                         SignetNodeEnv
-	ExecutionJwt                string
+	ExecutionJwt                string // Optional: hex encoded 32 byte Engine API JWT secret, generated when empty
 	ExecutionPvcSize            string
 	LighthousePvcSize           string
 	RollupPvcSize               string
+	Replicas                    int                            // Optional: number of execution and lighthouse replicas, defaults to 1
+	StorageClass                *storage.StorageClassComponent // Storage class for the node's data volumes
+	ExecutionPvcSnapshot        *storage.VolumeSnapshotSource  // Optional: restore the execution data volume from a snapshot
+	RollupPvcSnapshot           *storage.VolumeSnapshotSource  // Optional: restore the rollup data volume from a snapshot
+	LighthousePvcSnapshot       *storage.VolumeSnapshotSource  // Optional: restore the lighthouse data volume from a snapshot
 	ExecutionClientImage        string
 	ConsensusClientImage        string
-	ExecutionClientStartCommand []string
-	ConsensusClientStartCommand []string
+	ExecutionClientStartCommand []string // Optional: replaces the generated signet node command
+	ConsensusClientStartCommand []string // Optional: replaces the generated consensus client command, the execution endpoint is appended
 	AppLabels                   AppLabels
-	SignetNodeDataMountPath     string // Optional: defaults to "/root/.local/share/reth"
-	RollupDataMountPath         string // Optional: defaults to "/root/.local/share/exex"
-	ExecutionJwtMountPath       string // Optional: defaults to "/etc/reth/execution-jwt"
+	SignetNodeDataMountPath     string                       // Optional: defaults to "/root/.local/share/reth"
+	RollupDataMountPath         string                       // Optional: defaults to "/root/.local/share/exex"
+	ExecutionJwtMountPath       string                       // Optional: defaults to "/etc/reth/execution-jwt"
+	ExternalSecretRef           *utils.ExternalSecretRef     // Optional: sync sensitive env from AWS Secrets Manager
+	Snapshots                   *storage.SnapshotArgs        // Optional: take scheduled snapshots of the data volumes
+	SnapshotVolumes             []string                     // Optional: volumes to snapshot, defaults to all of them
+	PodIdentity                 *aws.PodIdentityConfig       // Optional: run the execution client under an IAM role with EKS Pod Identity
+	ExecutionDataArchive        *storage.ArchiveSource       // Optional: bootstrap the execution data volume from an S3 archive, requires PodIdentity
+	ExecutionResources          *ContainerResources          // Optional: execution client cpu and memory, unset values use the defaults
+	ConsensusResources          *ContainerResources          // Optional: consensus client cpu and memory, unset values use the defaults
+	ImagePullPolicy             string                       // Optional: pull policy of both client images, defaults to "Always"
+	NodeSelector                pulumi.StringMap             // Optional: schedule both client pods onto matching nodes
+	Tolerations                 corev1.TolerationArray       // Optional: tolerations for both client pods
+	Affinity                    *corev1.AffinityArgs         // Optional: affinity rules for both client pods
+	PriorityClassName           string                       // Optional: priority class of both client pods
+	ExecutionSyncReadiness      *execution.SyncReadinessArgs // Optional: keep execution replicas out of the RPC service and VirtualService while they sync
+	ConsensusSyncReadiness      *consensus.SyncReadinessArgs // Optional: keep consensus replicas out of the beacon API service while they sync
+	RpcVirtualService           RpcVirtualServiceArgs        // Istio VirtualService exposing the rollup RPC, hosts are required unless skipped
+}
+
+// RpcVirtualServiceArgs configures the Istio VirtualService that routes external traffic to the
+// node's rollup HTTP and websocket RPC ports
+type RpcVirtualServiceArgs struct {
+	Skip      bool     // Optional: do not create the VirtualService
+	Hosts     []string // Hosts the VirtualService answers for, such as rpc.pecorino.signet.sh
+	Gateways  []string // Optional: defaults to "default/init4-api-gateway"
+	HttpRoute RpcRoute // Optional: defaults to all paths on the env's rpc port
+	WsRoute   RpcRoute // Optional: defaults to websocket upgrades on all paths to the env's ws rpc port
+}
+
+// RpcRoute configures one route of the RPC VirtualService
+type RpcRoute struct {
+	Prefixes []string // Optional: URI prefixes to match, defaults to "/"
+	Port     int      // Optional: service port to route to
+}
+
+// ContainerResources sets the cpu and memory limits and requests of a container
+type ContainerResources struct {
+	CpuLimit      string
+	MemoryLimit   string
+	CpuRequest    string
+	MemoryRequest string
 }
 
 // Internal structs with Pulumi types for use within the component
 type signetNodeComponentArgsInternal struct {
-	Name                        string
-	Namespace                   pulumi.StringInput
-	Env                         signetNodeEnvInternal
-	ExecutionJwt                pulumi.StringInput
-	ExecutionPvcSize            pulumi.StringInput
-	LighthousePvcSize           pulumi.StringInput
-	RollupPvcSize               pulumi.StringInput
-	ExecutionClientImage        pulumi.StringInput
-	ConsensusClientImage        pulumi.StringInput
-	ExecutionClientStartCommand pulumi.StringArrayInput
-	ConsensusClientStartCommand pulumi.StringArrayInput
-	AppLabels                   AppLabels
-	SignetNodeDataMountPath     pulumi.StringInput
-	RollupDataMountPath         pulumi.StringInput
-	ExecutionJwtMountPath       pulumi.StringInput
+	Name      string
+	Namespace pulumi.StringInput
+	Env       signetNodeEnvInternal
 }
 
 type SignetNodeComponent struct {
 	pulumi.ResourceState
 
 	SignetNodeComponentArgs  SignetNodeComponentArgs
-	SignetNodeStatefulSet    *appsv1.StatefulSet
-	LighthouseStatefulSet    *appsv1.StatefulSet
-	SignetNodeService        *corev1.Service
-	LighthouseService        *corev1.Service
-	SignetNodeConfigMap      *corev1.ConfigMap
-	LighthouseConfigMap      *corev1.ConfigMap
-	JwtSecret                *corev1.Secret
-	HostDatabasePvc          *corev1.PersistentVolumeClaim
-	RollupDatabasePvc        *corev1.PersistentVolumeClaim
-	LighthousePvc            *corev1.PersistentVolumeClaim
-	SignetNodeVirtualService *crd.CustomResource
+	ExecutionClient          *execution.ExecutionClientComponent // Host chain and rollup data, one claim of each per replica
+	ConsensusClient          *consensus.ConsensusClientComponent // Paired with the execution replica of the same ordinal
+	SignetNodeVirtualService *crd.CustomResource                 // Nil when RpcVirtualService.Skip is set
+	SnapshotSchedule         *storage.VolumeSnapshotScheduleComponent
+	JWTSecret                *corev1.Secret            // Engine API JWT mounted by both clients
+	ServiceAccount           *corev1.ServiceAccount    // Set only when PodIdentity is given
+	PodIdentity              *aws.PodIdentityResources // Set only when PodIdentity is given
+	ArchiveAccess            *aws.IAMResources         // Set only when ExecutionDataArchive is given
+}
+
+// Conversion function to convert public args to internal args
+func (args SignetNodeComponentArgs) toInternal() signetNodeComponentArgsInternal {
+	return signetNodeComponentArgsInternal{
+		Name:      args.Name,
+		Namespace: pulumi.String(args.Namespace),
+		Env:       args.Env.toInternal(),
+	}
 }
 
 // Public-facing environment struct with base Go types
@@ -100,27 +142,6 @@ type signetNodeEnvInternal struct {
 	WsRpcPort          pulumi.IntInput    `pulumi:"wsRpcPort" validate:"required"`
 }
 
-// Conversion function to convert public args to internal args
-func (args SignetNodeComponentArgs) toInternal() signetNodeComponentArgsInternal {
-	return signetNodeComponentArgsInternal{
-		Name:                        args.Name,
-		Namespace:                   pulumi.String(args.Namespace),
-		Env:                         args.Env.toInternal(),
-		ExecutionJwt:                pulumi.String(args.ExecutionJwt),
-		ExecutionPvcSize:            pulumi.String(args.ExecutionPvcSize),
-		LighthousePvcSize:           pulumi.String(args.LighthousePvcSize),
-		RollupPvcSize:               pulumi.String(args.RollupPvcSize),
-		ExecutionClientImage:        pulumi.String(args.ExecutionClientImage),
-		ConsensusClientImage:        pulumi.String(args.ConsensusClientImage),
-		ExecutionClientStartCommand: pulumi.ToStringArray(args.ExecutionClientStartCommand),
-		ConsensusClientStartCommand: pulumi.ToStringArray(args.ConsensusClientStartCommand),
-		AppLabels:                   args.AppLabels,
-		SignetNodeDataMountPath:     pulumi.String(args.SignetNodeDataMountPath),
-		RollupDataMountPath:         pulumi.String(args.RollupDataMountPath),
-		ExecutionJwtMountPath:       pulumi.String(args.ExecutionJwtMountPath),
-	}
-}
-
 // Conversion function to convert public env to internal env
 func (e SignetNodeEnv) toInternal() signetNodeEnvInternal {
 	return signetNodeEnvInternal{
@@ -141,29 +162,8 @@ func (e SignetNodeEnv) toInternal() signetNodeEnvInternal {
 
 // GetEnvMap implements the utils.EnvProvider interface for internal env
 func (e signetNodeEnvInternal) GetEnvMap() pulumi.StringMap {
-	return utils.CreateEnvMap(e)
-}
-
-// ConsensusEnv contains environment variables for the consensus client
-type ConsensusEnv struct {
-	Example string `pulumi:"example"`
-}
-
-// Internal consensus env with Pulumi types
-type consensusEnvInternal struct {
-	Example pulumi.StringInput `pulumi:"example"`
-}
-
-// Conversion function for consensus env
-func (e ConsensusEnv) toInternal() consensusEnvInternal {
-	return consensusEnvInternal{
-		Example: pulumi.String(e.Example),
-	}
-}
-
-// GetEnvMap implements the utils.EnvProvider interface for internal consensus env
-func (e consensusEnvInternal) GetEnvMap() pulumi.StringMap {
-	return utils.CreateEnvMap(e)
+	envMap, _ := utils.CreateEnvMap(e)
+	return envMap
 }
 
 // SignetNode interface defines methods that the SignetNodeComponent must implement
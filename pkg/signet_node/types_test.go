@@ -0,0 +1,31 @@
+package signet_node
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignetNodeComponentArgsToInternal(t *testing.T) {
+	args := SignetNodeComponentArgs{
+		Name:         "test-node",
+		Namespace:    "default",
+		ExecutionJwt: "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718",
+		Env: SignetNodeEnv{
+			ChainName: "pecorino",
+			RpcPort:   8645,
+			WsRpcPort: 8646,
+		},
+	}
+
+	internalArgs := args.toInternal()
+	assert.Equal(t, "test-node", internalArgs.Name)
+	assert.Equal(t, pulumi.String("default"), internalArgs.Namespace)
+
+	// The env is handed to the execution client, which renders it into its ConfigMap
+	envMap := internalArgs.Env.GetEnvMap()
+	assert.Equal(t, pulumi.String("pecorino"), envMap["CHAIN_NAME"])
+	assert.Contains(t, envMap, "RPC_PORT")
+	assert.Contains(t, envMap, "WS_RPC_PORT")
+}
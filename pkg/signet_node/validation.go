@@ -2,6 +2,10 @@ package signet_node
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/network"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 )
 
 // ApplyDefaults sets default values for optional fields
@@ -15,8 +19,34 @@ func (args *SignetNodeComponentArgs) ApplyDefaults() {
 	if args.ExecutionJwtMountPath == "" {
 		args.ExecutionJwtMountPath = DefaultExecutionJwtMountPath
 	}
+	if args.Replicas == 0 {
+		args.Replicas = DefaultReplicas
+	}
+	if args.ImagePullPolicy == "" {
+		args.ImagePullPolicy = DefaultImagePullPolicy
+	}
 	// Apply defaults to env
 	args.Env.ApplyDefaults()
+	args.RpcVirtualService.ApplyDefaults(args.Env)
+}
+
+// ApplyDefaults sets default values for optional RpcVirtualServiceArgs fields, routing to the
+// env's rpc ports
+func (vs *RpcVirtualServiceArgs) ApplyDefaults(env SignetNodeEnv) {
+	if len(vs.Gateways) == 0 {
+		vs.Gateways = []string{DefaultGateway}
+	}
+	vs.HttpRoute.applyDefaults(env.RpcPort)
+	vs.WsRoute.applyDefaults(env.WsRpcPort)
+}
+
+func (route *RpcRoute) applyDefaults(port int) {
+	if len(route.Prefixes) == 0 {
+		route.Prefixes = []string{DefaultRoutePrefix}
+	}
+	if route.Port == 0 {
+		route.Port = port
+	}
 }
 
 // ApplyDefaults sets default values for optional SignetNodeEnv fields
@@ -36,8 +66,13 @@ func (args *SignetNodeComponentArgs) Validate() error {
 	if args.Namespace == "" {
 		return fmt.Errorf("namespace is required")
 	}
-	if args.ExecutionJwt == "" {
-		return fmt.Errorf("execution jwt is required")
+	if _, err := network.Lookup(args.Network); err != nil {
+		return fmt.Errorf("invalid network: %w", err)
+	}
+	if args.ExecutionJwt != "" {
+		if err := utils.ValidateJWTSecret(args.ExecutionJwt); err != nil {
+			return fmt.Errorf("invalid execution jwt: %w", err)
+		}
 	}
 	if args.ExecutionPvcSize == "" {
 		return fmt.Errorf("execution pvc size is required")
@@ -48,19 +83,91 @@ func (args *SignetNodeComponentArgs) Validate() error {
 	if args.RollupPvcSize == "" {
 		return fmt.Errorf("rollup pvc size is required")
 	}
+	if args.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative")
+	}
+	if args.StorageClass == nil {
+		return fmt.Errorf("storage class is required")
+	}
+	if args.ExecutionPvcSnapshot != nil {
+		if err := args.ExecutionPvcSnapshot.ValidateSize(args.ExecutionPvcSize); err != nil {
+			return fmt.Errorf("invalid execution pvc snapshot: %w", err)
+		}
+	}
+	if args.RollupPvcSnapshot != nil {
+		if err := args.RollupPvcSnapshot.ValidateSize(args.RollupPvcSize); err != nil {
+			return fmt.Errorf("invalid rollup pvc snapshot: %w", err)
+		}
+	}
+	if args.LighthousePvcSnapshot != nil {
+		if err := args.LighthousePvcSnapshot.ValidateSize(args.LighthousePvcSize); err != nil {
+			return fmt.Errorf("invalid lighthouse pvc snapshot: %w", err)
+		}
+	}
 	if args.ExecutionClientImage == "" {
 		return fmt.Errorf("execution client image is required")
 	}
 	if args.ConsensusClientImage == "" {
 		return fmt.Errorf("consensus client image is required")
 	}
-	if args.ExecutionClientStartCommand == nil {
-		return fmt.Errorf("execution client start command is required")
-	}
-	if args.ConsensusClientStartCommand == nil {
-		return fmt.Errorf("consensus client start command is required")
+	switch args.ImagePullPolicy {
+	case "", ImagePullPolicyAlways, ImagePullPolicyIfNotPresent, ImagePullPolicyNever:
+	default:
+		return fmt.Errorf("image pull policy must be %s, %s or %s", ImagePullPolicyAlways, ImagePullPolicyIfNotPresent, ImagePullPolicyNever)
 	}
 	// Note: AppLabels is optional and has a default zero value (empty map is fine)
+	if args.ExternalSecretRef != nil {
+		if err := args.ExternalSecretRef.Validate(); err != nil {
+			return fmt.Errorf("invalid external secret ref: %w", err)
+		}
+	}
+	if args.ExecutionSyncReadiness != nil {
+		if err := args.ExecutionSyncReadiness.Validate(); err != nil {
+			return fmt.Errorf("invalid execution sync readiness: %w", err)
+		}
+	}
+	if args.ConsensusSyncReadiness != nil {
+		if err := args.ConsensusSyncReadiness.Validate(); err != nil {
+			return fmt.Errorf("invalid consensus sync readiness: %w", err)
+		}
+	}
+	if args.PodIdentity != nil {
+		if err := args.PodIdentity.Validate(); err != nil {
+			return fmt.Errorf("invalid pod identity: %w", err)
+		}
+	}
+	if args.ExecutionDataArchive != nil {
+		if err := args.ExecutionDataArchive.Validate(); err != nil {
+			return fmt.Errorf("invalid execution data archive: %w", err)
+		}
+		if args.PodIdentity == nil {
+			return fmt.Errorf("execution data archive requires pod identity")
+		}
+	}
+
+	if args.Snapshots != nil {
+		if err := args.Snapshots.Validate(); err != nil {
+			return fmt.Errorf("invalid snapshots: %w", err)
+		}
+	} else if len(args.SnapshotVolumes) > 0 {
+		return fmt.Errorf("snapshot volumes require snapshots to be configured")
+	}
+	seenVolumes := make(map[string]bool)
+	for _, volume := range args.SnapshotVolumes {
+		switch volume {
+		case SnapshotVolumeHostData, SnapshotVolumeRollupData, SnapshotVolumeLighthouseData:
+		default:
+			return fmt.Errorf("unknown snapshot volume %s", volume)
+		}
+		if seenVolumes[volume] {
+			return fmt.Errorf("duplicate snapshot volume %s", volume)
+		}
+		seenVolumes[volume] = true
+	}
+
+	if err := args.RpcVirtualService.Validate(); err != nil {
+		return fmt.Errorf("invalid rpc virtual service: %w", err)
+	}
 
 	if err := args.Env.Validate(); err != nil {
 		return fmt.Errorf("invalid signet node env: %w", err)
@@ -68,6 +175,46 @@ func (args *SignetNodeComponentArgs) Validate() error {
 	return nil
 }
 
+// Validate validates the RpcVirtualServiceArgs struct
+func (vs *RpcVirtualServiceArgs) Validate() error {
+	if vs.Skip {
+		return nil
+	}
+	if len(vs.Hosts) == 0 {
+		return fmt.Errorf("hosts are required unless the virtual service is skipped")
+	}
+	for _, host := range vs.Hosts {
+		if host == "" {
+			return fmt.Errorf("hosts must not be empty")
+		}
+	}
+	for _, gateway := range vs.Gateways {
+		if gateway == "" {
+			return fmt.Errorf("gateways must not be empty")
+		}
+	}
+	if err := vs.HttpRoute.Validate(); err != nil {
+		return fmt.Errorf("invalid http route: %w", err)
+	}
+	if err := vs.WsRoute.Validate(); err != nil {
+		return fmt.Errorf("invalid ws route: %w", err)
+	}
+	return nil
+}
+
+// Validate validates the RpcRoute struct
+func (route *RpcRoute) Validate() error {
+	for _, prefix := range route.Prefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("prefix %q must start with /", prefix)
+		}
+	}
+	if route.Port < 0 || route.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	return nil
+}
+
 func (env *SignetNodeEnv) Validate() error {
 	if env.ChainName == "" {
 		return fmt.Errorf("chainName is required")
@@ -3,6 +3,10 @@ package signet_node
 import (
 	"testing"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/consensus"
+	"github.com/init4tech/signet-infra-components/pkg/ethereum/execution"
+	"github.com/init4tech/signet-infra-components/pkg/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,6 +14,7 @@ func TestSignetNodeComponentArgsValidate(t *testing.T) {
 	validArgs := SignetNodeComponentArgs{
 		Name:      "test-node",
 		Namespace: "default",
+		Network:   "holesky",
 		Env: SignetNodeEnv{
 			ChainName:          "test-chain",
 			IpcEndpoint:        "/tmp/reth.ipc",
@@ -23,15 +28,17 @@ func TestSignetNodeComponentArgsValidate(t *testing.T) {
 			WsRpcPort:          8546,
 			RustLog:            "info",
 		},
-		ExecutionJwt:                "jwt-secret-token",
+		ExecutionJwt:                "5f2b7a1c9e4d8036a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718",
 		ExecutionPvcSize:            "150Gi",
 		LighthousePvcSize:           "100Gi",
 		RollupPvcSize:               "50Gi",
+		StorageClass:                &storage.StorageClassComponent{},
 		ExecutionClientImage:        "execution:latest",
 		ConsensusClientImage:        "consensus:latest",
 		ExecutionClientStartCommand: []string{"./start-execution"},
 		ConsensusClientStartCommand: []string{"./start-consensus"},
 		AppLabels:                   AppLabels{},
+		RpcVirtualService:           RpcVirtualServiceArgs{Hosts: []string{"rpc.pecorino.signet.sh"}},
 	}
 
 	err := validArgs.Validate()
@@ -51,12 +58,153 @@ func TestSignetNodeComponentArgsValidate(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "namespace is required")
 
-	// Test missing execution jwt
+	// Test missing storage class
+	invalidStorageClass := validArgs
+	invalidStorageClass.StorageClass = nil
+	err = invalidStorageClass.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "storage class is required")
+
+	// Test replica count
+	withReplicas := validArgs
+	withReplicas.Replicas = 3
+	err = withReplicas.Validate()
+	assert.NoError(t, err)
+
+	withReplicas.Replicas = -1
+	err = withReplicas.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "replicas must not be negative")
+
+	// Test image pull policy
+	withPullPolicy := validArgs
+	withPullPolicy.ImagePullPolicy = ImagePullPolicyIfNotPresent
+	err = withPullPolicy.Validate()
+	assert.NoError(t, err)
+
+	withPullPolicy.ImagePullPolicy = "Sometimes"
+	err = withPullPolicy.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "image pull policy must be Always, IfNotPresent or Never")
+
+	// Test the rpc virtual service
+	withoutHosts := validArgs
+	withoutHosts.RpcVirtualService = RpcVirtualServiceArgs{}
+	err = withoutHosts.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid rpc virtual service: hosts are required unless the virtual service is skipped")
+
+	withoutHosts.RpcVirtualService.Skip = true
+	err = withoutHosts.Validate()
+	assert.NoError(t, err)
+
+	withRoutes := validArgs
+	withRoutes.RpcVirtualService.WsRoute = RpcRoute{Prefixes: []string{"ws"}}
+	err = withRoutes.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid ws route: prefix "ws" must start with /`)
+
+	withRoutes.RpcVirtualService.WsRoute = RpcRoute{}
+	withRoutes.RpcVirtualService.HttpRoute = RpcRoute{Port: 70000}
+	err = withRoutes.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid http route: port must be between 1 and 65535")
+
+	// Test restoring volumes from snapshots
+	withRestore := validArgs
+	withRestore.ExecutionPvcSnapshot = &storage.VolumeSnapshotSource{Name: "signet-node-data-snap", RestoreSize: "120Gi"}
+	withRestore.RollupPvcSnapshot = &storage.VolumeSnapshotSource{Name: "rollup-data-snap", RestoreSize: "50Gi"}
+	err = withRestore.Validate()
+	assert.NoError(t, err)
+
+	withRestore.LighthousePvcSnapshot = &storage.VolumeSnapshotSource{Name: "lighthouse-data-snap", RestoreSize: "200Gi"}
+	err = withRestore.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid lighthouse pvc snapshot: size 100Gi is smaller than the restore size 200Gi")
+
+	// Test snapshot configuration
+	withSnapshots := validArgs
+	withSnapshots.SnapshotVolumes = []string{SnapshotVolumeHostData}
+	err = withSnapshots.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "snapshot volumes require snapshots to be configured")
+
+	withSnapshots.Snapshots = &storage.SnapshotArgs{SnapshotClass: &storage.VolumeSnapshotClassComponent{}}
+	err = withSnapshots.Validate()
+	assert.NoError(t, err)
+
+	withSnapshots.SnapshotVolumes = []string{SnapshotVolumeHostData, "execution-data"}
+	err = withSnapshots.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown snapshot volume execution-data")
+
+	withSnapshots.SnapshotVolumes = []string{SnapshotVolumeLighthouseData, SnapshotVolumeLighthouseData}
+	err = withSnapshots.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate snapshot volume lighthouse-data")
+
+	withSnapshots.Snapshots = &storage.SnapshotArgs{}
+	withSnapshots.SnapshotVolumes = nil
+	err = withSnapshots.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid snapshots: snapshotClass is required")
+
+	// Test bootstrapping the execution volume from an archive
+	withArchive := validArgs
+	withArchive.ExecutionDataArchive = &storage.ArchiveSource{Bucket: "signet-archives", Key: "signet-node-data.tar.zst", Region: "us-east-1"}
+	err = withArchive.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "execution data archive requires pod identity")
+
+	withArchive.PodIdentity = &aws.PodIdentityConfig{ClusterName: "signet-cluster"}
+	err = withArchive.Validate()
+	assert.NoError(t, err)
+
+	withArchive.ExecutionDataArchive = &storage.ArchiveSource{Bucket: "signet-archives", Region: "us-east-1"}
+	err = withArchive.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid execution data archive: archive key is required")
+
+	withArchive.ExecutionDataArchive = nil
+	withArchive.PodIdentity = &aws.PodIdentityConfig{}
+	err = withArchive.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid pod identity")
+
+	// Test sync readiness
+	withReadiness := validArgs
+	withReadiness.ExecutionSyncReadiness = &execution.SyncReadinessArgs{MaxHeadLag: 2}
+	withReadiness.ConsensusSyncReadiness = &consensus.SyncReadinessArgs{MaxHeadLag: 2, MinPeers: 4}
+	err = withReadiness.Validate()
+	assert.NoError(t, err)
+
+	withReadiness.ConsensusSyncReadiness.MinPeers = -1
+	err = withReadiness.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid consensus sync readiness: minPeers must not be negative")
+
+	// Test network, required so the clients never fall back to mainnet
+	invalidNetwork := validArgs
+	invalidNetwork.Network = ""
+	err = invalidNetwork.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid network: unknown network")
+
+	invalidNetwork.Network = "goerli"
+	err = invalidNetwork.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid network: unknown network \"goerli\"")
+
+	// Test execution jwt, generated when missing
 	invalidJwt := validArgs
 	invalidJwt.ExecutionJwt = ""
 	err = invalidJwt.Validate()
+	assert.NoError(t, err)
+
+	invalidJwt.ExecutionJwt = "jwt-secret-token"
+	err = invalidJwt.Validate()
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "execution jwt is required")
+	assert.Contains(t, err.Error(), "invalid execution jwt: jwt secret must be 64 hex characters")
 
 	// Test missing execution pvc size
 	invalidPvc := validArgs
@@ -170,6 +318,11 @@ func TestApplyDefaults(t *testing.T) {
 		assert.Equal(t, DefaultSignetNodeDataMountPath, args.SignetNodeDataMountPath)
 		assert.Equal(t, DefaultRollupDataMountPath, args.RollupDataMountPath)
 		assert.Equal(t, DefaultExecutionJwtMountPath, args.ExecutionJwtMountPath)
+		assert.Equal(t, DefaultReplicas, args.Replicas)
+		assert.Equal(t, DefaultImagePullPolicy, args.ImagePullPolicy)
+		assert.Equal(t, []string{DefaultGateway}, args.RpcVirtualService.Gateways)
+		assert.Equal(t, RpcRoute{Prefixes: []string{DefaultRoutePrefix}, Port: DefaultSignetRpcPort}, args.RpcVirtualService.HttpRoute)
+		assert.Equal(t, RpcRoute{Prefixes: []string{DefaultRoutePrefix}, Port: DefaultSignetWsRpcPort}, args.RpcVirtualService.WsRoute)
 	})
 
 	t.Run("preserves custom values", func(t *testing.T) {
@@ -177,12 +330,14 @@ func TestApplyDefaults(t *testing.T) {
 			SignetNodeDataMountPath: "/custom/signet",
 			RollupDataMountPath:     "/custom/rollup",
 			ExecutionJwtMountPath:   "/custom/jwt",
+			ImagePullPolicy:         ImagePullPolicyIfNotPresent,
 		}
 		args.ApplyDefaults()
 
 		assert.Equal(t, "/custom/signet", args.SignetNodeDataMountPath)
 		assert.Equal(t, "/custom/rollup", args.RollupDataMountPath)
 		assert.Equal(t, "/custom/jwt", args.ExecutionJwtMountPath)
+		assert.Equal(t, ImagePullPolicyIfNotPresent, args.ImagePullPolicy)
 	})
 
 	t.Run("applies defaults only to unset fields", func(t *testing.T) {
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// archiveSuffixes maps the supported archive extensions to their compression
+var archiveSuffixes = map[string]string{
+	".tar":     ArchiveCompressionNone,
+	".tar.gz":  ArchiveCompressionGzip,
+	".tgz":     ArchiveCompressionGzip,
+	".tar.zst": ArchiveCompressionZstd,
+	".tzst":    ArchiveCompressionZstd,
+}
+
+// InitContainer returns an init container that restores the archive into the given volume,
+// mounted at mountPath, unless the volume already holds data. The pod must run under a
+// service account whose IAM role may read the archive.
+func (source *ArchiveSource) InitContainer(volumeName string, mountPath pulumi.StringInput) corev1.ContainerArgs {
+	image := source.Image
+	if image == "" {
+		image = DefaultArchiveImage
+	}
+	compression, _ := archiveCompression(source.Key)
+
+	return corev1.ContainerArgs{
+		Name:  pulumi.String(ArchiveRestoreContainerName),
+		Image: pulumi.String(image),
+		Command: pulumi.StringArray{
+			pulumi.String("/bin/sh"),
+			pulumi.String("-c"),
+			pulumi.String(archiveRestoreScript),
+		},
+		Env: corev1.EnvVarArray{
+			corev1.EnvVarArgs{
+				Name:  pulumi.String("ARCHIVE_BUCKET"),
+				Value: pulumi.String(source.Bucket),
+			},
+			corev1.EnvVarArgs{
+				Name:  pulumi.String("ARCHIVE_KEY"),
+				Value: pulumi.String(source.Key),
+			},
+			corev1.EnvVarArgs{
+				Name:  pulumi.String("ARCHIVE_COMPRESSION"),
+				Value: pulumi.String(compression),
+			},
+			corev1.EnvVarArgs{
+				Name:  pulumi.String("STRIP_COMPONENTS"),
+				Value: pulumi.String(strconv.Itoa(source.StripComponents)),
+			},
+			corev1.EnvVarArgs{
+				Name:  pulumi.String("AWS_REGION"),
+				Value: pulumi.String(source.Region),
+			},
+			corev1.EnvVarArgs{
+				Name:  pulumi.String("DATA_DIR"),
+				Value: mountPath,
+			},
+		},
+		VolumeMounts: corev1.VolumeMountArray{
+			corev1.VolumeMountArgs{
+				Name:      pulumi.String(volumeName),
+				MountPath: mountPath,
+			},
+		},
+	}
+}
+
+// archiveCompression returns the compression of an archive from its key's extension
+func archiveCompression(key string) (string, bool) {
+	for suffix, compression := range archiveSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return compression, true
+		}
+	}
+	return "", false
+}
+
+// archiveExtensions lists the supported archive extensions for error messages
+func archiveExtensions() string {
+	extensions := make([]string, 0, len(archiveSuffixes))
+	for suffix := range archiveSuffixes {
+		extensions = append(extensions, suffix)
+	}
+	sort.Strings(extensions)
+	return strings.Join(extensions, ", ")
+}
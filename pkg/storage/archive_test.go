@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveCompression(t *testing.T) {
+	testCases := []struct {
+		key         string
+		compression string
+		ok          bool
+	}{
+		{key: "data.tar", compression: ArchiveCompressionNone, ok: true},
+		{key: "data.tar.gz", compression: ArchiveCompressionGzip, ok: true},
+		{key: "data.tgz", compression: ArchiveCompressionGzip, ok: true},
+		{key: "snapshots/data.tar.zst", compression: ArchiveCompressionZstd, ok: true},
+		{key: "data.tzst", compression: ArchiveCompressionZstd, ok: true},
+		{key: "data.zst", ok: false},
+		{key: "data.tar.bz2", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			compression, ok := archiveCompression(tc.key)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.compression, compression)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package storage
+
+// Component kinds
+const (
+	StorageClassComponentKind           = "signet:index:StorageClass"
+	VolumeSnapshotClassComponentKind    = "signet:index:VolumeSnapshotClass"
+	VolumeSnapshotScheduleComponentKind = "signet:index:VolumeSnapshotSchedule"
+)
+
+// EBS CSI driver constants
+const (
+	EbsCsiProvisioner = "ebs.csi.aws.com"
+	VolumeTypeGp3     = "gp3"
+)
+
+// gp3 performance limits. Throughput is provisioned in MiB/s and may not exceed a quarter
+// of the provisioned IOPS.
+const (
+	DefaultGp3Iops       = 3000
+	MaxGp3Iops           = 16000
+	DefaultGp3Throughput = 125
+	MaxGp3Throughput     = 1000
+	Gp3IopsPerThroughput = 4
+)
+
+// StorageClass parameter keys understood by the EBS CSI driver
+const (
+	ParameterType       = "type"
+	ParameterIops       = "iops"
+	ParameterThroughput = "throughput"
+	ParameterEncrypted  = "encrypted"
+	ParameterKmsKeyId   = "kmsKeyId"
+	ParameterFsType     = "csi.storage.k8s.io/fstype"
+)
+
+// StorageClass settings
+const (
+	DefaultFsType                     = "ext4"
+	ReclaimPolicyDelete               = "Delete"
+	ReclaimPolicyRetain               = "Retain"
+	VolumeBindingWaitForFirstConsumer = "WaitForFirstConsumer"
+	IsDefaultClassAnnotation          = "storageclass.kubernetes.io/is-default-class"
+)
+
+// VolumeSnapshot constants
+const (
+	VolumeSnapshotAPIVersion    = "snapshot.storage.k8s.io/v1"
+	VolumeSnapshotClassKind     = "VolumeSnapshotClass"
+	DeletionPolicyDelete        = "Delete"
+	DeletionPolicyRetain        = "Retain"
+	IsDefaultSnapshotAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+	// SnapshotSourceLabel is set on each scheduled VolumeSnapshot to the name of its claim
+	SnapshotSourceLabel = "signet.sh/snapshot-of"
+)
+
+// Snapshot schedule defaults
+const (
+	DefaultSnapshotSchedule  = "0 0 * * *"
+	DefaultSnapshotRetention = 7
+	MaxSnapshotRetention     = 100
+	DefaultSnapshotImage     = "alpine/k8s:1.33.4"
+	SnapshotJobHistoryLimit  = 3
+	SnapshotJobBackoffLimit  = 2
+	ConcurrencyPolicyForbid  = "Forbid"
+	RestartPolicyOnFailure   = "OnFailure"
+	// SnapshotReadyTimeout bounds how long the job waits for a new snapshot to become ready
+	// before leaving the existing snapshots of its claim in place
+	SnapshotReadyTimeout = "2h"
+)
+
+// Snapshot schedule resource name suffixes
+const (
+	SnapshotServiceAccountSuffix = "-snapshotter"
+	SnapshotRoleSuffix           = "-snapshotter-role"
+	SnapshotRoleBindingSuffix    = "-snapshotter-binding"
+	SnapshotCronJobSuffix        = "-snapshots"
+)
+
+// snapshotScript snapshots each claim passed as an argument and waits for the snapshot to
+// become ready to use. Only then are all but the newest $RETENTION ready snapshots of that
+// claim deleted, so a failed or pending snapshot never displaces a usable one.
+const snapshotScript = `set -eu
+timestamp=$(date -u +%Y%m%d%H%M%S)
+for pvc in "$@"; do
+  snapshot="${pvc}-${timestamp}"
+  kubectl apply -f - <<SNAPSHOT
+apiVersion: ` + VolumeSnapshotAPIVersion + `
+kind: VolumeSnapshot
+metadata:
+  name: ${snapshot}
+  labels:
+    ` + SnapshotSourceLabel + `: ${pvc}
+spec:
+  volumeSnapshotClassName: ${SNAPSHOT_CLASS}
+  source:
+    persistentVolumeClaimName: ${pvc}
+SNAPSHOT
+  if ! kubectl wait --for=jsonpath='{.status.readyToUse}'=true "volumesnapshot/${snapshot}" --timeout="${READY_TIMEOUT}"; then
+    echo "${snapshot} is not ready to use, keeping the existing snapshots of ${pvc}"
+    continue
+  fi
+  kubectl get volumesnapshots -l "` + SnapshotSourceLabel + `=${pvc}" --sort-by=.metadata.creationTimestamp \
+    -o jsonpath='{range .items[?(@.status.readyToUse==true)]}{.metadata.name}{"\n"}{end}' \
+    | awk -v keep="${RETENTION}" '{ names[NR] = $0 } END { for (i = 1; i <= NR - keep; i++) print names[i] }' \
+    | xargs -r kubectl delete volumesnapshot
+done
+`
+
+// Archive restore constants
+const (
+	DefaultArchiveImage         = "amazon/aws-cli:2.27.49"
+	ArchiveRestoreContainerName = "restore-archive"
+	ArchiveCompressionNone      = "none"
+	ArchiveCompressionGzip      = "gzip"
+	ArchiveCompressionZstd      = "zstd"
+	// archiveRestoreDir is the directory under the data mount an archive is extracted into
+	// before it is moved into place, so an interrupted restore is retried rather than kept
+	archiveRestoreDir = ".archive-restore"
+	// archiveRestoreMarker is written under the data mount once every extracted entry has been
+	// moved into place, marking the restore as complete
+	archiveRestoreMarker = ".archive-restored"
+)
+
+// archiveRestoreScript streams the archive from S3 into $DATA_DIR unless an earlier restore
+// completed, which is recorded by a marker file written after the extracted data is moved into
+// place. A leftover extraction directory means an earlier restore was interrupted, so the data
+// directory is cleared and the restore starts over; data present without either was not
+// restored from an archive and is kept. Missing tar, gzip or zstd binaries are installed with
+// the image's package manager.
+const archiveRestoreScript = `set -eu
+(set -o pipefail) 2>/dev/null && set -o pipefail
+marker="${DATA_DIR}/` + archiveRestoreMarker + `"
+restore_dir="${DATA_DIR}/` + archiveRestoreDir + `"
+if [ -f "${marker}" ]; then
+  echo "${DATA_DIR} was already restored from an archive, skipping archive restore"
+  exit 0
+fi
+if [ -d "${restore_dir}" ]; then
+  echo "an earlier archive restore into ${DATA_DIR} did not complete, starting over"
+  find "${DATA_DIR}" -mindepth 1 -maxdepth 1 ! -name lost+found -exec rm -rf {} +
+fi
+existing=$(ls -A "${DATA_DIR}" | grep -v -x -F -e lost+found || true)
+if [ -n "${existing}" ]; then
+  echo "${DATA_DIR} holds data that was not restored from an archive, skipping archive restore"
+  exit 0
+fi
+require() {
+  command -v "$1" >/dev/null 2>&1 && return 0
+  if command -v dnf >/dev/null 2>&1; then dnf install -y "$1"; else yum install -y "$1"; fi
+}
+require tar
+case "${ARCHIVE_COMPRESSION}" in
+  ` + ArchiveCompressionGzip + `) require gzip; decompress="gzip -dc" ;;
+  ` + ArchiveCompressionZstd + `) require zstd; decompress="zstd -dc" ;;
+  *) decompress="cat" ;;
+esac
+mkdir -p "${restore_dir}"
+echo "restoring s3://${ARCHIVE_BUCKET}/${ARCHIVE_KEY} into ${DATA_DIR}"
+aws s3 cp "s3://${ARCHIVE_BUCKET}/${ARCHIVE_KEY}" - \
+  | ${decompress} \
+  | tar -x -C "${restore_dir}" --strip-components="${STRIP_COMPONENTS}"
+find "${restore_dir}" -mindepth 1 -maxdepth 1 -exec mv {} "${DATA_DIR}/" \;
+touch "${marker}"
+rmdir "${restore_dir}"
+`
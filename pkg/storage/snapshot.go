@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
+	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
+	batchv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/batch/v1"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	rbacv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/rbac/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// NewVolumeSnapshotClassComponent creates a VolumeSnapshotClass for the EBS CSI driver. The
+// snapshot CRDs and snapshot controller must already be installed in the cluster.
+func NewVolumeSnapshotClassComponent(ctx *pulumi.Context, args *VolumeSnapshotClassArgs, opts ...pulumi.ResourceOption) (*VolumeSnapshotClassComponent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid volume snapshot class args: %w", err)
+	}
+
+	component := &VolumeSnapshotClassComponent{}
+
+	err := ctx.RegisterComponentResource(VolumeSnapshotClassComponentKind, args.Name, component, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register component resource: %w", err)
+	}
+
+	deletionPolicy := args.DeletionPolicy
+	if deletionPolicy == "" {
+		deletionPolicy = DeletionPolicyDelete
+	}
+
+	var annotations pulumi.StringMap
+	if args.IsDefault {
+		annotations = pulumi.StringMap{
+			IsDefaultSnapshotAnnotation: pulumi.String("true"),
+		}
+	}
+
+	snapshotClass, err := crd.NewCustomResource(ctx, args.Name, &crd.CustomResourceArgs{
+		ApiVersion: pulumi.String(VolumeSnapshotAPIVersion),
+		Kind:       pulumi.String(VolumeSnapshotClassKind),
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:        pulumi.String(args.Name),
+			Labels:      utils.CreateResourceLabels(args.Name, args.Name, args.Name, nil),
+			Annotations: annotations,
+		},
+		OtherFields: map[string]interface{}{
+			"driver":         EbsCsiProvisioner,
+			"deletionPolicy": deletionPolicy,
+		},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create volume snapshot class: %w", err)
+	}
+	component.VolumeSnapshotClass = snapshotClass
+	component.Name = snapshotClass.Metadata.Name().Elem()
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"name": component.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+// NewVolumeSnapshotScheduleComponent creates a CronJob that snapshots each of the given claims
+// on a schedule and deletes all but the newest snapshots of each claim. The job runs under its
+// own service account, which may only manage VolumeSnapshots in the claims' namespace.
+func NewVolumeSnapshotScheduleComponent(ctx *pulumi.Context, args *VolumeSnapshotScheduleArgs, opts ...pulumi.ResourceOption) (*VolumeSnapshotScheduleComponent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid volume snapshot schedule args: %w", err)
+	}
+
+	internalArgs := args.toInternal()
+	component := &VolumeSnapshotScheduleComponent{}
+
+	err := ctx.RegisterComponentResource(VolumeSnapshotScheduleComponentKind, args.Name, component, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register component resource: %w", err)
+	}
+
+	serviceAccountName := fmt.Sprintf("%s%s", args.Name, SnapshotServiceAccountSuffix)
+	serviceAccount, err := corev1.NewServiceAccount(ctx, serviceAccountName, &corev1.ServiceAccountArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(serviceAccountName),
+			Namespace: internalArgs.Namespace,
+			Labels:    utils.CreateResourceLabels(args.Name, serviceAccountName, args.Name, nil),
+		},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot service account: %w", err)
+	}
+	component.ServiceAccount = serviceAccount
+
+	roleName := fmt.Sprintf("%s%s", args.Name, SnapshotRoleSuffix)
+	role, err := rbacv1.NewRole(ctx, roleName, &rbacv1.RoleArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(roleName),
+			Namespace: internalArgs.Namespace,
+			Labels:    utils.CreateResourceLabels(args.Name, roleName, args.Name, nil),
+		},
+		Rules: rbacv1.PolicyRuleArray{
+			rbacv1.PolicyRuleArgs{
+				ApiGroups: pulumi.StringArray{pulumi.String(utils.VolumeSnapshotAPIGroup)},
+				Resources: pulumi.StringArray{pulumi.String("volumesnapshots")},
+				Verbs: pulumi.StringArray{
+					pulumi.String("get"),
+					pulumi.String("list"),
+					pulumi.String("watch"),
+					pulumi.String("create"),
+					pulumi.String("patch"),
+					pulumi.String("delete"),
+				},
+			},
+			rbacv1.PolicyRuleArgs{
+				ApiGroups: pulumi.StringArray{pulumi.String("")},
+				Resources: pulumi.StringArray{pulumi.String("persistentvolumeclaims")},
+				Verbs:     pulumi.StringArray{pulumi.String("get")},
+			},
+		},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot role: %w", err)
+	}
+	component.Role = role
+
+	roleBindingName := fmt.Sprintf("%s%s", args.Name, SnapshotRoleBindingSuffix)
+	roleBinding, err := rbacv1.NewRoleBinding(ctx, roleBindingName, &rbacv1.RoleBindingArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(roleBindingName),
+			Namespace: internalArgs.Namespace,
+			Labels:    utils.CreateResourceLabels(args.Name, roleBindingName, args.Name, nil),
+		},
+		RoleRef: rbacv1.RoleRefArgs{
+			ApiGroup: pulumi.String("rbac.authorization.k8s.io"),
+			Kind:     pulumi.String("Role"),
+			Name:     role.Metadata.Name().Elem(),
+		},
+		Subjects: rbacv1.SubjectArray{
+			rbacv1.SubjectArgs{
+				Kind:      pulumi.String("ServiceAccount"),
+				Name:      serviceAccount.Metadata.Name().Elem(),
+				Namespace: internalArgs.Namespace,
+			},
+		},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot role binding: %w", err)
+	}
+	component.RoleBinding = roleBinding
+
+	cronJobName := fmt.Sprintf("%s%s", args.Name, SnapshotCronJobSuffix)
+	labels := utils.CreateResourceLabels(args.Name, cronJobName, args.Name, nil)
+	cronJob, err := batchv1.NewCronJob(ctx, cronJobName, &batchv1.CronJobArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(cronJobName),
+			Namespace: internalArgs.Namespace,
+			Labels:    labels,
+		},
+		Spec: &batchv1.CronJobSpecArgs{
+			Schedule:                   internalArgs.Schedule,
+			ConcurrencyPolicy:          pulumi.String(ConcurrencyPolicyForbid),
+			SuccessfulJobsHistoryLimit: pulumi.Int(SnapshotJobHistoryLimit),
+			FailedJobsHistoryLimit:     pulumi.Int(SnapshotJobHistoryLimit),
+			JobTemplate: &batchv1.JobTemplateSpecArgs{
+				Spec: &batchv1.JobSpecArgs{
+					BackoffLimit: pulumi.Int(SnapshotJobBackoffLimit),
+					Template: &corev1.PodTemplateSpecArgs{
+						Metadata: &metav1.ObjectMetaArgs{
+							Labels: labels,
+						},
+						Spec: &corev1.PodSpecArgs{
+							ServiceAccountName: serviceAccount.Metadata.Name(),
+							RestartPolicy:      pulumi.String(RestartPolicyOnFailure),
+							Containers: corev1.ContainerArray{
+								corev1.ContainerArgs{
+									Name:  pulumi.String("snapshot"),
+									Image: internalArgs.Image,
+									// The script receives the claim names as positional arguments
+									Command: pulumi.StringArray{
+										pulumi.String("/bin/sh"),
+										pulumi.String("-c"),
+										pulumi.String(snapshotScript),
+										pulumi.String("snapshot"),
+									},
+									Args: internalArgs.PvcNames,
+									Env: corev1.EnvVarArray{
+										corev1.EnvVarArgs{
+											Name:  pulumi.String("SNAPSHOT_CLASS"),
+											Value: internalArgs.SnapshotClass,
+										},
+										corev1.EnvVarArgs{
+											Name:  pulumi.String("RETENTION"),
+											Value: internalArgs.Retention,
+										},
+										corev1.EnvVarArgs{
+											Name:  pulumi.String("READY_TIMEOUT"),
+											Value: pulumi.String(SnapshotReadyTimeout),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, pulumi.Parent(component), pulumi.DependsOn([]pulumi.Resource{roleBinding}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot cron job: %w", err)
+	}
+	component.CronJob = cronJob
+
+	return component, nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeSnapshotScheduleArgsToInternal(t *testing.T) {
+	args := VolumeSnapshotScheduleArgs{
+		Name:      "signet-node",
+		Namespace: "signet",
+		PvcNames:  pulumi.StringArray{pulumi.String("signet-node-data")},
+		Snapshots: SnapshotArgs{SnapshotClass: &VolumeSnapshotClassComponent{}},
+	}
+
+	internal := args.toInternal()
+	assert.Equal(t, pulumi.String(DefaultSnapshotSchedule), internal.Schedule)
+	assert.Equal(t, pulumi.String("7"), internal.Retention)
+	assert.Equal(t, pulumi.String(DefaultSnapshotImage), internal.Image)
+
+	args.Snapshots.Schedule = "0 */6 * * *"
+	args.Snapshots.Retention = 28
+	args.Snapshots.Image = "example/kubectl:latest"
+	internal = args.toInternal()
+	assert.Equal(t, pulumi.String("0 */6 * * *"), internal.Schedule)
+	assert.Equal(t, pulumi.String("28"), internal.Retention)
+	assert.Equal(t, pulumi.String("example/kubectl:latest"), internal.Image)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/init4tech/signet-infra-components/pkg/utils"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	storagev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/storage/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// NewStorageClassComponent creates a gp3 StorageClass backed by the EBS CSI driver. Volumes
+// are always encrypted and can be expanded in place, and binding waits for the first
+// consuming pod so volumes are created in the availability zone the pod is scheduled in.
+func NewStorageClassComponent(ctx *pulumi.Context, args *StorageClassArgs, opts ...pulumi.ResourceOption) (*StorageClassComponent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage class args: %w", err)
+	}
+
+	internalArgs := args.toInternal()
+	component := &StorageClassComponent{}
+
+	err := ctx.RegisterComponentResource(StorageClassComponentKind, args.Name, component, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register component resource: %w", err)
+	}
+
+	storageClass, err := storagev1.NewStorageClass(ctx, args.Name, &storagev1.StorageClassArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:        internalArgs.Name,
+			Labels:      utils.CreateResourceLabels(args.Name, args.Name, args.Name, nil),
+			Annotations: internalArgs.Annotations,
+		},
+		Provisioner:          pulumi.String(EbsCsiProvisioner),
+		Parameters:           internalArgs.Parameters,
+		ReclaimPolicy:        internalArgs.ReclaimPolicy,
+		AllowVolumeExpansion: pulumi.Bool(true),
+		VolumeBindingMode:    pulumi.String(VolumeBindingWaitForFirstConsumer),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage class: %w", err)
+	}
+	component.StorageClass = storageClass
+	component.Name = storageClass.Metadata.Name().Elem()
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"name": component.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+// parameters returns the EBS CSI driver parameters for the StorageClass
+func (args StorageClassArgs) parameters() map[string]string {
+	iops := args.Iops
+	if iops == 0 {
+		iops = DefaultGp3Iops
+	}
+	throughput := args.Throughput
+	if throughput == 0 {
+		throughput = DefaultGp3Throughput
+	}
+	fsType := args.FsType
+	if fsType == "" {
+		fsType = DefaultFsType
+	}
+
+	parameters := map[string]string{
+		ParameterType:       VolumeTypeGp3,
+		ParameterIops:       strconv.Itoa(iops),
+		ParameterThroughput: strconv.Itoa(throughput),
+		ParameterEncrypted:  "true",
+		ParameterFsType:     fsType,
+	}
+	if args.KmsKeyId != "" {
+		parameters[ParameterKmsKeyId] = args.KmsKeyId
+	}
+	return parameters
+}
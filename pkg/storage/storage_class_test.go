@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClassParameters(t *testing.T) {
+	args := StorageClassArgs{Name: "gp3"}
+	assert.Equal(t, map[string]string{
+		ParameterType:       VolumeTypeGp3,
+		ParameterIops:       "3000",
+		ParameterThroughput: "125",
+		ParameterEncrypted:  "true",
+		ParameterFsType:     DefaultFsType,
+	}, args.parameters())
+
+	args = StorageClassArgs{
+		Name:       "gp3-fast",
+		Iops:       8000,
+		Throughput: 500,
+		KmsKeyId:   "arn:aws:kms:us-east-1:123456789012:key/abcd",
+		FsType:     "xfs",
+	}
+	parameters := args.parameters()
+	assert.Equal(t, "8000", parameters[ParameterIops])
+	assert.Equal(t, "500", parameters[ParameterThroughput])
+	assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/abcd", parameters[ParameterKmsKeyId])
+	assert.Equal(t, "xfs", parameters[ParameterFsType])
+}
+
+func TestStorageClassArgsToInternal(t *testing.T) {
+	internal := StorageClassArgs{Name: "gp3"}.toInternal()
+	assert.Equal(t, pulumi.String(ReclaimPolicyDelete), internal.ReclaimPolicy)
+	assert.Nil(t, internal.Annotations)
+
+	internal = StorageClassArgs{Name: "gp3", ReclaimPolicy: ReclaimPolicyRetain, IsDefault: true}.toInternal()
+	assert.Equal(t, pulumi.String(ReclaimPolicyRetain), internal.ReclaimPolicy)
+	assert.Equal(t, pulumi.String("true"), internal.Annotations[IsDefaultClassAnnotation])
+}
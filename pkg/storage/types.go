@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"strconv"
+
+	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
+	batchv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/batch/v1"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	rbacv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/rbac/v1"
+	storagev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/storage/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Public-facing structs with base Go types
+
+// StorageClassArgs configures an encrypted gp3 StorageClass provisioned by the EBS CSI driver
+type StorageClassArgs struct {
+	// Name is the name of the StorageClass
+	Name string
+	// Iops is the provisioned IOPS per volume, defaults to 3000
+	Iops int
+	// Throughput is the provisioned throughput per volume in MiB/s, defaults to 125
+	Throughput int
+	// KmsKeyId optionally encrypts volumes with a customer managed KMS key instead of the EBS default key
+	KmsKeyId string
+	// ReclaimPolicy is Delete or Retain, defaults to Delete
+	ReclaimPolicy string
+	// FsType is the filesystem created on new volumes, defaults to ext4
+	FsType string
+	// IsDefault marks the StorageClass as the cluster default
+	IsDefault bool
+}
+
+// Internal structs with Pulumi types
+
+type storageClassArgsInternal struct {
+	Name          pulumi.StringInput
+	Parameters    pulumi.StringMap
+	ReclaimPolicy pulumi.StringInput
+	Annotations   pulumi.StringMap
+}
+
+// toInternal converts public args to internal args for use with Pulumi
+func (args StorageClassArgs) toInternal() storageClassArgsInternal {
+	internal := storageClassArgsInternal{
+		Name:          pulumi.String(args.Name),
+		Parameters:    pulumi.ToStringMap(args.parameters()),
+		ReclaimPolicy: pulumi.String(ReclaimPolicyDelete),
+	}
+	if args.ReclaimPolicy != "" {
+		internal.ReclaimPolicy = pulumi.String(args.ReclaimPolicy)
+	}
+	if args.IsDefault {
+		internal.Annotations = pulumi.StringMap{
+			IsDefaultClassAnnotation: pulumi.String("true"),
+		}
+	}
+	return internal
+}
+
+// StorageClassComponent is a gp3 StorageClass. Components that create persistent volume
+// claims take it in place of a storage class name.
+type StorageClassComponent struct {
+	pulumi.ResourceState
+
+	// StorageClass is the Kubernetes StorageClass
+	StorageClass *storagev1.StorageClass
+	// Name is the name of the StorageClass, for use as a claim's storageClassName
+	Name pulumi.StringOutput
+}
+
+// VolumeSnapshotClassArgs configures a VolumeSnapshotClass for the EBS CSI driver
+type VolumeSnapshotClassArgs struct {
+	// Name is the name of the VolumeSnapshotClass
+	Name string
+	// DeletionPolicy is Delete or Retain, defaults to Delete so rotated snapshots are removed from EBS
+	DeletionPolicy string
+	// IsDefault marks the VolumeSnapshotClass as the cluster default
+	IsDefault bool
+}
+
+// VolumeSnapshotClassComponent is a VolumeSnapshotClass for EBS snapshots
+type VolumeSnapshotClassComponent struct {
+	pulumi.ResourceState
+
+	// VolumeSnapshotClass is the VolumeSnapshotClass custom resource
+	VolumeSnapshotClass *crd.CustomResource
+	// Name is the name of the VolumeSnapshotClass
+	Name pulumi.StringOutput
+}
+
+// SnapshotArgs opts a component into scheduled snapshots of its data volumes
+type SnapshotArgs struct {
+	// SnapshotClass is the VolumeSnapshotClass snapshots are taken with
+	SnapshotClass *VolumeSnapshotClassComponent
+	// Schedule is the cron schedule snapshots are taken on, defaults to daily at midnight UTC
+	Schedule string
+	// Retention is the number of snapshots kept per volume, defaults to 7
+	Retention int
+	// Image is the image the snapshot job runs, it must provide sh and kubectl
+	Image string
+}
+
+// VolumeSnapshotScheduleArgs configures a CronJob that takes rotating snapshots of claims
+type VolumeSnapshotScheduleArgs struct {
+	// Name is the base name for all resources
+	Name string
+	// Namespace is the namespace of the claims
+	Namespace string
+	// PvcNames are the names of the claims to snapshot
+	PvcNames pulumi.StringArrayInput
+	// Snapshots configures the schedule and retention
+	Snapshots SnapshotArgs
+}
+
+type volumeSnapshotScheduleArgsInternal struct {
+	Namespace     pulumi.StringInput
+	PvcNames      pulumi.StringArrayInput
+	SnapshotClass pulumi.StringInput
+	Schedule      pulumi.StringInput
+	Retention     pulumi.StringInput
+	Image         pulumi.StringInput
+}
+
+// toInternal converts public args to internal args for use with Pulumi
+func (args VolumeSnapshotScheduleArgs) toInternal() volumeSnapshotScheduleArgsInternal {
+	internal := volumeSnapshotScheduleArgsInternal{
+		Namespace:     pulumi.String(args.Namespace),
+		PvcNames:      args.PvcNames,
+		SnapshotClass: args.Snapshots.SnapshotClass.Name,
+		Schedule:      pulumi.String(DefaultSnapshotSchedule),
+		Retention:     pulumi.String(strconv.Itoa(DefaultSnapshotRetention)),
+		Image:         pulumi.String(DefaultSnapshotImage),
+	}
+	if args.Snapshots.Schedule != "" {
+		internal.Schedule = pulumi.String(args.Snapshots.Schedule)
+	}
+	if args.Snapshots.Retention != 0 {
+		internal.Retention = pulumi.String(strconv.Itoa(args.Snapshots.Retention))
+	}
+	if args.Snapshots.Image != "" {
+		internal.Image = pulumi.String(args.Snapshots.Image)
+	}
+	return internal
+}
+
+// VolumeSnapshotScheduleComponent is a CronJob, with the RBAC it needs, that snapshots claims
+type VolumeSnapshotScheduleComponent struct {
+	pulumi.ResourceState
+
+	// ServiceAccount is the account the snapshot job runs as
+	ServiceAccount *corev1.ServiceAccount
+	// Role allows managing VolumeSnapshots in the claims' namespace
+	Role *rbacv1.Role
+	// RoleBinding binds Role to ServiceAccount
+	RoleBinding *rbacv1.RoleBinding
+	// CronJob takes the snapshots
+	CronJob *batchv1.CronJob
+}
+
+// VolumeSnapshotSource restores a new volume from an existing VolumeSnapshot
+type VolumeSnapshotSource struct {
+	// Name is the name of the VolumeSnapshot, which must be in the claim's namespace
+	Name string
+	// RestoreSize is the snapshot's status.restoreSize, e.g. "150Gi". The claim must be at least this large.
+	RestoreSize string
+}
+
+// ArchiveSource bootstraps an empty data volume from a tar archive in S3. The archive is
+// downloaded by an init container that authenticates with the pod's IAM role, so the bucket
+// may live in another region or account as long as the role is allowed to read the object.
+type ArchiveSource struct {
+	// Bucket is the name of the S3 bucket holding the archive
+	Bucket string
+	// Key is the object key of the archive, ending in .tar, .tar.gz, .tgz, .tar.zst or .tzst
+	Key string
+	// Region is the region of the bucket
+	Region string
+	// StripComponents removes leading path components from the archive's entries
+	StripComponents int
+	// Image is the image the restore runs in, it must provide sh and the aws CLI
+	Image string
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Validate validates the StorageClassArgs struct
+func (args *StorageClassArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	iops := args.Iops
+	if iops == 0 {
+		iops = DefaultGp3Iops
+	}
+	if iops < DefaultGp3Iops || iops > MaxGp3Iops {
+		return fmt.Errorf("iops must be between %d and %d", DefaultGp3Iops, MaxGp3Iops)
+	}
+
+	throughput := args.Throughput
+	if throughput == 0 {
+		throughput = DefaultGp3Throughput
+	}
+	if throughput < DefaultGp3Throughput || throughput > MaxGp3Throughput {
+		return fmt.Errorf("throughput must be between %d and %d", DefaultGp3Throughput, MaxGp3Throughput)
+	}
+	if throughput*Gp3IopsPerThroughput > iops {
+		return fmt.Errorf("throughput of %d requires at least %d iops", throughput, throughput*Gp3IopsPerThroughput)
+	}
+
+	if args.ReclaimPolicy != "" && args.ReclaimPolicy != ReclaimPolicyDelete && args.ReclaimPolicy != ReclaimPolicyRetain {
+		return fmt.Errorf("reclaimPolicy must be %s or %s", ReclaimPolicyDelete, ReclaimPolicyRetain)
+	}
+
+	return nil
+}
+
+// Validate validates the VolumeSnapshotClassArgs struct
+func (args *VolumeSnapshotClassArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if args.DeletionPolicy != "" && args.DeletionPolicy != DeletionPolicyDelete && args.DeletionPolicy != DeletionPolicyRetain {
+		return fmt.Errorf("deletionPolicy must be %s or %s", DeletionPolicyDelete, DeletionPolicyRetain)
+	}
+	return nil
+}
+
+// Validate validates the SnapshotArgs struct
+func (args *SnapshotArgs) Validate() error {
+	if args.SnapshotClass == nil {
+		return fmt.Errorf("snapshotClass is required")
+	}
+	// Accept five field expressions and macros such as @daily
+	if args.Schedule != "" && !strings.HasPrefix(args.Schedule, "@") && len(strings.Fields(args.Schedule)) != 5 {
+		return fmt.Errorf("schedule must be a five field cron expression")
+	}
+	if args.Retention < 0 || args.Retention > MaxSnapshotRetention {
+		return fmt.Errorf("retention must be between 1 and %d", MaxSnapshotRetention)
+	}
+	return nil
+}
+
+// Validate validates the VolumeSnapshotScheduleArgs struct
+func (args *VolumeSnapshotScheduleArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if args.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if args.PvcNames == nil {
+		return fmt.Errorf("pvcNames is required")
+	}
+	return args.Snapshots.Validate()
+}
+
+// Validate validates the VolumeSnapshotSource struct
+func (source *VolumeSnapshotSource) Validate() error {
+	if source.Name == "" {
+		return fmt.Errorf("snapshot name is required")
+	}
+	if source.RestoreSize == "" {
+		return fmt.Errorf("snapshot restore size is required")
+	}
+	if _, err := parseQuantity(source.RestoreSize); err != nil {
+		return fmt.Errorf("invalid snapshot restore size: %w", err)
+	}
+	return nil
+}
+
+// ValidateSize checks that a claim of the given size can hold the snapshot
+func (source *VolumeSnapshotSource) ValidateSize(size string) error {
+	if err := source.Validate(); err != nil {
+		return err
+	}
+	sizeBytes, err := parseQuantity(size)
+	if err != nil {
+		return fmt.Errorf("invalid size: %w", err)
+	}
+	restoreBytes, _ := parseQuantity(source.RestoreSize)
+	if sizeBytes.Cmp(restoreBytes) < 0 {
+		return fmt.Errorf("size %s is smaller than the restore size %s of snapshot %s", size, source.RestoreSize, source.Name)
+	}
+	return nil
+}
+
+// Validate validates the ArchiveSource struct
+func (source *ArchiveSource) Validate() error {
+	if source.Bucket == "" {
+		return fmt.Errorf("archive bucket is required")
+	}
+	if strings.Contains(source.Bucket, "/") {
+		return fmt.Errorf("archive bucket must be a bucket name, not a path")
+	}
+	if source.Key == "" {
+		return fmt.Errorf("archive key is required")
+	}
+	if _, ok := archiveCompression(source.Key); !ok {
+		return fmt.Errorf("archive key %s must end in one of %s", source.Key, archiveExtensions())
+	}
+	if source.Region == "" {
+		return fmt.Errorf("archive region is required")
+	}
+	if source.StripComponents < 0 {
+		return fmt.Errorf("archive strip components must not be negative")
+	}
+	return nil
+}
+
+// quantitySuffixes maps Kubernetes quantity suffixes to their multipliers
+var quantitySuffixes = map[string]int64{
+	"":   1,
+	"k":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+	"T":  1e12,
+	"P":  1e15,
+	"E":  1e18,
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+	"Pi": 1 << 50,
+	"Ei": 1 << 60,
+}
+
+// parseQuantity parses a Kubernetes storage quantity such as "150Gi" or "1.5T"
+func parseQuantity(quantity string) (*big.Rat, error) {
+	number := strings.TrimRight(quantity, "kKMGTPEi")
+	multiplier, ok := quantitySuffixes[quantity[len(number):]]
+	if !ok {
+		return nil, fmt.Errorf("unknown suffix in quantity %q", quantity)
+	}
+	value, ok := new(big.Rat).SetString(number)
+	if !ok || number == "" || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid quantity %q", quantity)
+	}
+	return value.Mul(value, new(big.Rat).SetInt64(multiplier)), nil
+}
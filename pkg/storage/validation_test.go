@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClassArgsValidate(t *testing.T) {
+	validArgs := StorageClassArgs{
+		Name: "gp3",
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(args *StorageClassArgs)
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			modify: func(args *StorageClassArgs) {},
+		},
+		{
+			name: "provisioned performance",
+			modify: func(args *StorageClassArgs) {
+				args.Iops = 16000
+				args.Throughput = 1000
+				args.ReclaimPolicy = ReclaimPolicyRetain
+			},
+		},
+		{
+			name:    "missing name",
+			modify:  func(args *StorageClassArgs) { args.Name = "" },
+			wantErr: "name is required",
+		},
+		{
+			name:    "iops too low",
+			modify:  func(args *StorageClassArgs) { args.Iops = 2000 },
+			wantErr: "iops must be between 3000 and 16000",
+		},
+		{
+			name:    "iops too high",
+			modify:  func(args *StorageClassArgs) { args.Iops = 20000 },
+			wantErr: "iops must be between 3000 and 16000",
+		},
+		{
+			name:    "throughput too high",
+			modify:  func(args *StorageClassArgs) { args.Throughput = 2000 },
+			wantErr: "throughput must be between 125 and 1000",
+		},
+		{
+			name:    "throughput exceeds iops ratio",
+			modify:  func(args *StorageClassArgs) { args.Throughput = 1000 },
+			wantErr: "throughput of 1000 requires at least 4000 iops",
+		},
+		{
+			name:    "invalid reclaim policy",
+			modify:  func(args *StorageClassArgs) { args.ReclaimPolicy = "Recycle" },
+			wantErr: "reclaimPolicy must be Delete or Retain",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := validArgs
+			tc.modify(&args)
+			err := args.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestVolumeSnapshotClassArgsValidate(t *testing.T) {
+	args := VolumeSnapshotClassArgs{Name: "ebs-snapshots"}
+	assert.NoError(t, args.Validate())
+
+	args.DeletionPolicy = DeletionPolicyRetain
+	assert.NoError(t, args.Validate())
+
+	args.DeletionPolicy = "Keep"
+	assert.EqualError(t, args.Validate(), "deletionPolicy must be Delete or Retain")
+
+	args = VolumeSnapshotClassArgs{}
+	assert.EqualError(t, args.Validate(), "name is required")
+}
+
+func TestVolumeSnapshotScheduleArgsValidate(t *testing.T) {
+	validArgs := VolumeSnapshotScheduleArgs{
+		Name:      "signet-node",
+		Namespace: "signet",
+		PvcNames:  pulumi.StringArray{pulumi.String("signet-node-data")},
+		Snapshots: SnapshotArgs{
+			SnapshotClass: &VolumeSnapshotClassComponent{},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(args *VolumeSnapshotScheduleArgs)
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			modify: func(args *VolumeSnapshotScheduleArgs) {},
+		},
+		{
+			name: "custom schedule",
+			modify: func(args *VolumeSnapshotScheduleArgs) {
+				args.Snapshots.Schedule = "30 */6 * * *"
+				args.Snapshots.Retention = 28
+			},
+		},
+		{
+			name:   "macro schedule",
+			modify: func(args *VolumeSnapshotScheduleArgs) { args.Snapshots.Schedule = "@weekly" },
+		},
+		{
+			name:    "missing name",
+			modify:  func(args *VolumeSnapshotScheduleArgs) { args.Name = "" },
+			wantErr: "name is required",
+		},
+		{
+			name:    "missing namespace",
+			modify:  func(args *VolumeSnapshotScheduleArgs) { args.Namespace = "" },
+			wantErr: "namespace is required",
+		},
+		{
+			name:    "missing pvc names",
+			modify:  func(args *VolumeSnapshotScheduleArgs) { args.PvcNames = nil },
+			wantErr: "pvcNames is required",
+		},
+		{
+			name:    "missing snapshot class",
+			modify:  func(args *VolumeSnapshotScheduleArgs) { args.Snapshots.SnapshotClass = nil },
+			wantErr: "snapshotClass is required",
+		},
+		{
+			name:    "invalid schedule",
+			modify:  func(args *VolumeSnapshotScheduleArgs) { args.Snapshots.Schedule = "daily" },
+			wantErr: "schedule must be a five field cron expression",
+		},
+		{
+			name:    "negative retention",
+			modify:  func(args *VolumeSnapshotScheduleArgs) { args.Snapshots.Retention = -1 },
+			wantErr: "retention must be between 1 and 100",
+		},
+		{
+			name:    "retention too high",
+			modify:  func(args *VolumeSnapshotScheduleArgs) { args.Snapshots.Retention = 101 },
+			wantErr: "retention must be between 1 and 100",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := validArgs
+			tc.modify(&args)
+			err := args.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestVolumeSnapshotSourceValidateSize(t *testing.T) {
+	source := VolumeSnapshotSource{Name: "signet-node-data-20250101000000", RestoreSize: "150Gi"}
+
+	assert.NoError(t, source.ValidateSize("150Gi"))
+	assert.NoError(t, source.ValidateSize("200Gi"))
+	assert.NoError(t, source.ValidateSize("1Ti"))
+	assert.EqualError(t, source.ValidateSize("100Gi"), "size 100Gi is smaller than the restore size 150Gi of snapshot signet-node-data-20250101000000")
+	// 150G is less than 150Gi
+	assert.Error(t, source.ValidateSize("150G"))
+	assert.EqualError(t, source.ValidateSize("lots"), "invalid size: invalid quantity \"lots\"")
+
+	assert.EqualError(t, (&VolumeSnapshotSource{RestoreSize: "150Gi"}).ValidateSize("150Gi"), "snapshot name is required")
+	assert.EqualError(t, (&VolumeSnapshotSource{Name: "snap"}).ValidateSize("150Gi"), "snapshot restore size is required")
+	assert.EqualError(t, (&VolumeSnapshotSource{Name: "snap", RestoreSize: "Gi"}).ValidateSize("150Gi"), "invalid snapshot restore size: invalid quantity \"Gi\"")
+}
+
+func TestArchiveSourceValidate(t *testing.T) {
+	validSource := ArchiveSource{
+		Bucket: "signet-archives",
+		Key:    "mainnet/signet-node-data.tar.zst",
+		Region: "us-east-1",
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(source *ArchiveSource)
+		wantErr string
+	}{
+		{
+			name:   "zstd archive",
+			modify: func(source *ArchiveSource) {},
+		},
+		{
+			name:   "gzip archive",
+			modify: func(source *ArchiveSource) { source.Key = "signet-node-data.tgz" },
+		},
+		{
+			name: "uncompressed archive",
+			modify: func(source *ArchiveSource) {
+				source.Key = "signet-node-data.tar"
+				source.StripComponents = 1
+			},
+		},
+		{
+			name:    "missing bucket",
+			modify:  func(source *ArchiveSource) { source.Bucket = "" },
+			wantErr: "archive bucket is required",
+		},
+		{
+			name:    "bucket uri",
+			modify:  func(source *ArchiveSource) { source.Bucket = "s3://signet-archives" },
+			wantErr: "archive bucket must be a bucket name, not a path",
+		},
+		{
+			name:    "missing key",
+			modify:  func(source *ArchiveSource) { source.Key = "" },
+			wantErr: "archive key is required",
+		},
+		{
+			name:    "unsupported extension",
+			modify:  func(source *ArchiveSource) { source.Key = "signet-node-data.zip" },
+			wantErr: "archive key signet-node-data.zip must end in one of .tar, .tar.gz, .tar.zst, .tgz, .tzst",
+		},
+		{
+			name:    "missing region",
+			modify:  func(source *ArchiveSource) { source.Region = "" },
+			wantErr: "archive region is required",
+		},
+		{
+			name:    "negative strip components",
+			modify:  func(source *ArchiveSource) { source.StripComponents = -1 },
+			wantErr: "archive strip components must not be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := validSource
+			tc.modify(&source)
+			err := source.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	testCases := []struct {
+		quantity string
+		want     int64
+	}{
+		{"1024", 1024},
+		{"1Ki", 1024},
+		{"1k", 1000},
+		{"150Gi", 150 << 30},
+		{"1.5Ti", 3 << 39},
+		{"2T", 2e12},
+	}
+
+	for _, tc := range testCases {
+		value, err := parseQuantity(tc.quantity)
+		assert.NoError(t, err, tc.quantity)
+		assert.Equal(t, big.NewRat(tc.want, 1), value, tc.quantity)
+	}
+
+	for _, quantity := range []string{"", "Gi", "-1Gi", "10Xi", "ten"} {
+		_, err := parseQuantity(quantity)
+		assert.Error(t, err, quantity)
+	}
+}
@@ -11,6 +11,12 @@ const (
 	VirtualServiceName    = "tx-cache-virtual-service"
 	JwtPolicyName         = "tx-cache-jwt-policy"
 	AuthPolicyName        = "tx-cache-authorization-policy"
+	ExternalSecretSuffix  = "-external-secret"
+)
+
+// Env names of the sensitive TxCacheEnv fields, which an ExternalSecretRef has to sync
+const (
+	AwsSecretAccessKeyEnvName = "AWS_SECRET_ACCESS_KEY"
 )
 
 // Kubernetes API versions and kinds
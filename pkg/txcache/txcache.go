@@ -3,6 +3,7 @@ package txcache
 import (
 	"fmt"
 
+	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
@@ -41,6 +42,24 @@ func NewTxCacheComponent(ctx *pulumi.Context, args TxCacheComponentArgs, opts ..
 		return nil, fmt.Errorf("failed to create service account: %w", err)
 	}
 
+	// Bind an IAM role to the service account when using EKS Pod Identity
+	if internalArgs.PodIdentity != nil {
+		podIdentity, err := aws.CreatePodIdentityResources(
+			ctx,
+			args.Name,
+			args.Name,
+			*internalArgs.PodIdentity,
+			internalArgs.Namespace,
+			serviceAccount.Metadata.Name().Elem(),
+			nil,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pod identity resources: %w", err)
+		}
+		component.PodIdentity = podIdentity
+	}
+
 	// Create ConfigMap for environment variables
 	configMapName := fmt.Sprintf("%s-env", args.Name)
 	configMap, err := utils.CreateConfigMap(
@@ -54,6 +73,43 @@ func NewTxCacheComponent(ctx *pulumi.Context, args TxCacheComponentArgs, opts ..
 		return nil, fmt.Errorf("failed to create environment ConfigMap: %w", err)
 	}
 
+	// Create Secret for sensitive environment variables, either synced from
+	// AWS Secrets Manager or populated from the env
+	var secret pulumi.Resource
+	var secretName pulumi.StringPtrInput
+	if internalArgs.ExternalSecretRef != nil {
+		externalSecretName := fmt.Sprintf("%s%s", args.Name, ExternalSecretSuffix)
+		externalSecret, err := utils.CreateExternalSecret(
+			ctx,
+			externalSecretName,
+			internalArgs.Namespace,
+			appLabels,
+			*internalArgs.ExternalSecretRef,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create external secret: %w", err)
+		}
+		component.ExternalSecret = externalSecret
+		secret = externalSecret
+		secretName = pulumi.String(externalSecretName)
+	} else {
+		envSecret, err := utils.CreateSecret(
+			ctx,
+			fmt.Sprintf("%s-secret-env", args.Name),
+			internalArgs.Namespace,
+			appLabels,
+			internalArgs.Env,
+			component,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create environment Secret: %w", err)
+		}
+		component.Secret = envSecret
+		secret = envSecret
+		secretName = envSecret.Metadata.Name()
+	}
+
 	// create the deployment for the quincey-server container to use the KMS key
 	txCacheDeployment, err := appsv1.NewDeployment(ctx, DeploymentName, &appsv1.DeploymentArgs{
 		Metadata: &metav1.ObjectMetaArgs{
@@ -81,6 +137,11 @@ func NewTxCacheComponent(ctx *pulumi.Context, args TxCacheComponentArgs, opts ..
 										Name: configMap.Metadata.Name(),
 									},
 								},
+								&corev1.EnvFromSourceArgs{
+									SecretRef: &corev1.SecretEnvSourceArgs{
+										Name: secretName,
+									},
+								},
 							},
 							Ports: corev1.ContainerPortArray{
 								&corev1.ContainerPortArgs{
@@ -92,7 +153,7 @@ func NewTxCacheComponent(ctx *pulumi.Context, args TxCacheComponentArgs, opts ..
 				},
 			},
 		},
-	}, pulumi.DependsOn([]pulumi.Resource{configMap}), pulumi.Parent(component))
+	}, pulumi.DependsOn([]pulumi.Resource{configMap, secret}), pulumi.Parent(component))
 	if err != nil {
 		return nil, err
 	}
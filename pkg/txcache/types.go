@@ -1,6 +1,7 @@
 package txcache
 
 import (
+	"github.com/init4tech/signet-infra-components/pkg/aws"
 	"github.com/init4tech/signet-infra-components/pkg/utils"
 	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
 	appsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
@@ -11,6 +12,9 @@ import (
 type TxCacheComponent struct {
 	ServiceAccount        *corev1.ServiceAccount
 	ConfigMap             *corev1.ConfigMap
+	Secret                *corev1.Secret
+	ExternalSecret        *crd.CustomResource
+	PodIdentity           *aws.PodIdentityResources
 	Deployment            *appsv1.Deployment
 	Service               *corev1.Service
 	VirtualService        *crd.CustomResource
@@ -20,29 +24,33 @@ type TxCacheComponent struct {
 }
 
 type TxCacheComponentArgs struct {
-	Namespace    string     `pulumi:"txCacheNamespace" validate:"required"`
-	Name         string     `pulumi:"txCacheName" validate:"required"`
-	Image        string     `pulumi:"txCacheImage" validate:"required"`
-	Port         int        `pulumi:"txCachePort" validate:"required"`
-	OauthIssuer  string     `pulumi:"txCacheOauthIssuer" validate:"required"`
-	OauthJwksUri string     `pulumi:"txCacheOauthJwksUri" validate:"required"`
-	Env          TxCacheEnv `pulumi:"txCacheEnv" validate:"required"`
+	Namespace         string                   `pulumi:"txCacheNamespace" validate:"required"`
+	Name              string                   `pulumi:"txCacheName" validate:"required"`
+	Image             string                   `pulumi:"txCacheImage" validate:"required"`
+	Port              int                      `pulumi:"txCachePort" validate:"required"`
+	OauthIssuer       string                   `pulumi:"txCacheOauthIssuer" validate:"required"`
+	OauthJwksUri      string                   `pulumi:"txCacheOauthJwksUri" validate:"required"`
+	Env               TxCacheEnv               `pulumi:"txCacheEnv" validate:"required"`
+	ExternalSecretRef *utils.ExternalSecretRef `pulumi:"txCacheExternalSecretRef"` // Optional: sync sensitive env from AWS Secrets Manager instead of Env
+	PodIdentity       *aws.PodIdentityConfig   `pulumi:"txCachePodIdentity"`       // Optional: use EKS Pod Identity instead of static AWS access keys
 }
 
 type TxCacheComponentArgsInternal struct {
-	Namespace    pulumi.StringInput `pulumi:"txCacheNamespace" validate:"required"`
-	Name         pulumi.StringInput `pulumi:"txCacheName" validate:"required"`
-	Image        pulumi.StringInput `pulumi:"txCacheImage" validate:"required"`
-	Port         pulumi.IntInput    `pulumi:"txCachePort" validate:"required"`
-	OauthIssuer  pulumi.StringInput `pulumi:"txCacheOauthIssuer" validate:"required"`
-	OauthJwksUri pulumi.StringInput `pulumi:"txCacheOauthJwksUri" validate:"required"`
-	Env          TxCacheEnvInternal `pulumi:"txCacheEnv" validate:"required"`
+	Namespace         pulumi.StringInput       `pulumi:"txCacheNamespace" validate:"required"`
+	Name              pulumi.StringInput       `pulumi:"txCacheName" validate:"required"`
+	Image             pulumi.StringInput       `pulumi:"txCacheImage" validate:"required"`
+	Port              pulumi.IntInput          `pulumi:"txCachePort" validate:"required"`
+	OauthIssuer       pulumi.StringInput       `pulumi:"txCacheOauthIssuer" validate:"required"`
+	OauthJwksUri      pulumi.StringInput       `pulumi:"txCacheOauthJwksUri" validate:"required"`
+	Env               TxCacheEnvInternal       `pulumi:"txCacheEnv" validate:"required"`
+	ExternalSecretRef *utils.ExternalSecretRef `pulumi:"txCacheExternalSecretRef"`
+	PodIdentity       *aws.PodIdentityConfig   `pulumi:"txCachePodIdentity"`
 }
 
 type TxCacheEnv struct {
 	HttpPort                  string `pulumi:"txCacheHttpPort" validate:"required"`
 	AwsAccessKeyId            string `pulumi:"txCacheAwsAccessKeyId" validate:"required"`
-	AwsSecretAccessKey        string `pulumi:"txCacheAwsSecretAccessKey" validate:"required"`
+	AwsSecretAccessKey        string `pulumi:"txCacheAwsSecretAccessKey" validate:"required" secret:"true"`
 	AwsRegion                 string `pulumi:"txCacheAwsRegion" validate:"required"`
 	RustLog                   string `pulumi:"txCacheRustLog" validate:"required"`
 	BlockQueryStart           string `pulumi:"txCacheBlockQueryStart" validate:"required"`
@@ -60,7 +68,7 @@ type TxCacheEnv struct {
 type TxCacheEnvInternal struct {
 	HttpPort                  pulumi.StringInput `pulumi:"txCacheHttpPort" validate:"required"`
 	AwsAccessKeyId            pulumi.StringInput `pulumi:"txCacheAwsAccessKeyId" validate:"required"`
-	AwsSecretAccessKey        pulumi.StringInput `pulumi:"txCacheAwsSecretAccessKey" validate:"required"`
+	AwsSecretAccessKey        pulumi.StringInput `pulumi:"txCacheAwsSecretAccessKey" validate:"required" secret:"true"`
 	AwsRegion                 pulumi.StringInput `pulumi:"txCacheAwsRegion" validate:"required"`
 	RustLog                   pulumi.StringInput `pulumi:"txCacheRustLog" validate:"required"`
 	BlockQueryStart           pulumi.StringInput `pulumi:"txCacheBlockQueryStart" validate:"required"`
@@ -76,22 +84,29 @@ type TxCacheEnvInternal struct {
 }
 
 func (args TxCacheComponentArgs) toInternal() TxCacheComponentArgsInternal {
-	return TxCacheComponentArgsInternal{
-		Namespace:    pulumi.String(args.Namespace),
-		Name:         pulumi.String(args.Name),
-		Image:        pulumi.String(args.Image),
-		Port:         pulumi.Int(args.Port),
-		OauthIssuer:  pulumi.String(args.OauthIssuer),
-		OauthJwksUri: pulumi.String(args.OauthJwksUri),
-		Env:          args.Env.toInternal(),
+	internal := TxCacheComponentArgsInternal{
+		Namespace:         pulumi.String(args.Namespace),
+		Name:              pulumi.String(args.Name),
+		Image:             pulumi.String(args.Image),
+		Port:              pulumi.Int(args.Port),
+		OauthIssuer:       pulumi.String(args.OauthIssuer),
+		OauthJwksUri:      pulumi.String(args.OauthJwksUri),
+		Env:               args.Env.toInternal(),
+		ExternalSecretRef: args.ExternalSecretRef,
+		PodIdentity:       args.PodIdentity,
 	}
+	if args.PodIdentity != nil {
+		internal.Env.AwsAccessKeyId = nil
+		internal.Env.AwsSecretAccessKey = nil
+	}
+	return internal
 }
 
 func (env TxCacheEnv) toInternal() TxCacheEnvInternal {
 	return TxCacheEnvInternal{
 		HttpPort:                  pulumi.String(env.HttpPort),
 		AwsAccessKeyId:            pulumi.String(env.AwsAccessKeyId),
-		AwsSecretAccessKey:        pulumi.String(env.AwsSecretAccessKey),
+		AwsSecretAccessKey:        utils.SecretString(env.AwsSecretAccessKey),
 		AwsRegion:                 pulumi.String(env.AwsRegion),
 		RustLog:                   pulumi.String(env.RustLog),
 		BlockQueryStart:           pulumi.String(env.BlockQueryStart),
@@ -109,5 +124,12 @@ func (env TxCacheEnv) toInternal() TxCacheEnvInternal {
 
 // GetEnvMap implements the EnvProvider interface for TxCacheEnvInternal
 func (e TxCacheEnvInternal) GetEnvMap() pulumi.StringMap {
-	return utils.CreateEnvMap(e)
+	envMap, _ := utils.CreateEnvMap(e)
+	return envMap
+}
+
+// GetSecretEnvMap implements the SecretEnvProvider interface for TxCacheEnvInternal
+func (e TxCacheEnvInternal) GetSecretEnvMap() pulumi.StringMap {
+	_, secretMap := utils.CreateEnvMap(e)
+	return secretMap
 }
@@ -1,9 +1,11 @@
 package txcache
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,11 +29,13 @@ func TestTxCacheEnvInternal_GetEnvMap(t *testing.T) {
 	}
 
 	envMap := env.GetEnvMap()
+	secretMap := env.GetSecretEnvMap()
 
 	// Verify all required fields are mapped correctly
 	assert.Equal(t, pulumi.String("8080"), envMap["HTTP_PORT"])
 	assert.Equal(t, pulumi.String("test-key"), envMap["AWS_ACCESS_KEY_ID"])
-	assert.Equal(t, pulumi.String("test-secret"), envMap["AWS_SECRET_ACCESS_KEY"])
+	assert.Equal(t, pulumi.String("test-secret"), secretMap["AWS_SECRET_ACCESS_KEY"])
+	assert.NotContains(t, envMap, "AWS_SECRET_ACCESS_KEY")
 	assert.Equal(t, pulumi.String("us-east-1"), envMap["AWS_REGION"])
 	assert.Equal(t, pulumi.String("info"), envMap["RUST_LOG"])
 	assert.Equal(t, pulumi.String("0"), envMap["BLOCK_QUERY_START"])
@@ -65,12 +69,16 @@ func TestTxCacheEnvInternal_GetEnvMap_FieldNamesConversion(t *testing.T) {
 	}
 
 	envMap := env.GetEnvMap()
+	secretMap := env.GetSecretEnvMap()
+
+	// Sensitive values are routed to the secret map
+	_, exists := secretMap["AWS_SECRET_ACCESS_KEY"]
+	assert.True(t, exists, "Expected secret environment variable AWS_SECRET_ACCESS_KEY to exist")
 
 	// Ensure all expected env var names exist
 	expectedKeys := []string{
 		"HTTP_PORT",
 		"AWS_ACCESS_KEY_ID",
-		"AWS_SECRET_ACCESS_KEY",
 		"AWS_REGION",
 		"RUST_LOG",
 		"BLOCK_QUERY_START",
@@ -87,4 +95,23 @@ func TestTxCacheEnvInternal_GetEnvMap_FieldNamesConversion(t *testing.T) {
 		_, exists := envMap[key]
 		assert.True(t, exists, "Expected environment variable %s to exist", key)
 	}
-}
\ No newline at end of file
+}
+
+func TestTxCacheEnv_ToInternalMarksSecrets(t *testing.T) {
+	env := TxCacheEnv{
+		HttpPort:           "8080",
+		AwsAccessKeyId:     "key",
+		AwsSecretAccessKey: "secret",
+		AwsRegion:          "us-east-1",
+	}.toInternal()
+
+	result, err := internals.UnsafeAwaitOutput(context.Background(), env.AwsSecretAccessKey.ToStringOutput())
+	assert.NoError(t, err)
+	assert.True(t, result.Secret)
+	assert.Equal(t, "secret", result.Value)
+
+	// The access key id is not sensitive and stays in plaintext
+	result, err = internals.UnsafeAwaitOutput(context.Background(), env.AwsAccessKeyId.ToStringOutput())
+	assert.NoError(t, err)
+	assert.False(t, result.Secret)
+}
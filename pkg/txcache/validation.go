@@ -28,7 +28,26 @@ func (args TxCacheComponentArgs) Validate() error {
 		return fmt.Errorf("oauthJwksUri is required")
 	}
 
-	if err := validateEnv(args.Env); err != nil {
+	if args.ExternalSecretRef != nil {
+		if err := args.ExternalSecretRef.Validate(); err != nil {
+			return fmt.Errorf("invalid external secret ref: %w", err)
+		}
+		if args.PodIdentity == nil {
+			if err := args.ExternalSecretRef.RequireEnvNames(AwsSecretAccessKeyEnvName); err != nil {
+				return fmt.Errorf("invalid external secret ref: %w", err)
+			}
+		}
+	}
+
+	if args.PodIdentity != nil {
+		if err := args.PodIdentity.Validate(); err != nil {
+			return fmt.Errorf("invalid pod identity config: %w", err)
+		}
+	}
+
+	// Sensitive values are not required when they are synced from an external store,
+	// and static AWS keys are not required when using EKS Pod Identity
+	if err := validateEnv(args.Env, args.ExternalSecretRef == nil, args.PodIdentity == nil); err != nil {
 		return err
 	}
 
@@ -38,16 +57,18 @@ func (args TxCacheComponentArgs) Validate() error {
 // validateEnv validates the TxCacheEnv struct
 // OtelExporterOtlpProtocol and OtelExporterOtlpEndpoint are optional
 // so no validation needed for them
-func validateEnv(env TxCacheEnv) error {
+// Sensitive fields are only checked when requireSecrets is set, and static
+// AWS keys only when requireStaticCredentials is set
+func validateEnv(env TxCacheEnv, requireSecrets, requireStaticCredentials bool) error {
 	if env.HttpPort == "" {
 		return fmt.Errorf("httpPort is required")
 	}
 
-	if env.AwsAccessKeyId == "" {
+	if requireStaticCredentials && env.AwsAccessKeyId == "" {
 		return fmt.Errorf("awsAccessKeyId is required")
 	}
 
-	if env.AwsSecretAccessKey == "" {
+	if requireSecrets && requireStaticCredentials && env.AwsSecretAccessKey == "" {
 		return fmt.Errorf("awsSecretAccessKey is required")
 	}
 
@@ -2,6 +2,9 @@ package txcache
 
 import (
 	"testing"
+
+	"github.com/init4tech/signet-infra-components/pkg/aws"
+	"github.com/init4tech/signet-infra-components/pkg/utils"
 )
 
 func TestTxCacheComponentArgs_Validate(t *testing.T) {
@@ -81,6 +84,124 @@ func TestTxCacheComponentArgs_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "external secret ref without aws secret",
+			args: TxCacheComponentArgs{
+				Namespace:    "test-namespace",
+				Name:         "test-name",
+				Image:        "test-image",
+				Port:         8080,
+				OauthIssuer:  "test-issuer",
+				OauthJwksUri: "test-jwks-uri",
+				Env: TxCacheEnv{
+					HttpPort:                  "8080",
+					AwsAccessKeyId:            "test-key",
+					AwsRegion:                 "us-west-2",
+					RustLog:                   "info",
+					BlockQueryStart:           "1000",
+					BlockQueryCutoff:          "2000",
+					SlotOffset:                "0",
+					ExpirationTimestampOffset: "3600",
+					NetworkName:               "testnet",
+					Builders:                  "builder1,builder2",
+					SlotDuration:              "12",
+					StartTimestamp:            "1640995200",
+				},
+				ExternalSecretRef: &utils.ExternalSecretRef{
+					SecretStoreName: "aws-secrets-manager",
+					Data: []utils.ExternalSecretData{
+						{EnvName: "AWS_SECRET_ACCESS_KEY", RemoteKey: "signet/tx-cache"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "external secret ref without aws secret access key",
+			args: TxCacheComponentArgs{
+				Namespace:    "test-namespace",
+				Name:         "test-name",
+				Image:        "test-image",
+				Port:         8080,
+				OauthIssuer:  "test-issuer",
+				OauthJwksUri: "test-jwks-uri",
+				Env: TxCacheEnv{
+					HttpPort:                  "8080",
+					AwsAccessKeyId:            "test-key",
+					AwsRegion:                 "us-west-2",
+					RustLog:                   "info",
+					BlockQueryStart:           "1000",
+					BlockQueryCutoff:          "2000",
+					SlotOffset:                "0",
+					ExpirationTimestampOffset: "3600",
+					NetworkName:               "testnet",
+					Builders:                  "builder1,builder2",
+					SlotDuration:              "12",
+					StartTimestamp:            "1640995200",
+				},
+				ExternalSecretRef: &utils.ExternalSecretRef{
+					SecretStoreName: "aws-secrets-manager",
+					Data: []utils.ExternalSecretData{
+						{EnvName: "AWS_SECRET_KEY", RemoteKey: "signet/tx-cache"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid external secret ref",
+			args: TxCacheComponentArgs{
+				Namespace:    "test-namespace",
+				Name:         "test-name",
+				Image:        "test-image",
+				Port:         8080,
+				OauthIssuer:  "test-issuer",
+				OauthJwksUri: "test-jwks-uri",
+				ExternalSecretRef: &utils.ExternalSecretRef{
+					SecretStoreName: "aws-secrets-manager",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod identity without static aws keys",
+			args: TxCacheComponentArgs{
+				Namespace:    "test-namespace",
+				Name:         "test-name",
+				Image:        "test-image",
+				Port:         8080,
+				OauthIssuer:  "test-issuer",
+				OauthJwksUri: "test-jwks-uri",
+				Env: TxCacheEnv{
+					HttpPort:                  "8080",
+					AwsRegion:                 "us-west-2",
+					RustLog:                   "info",
+					BlockQueryStart:           "1000",
+					BlockQueryCutoff:          "2000",
+					SlotOffset:                "0",
+					ExpirationTimestampOffset: "3600",
+					NetworkName:               "testnet",
+					Builders:                  "builder1,builder2",
+					SlotDuration:              "12",
+					StartTimestamp:            "1640995200",
+				},
+				PodIdentity: &aws.PodIdentityConfig{ClusterName: "signet-cluster"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid pod identity config",
+			args: TxCacheComponentArgs{
+				Namespace:    "test-namespace",
+				Name:         "test-name",
+				Image:        "test-image",
+				Port:         8080,
+				OauthIssuer:  "test-issuer",
+				OauthJwksUri: "test-jwks-uri",
+				PodIdentity:  &aws.PodIdentityConfig{},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -377,7 +498,7 @@ func TestValidateEnv(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateEnv(tt.env)
+			err := validateEnv(tt.env, true, true)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validateEnv() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
@@ -11,12 +12,23 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
+// SecretTag is the struct tag used to mark env fields that hold sensitive values.
+// Fields tagged with `secret:"true"` are routed into a Kubernetes Secret instead of a ConfigMap.
+const SecretTag = "secret"
+
 // EnvProvider is an interface for structs that provide environment variables
 type EnvProvider interface {
 	// GetEnvMap converts the struct's fields to a map of environment variables
 	GetEnvMap() pulumi.StringMap
 }
 
+// SecretEnvProvider is an interface for env structs that also carry sensitive values
+type SecretEnvProvider interface {
+	EnvProvider
+	// GetSecretEnvMap converts the struct's secret fields to a map of environment variables
+	GetSecretEnvMap() pulumi.StringMap
+}
+
 // CreateConfigMap creates a Kubernetes ConfigMap from an environment variables struct
 // It automatically converts field names to environment variable format (UPPER_SNAKE_CASE)
 // The optional parent parameter can be provided to set the parent resource for the ConfigMap
@@ -48,16 +60,62 @@ func CreateConfigMap[T EnvProvider](
 	}, opts...)
 }
 
-// CreateEnvMap converts a struct to a map of environment variables
+// CreateSecret creates a Kubernetes Secret from the sensitive fields of an environment variables struct
+// The optional parent parameter can be provided to set the parent resource for the Secret
+func CreateSecret[T SecretEnvProvider](
+	ctx *pulumi.Context,
+	name string,
+	namespace pulumi.StringInput,
+	labels pulumi.StringMap,
+	env T,
+	parent ...pulumi.Resource,
+) (*corev1.Secret, error) {
+
+	// Prepare options with parent if provided
+	var opts []pulumi.ResourceOption
+	if len(parent) > 0 && parent[0] != nil {
+		opts = append(opts, pulumi.Parent(parent[0]))
+	}
+
+	return CreateSecretWithOptions(ctx, name, namespace, labels, env, opts...)
+}
+
+// CreateSecretWithOptions creates a Secret like CreateSecret, passing opts such as a parent
+// and aliases through to the resource
+func CreateSecretWithOptions[T SecretEnvProvider](
+	ctx *pulumi.Context,
+	name string,
+	namespace pulumi.StringInput,
+	labels pulumi.StringMap,
+	env T,
+	opts ...pulumi.ResourceOption,
+) (*corev1.Secret, error) {
+
+	// Get secret environment variables as a map using the SecretEnvProvider interface
+	data := env.GetSecretEnvMap()
+
+	// Create and return Secret
+	return corev1.NewSecret(ctx, name, &corev1.SecretArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		StringData: data,
+	}, opts...)
+}
+
+// CreateEnvMap converts a struct to maps of environment variables
 // Field names are converted from camelCase to UPPER_SNAKE_CASE
-func CreateEnvMap[T any](env T) pulumi.StringMap {
+// The first map holds plain values, the second holds fields tagged with `secret:"true"`
+func CreateEnvMap[T any](env T) (pulumi.StringMap, pulumi.StringMap) {
 	result := pulumi.StringMap{}
+	secrets := pulumi.StringMap{}
 	t := reflect.TypeOf(env)
 	v := reflect.ValueOf(env)
 
-	// If not a struct, return empty map
+	// If not a struct, return empty maps
 	if t.Kind() != reflect.Struct {
-		return result
+		return result, secrets
 	}
 
 	for i := 0; i < t.NumField(); i++ {
@@ -73,12 +131,25 @@ func CreateEnvMap[T any](env T) pulumi.StringMap {
 		envName := CamelToSnake(field.Name)
 
 		// Convert value to string using appropriate method
-		if stringValue := getStringValue(fieldValue); stringValue != nil {
+		stringValue := getStringValue(fieldValue)
+		if stringValue == nil {
+			continue
+		}
+
+		if isSecretField(field) {
+			secrets[envName] = stringValue
+		} else {
 			result[envName] = stringValue
 		}
 	}
 
-	return result
+	return result, secrets
+}
+
+// isSecretField reports whether a struct field is tagged as sensitive
+func isSecretField(field reflect.StructField) bool {
+	secret, err := strconv.ParseBool(field.Tag.Get(SecretTag))
+	return err == nil && secret
 }
 
 // getStringValue converts any pulumi Input to a StringInput
@@ -193,13 +264,41 @@ func CreateResourceRequirements(cpuLimit, memoryLimit, cpuRequest, memoryRequest
 	}
 }
 
-// CreatePersistentVolumeClaim creates a PVC with consistent labeling and defaults
+// VolumeSnapshot data source constants
+const (
+	VolumeSnapshotAPIGroup = "snapshot.storage.k8s.io"
+	VolumeSnapshotKind     = "VolumeSnapshot"
+)
+
+// SnapshotDataSourceRef returns a claim data source that restores the named VolumeSnapshot,
+// or nil when no snapshot name is given. The snapshot must be in the claim's namespace.
+func SnapshotDataSourceRef(snapshotName string) corev1.TypedObjectReferencePtrInput {
+	if snapshotName == "" {
+		return nil
+	}
+	return &corev1.TypedObjectReferenceArgs{
+		ApiGroup: pulumi.String(VolumeSnapshotAPIGroup),
+		Kind:     pulumi.String(VolumeSnapshotKind),
+		Name:     pulumi.String(snapshotName),
+	}
+}
+
+// IgnoreDataSourceChanges ignores changes to a claim's data source. The data source only
+// seeds a new volume, so setting or changing it must not replace an existing claim.
+func IgnoreDataSourceChanges() pulumi.ResourceOption {
+	return pulumi.IgnoreChanges([]string{"spec.dataSource", "spec.dataSourceRef"})
+}
+
+// CreatePersistentVolumeClaim creates a PVC with consistent labeling and defaults. The storage
+// class name is required; pass the Name of a storage.StorageClassComponent. When snapshotName
+// is set, a new volume is restored from that VolumeSnapshot instead of starting empty.
 func CreatePersistentVolumeClaim(
 	ctx *pulumi.Context,
 	name string,
 	namespace pulumi.StringInput,
 	storageSize pulumi.StringInput,
-	storageClass string,
+	storageClassName pulumi.StringInput,
+	snapshotName string,
 	labels pulumi.StringMap,
 	component pulumi.Resource,
 ) (*corev1.PersistentVolumeClaim, error) {
@@ -207,8 +306,8 @@ func CreatePersistentVolumeClaim(
 		storageSize = pulumi.String("150Gi") // Default storage size
 	}
 
-	if storageClass == "" {
-		storageClass = "aws-gp3" // Default storage class
+	if storageClassName == nil {
+		return nil, fmt.Errorf("storage class name is required for PVC %s", name)
 	}
 
 	if labels == nil {
@@ -227,7 +326,8 @@ func CreatePersistentVolumeClaim(
 					"storage": storageSize,
 				},
 			},
-			StorageClassName: pulumi.String(storageClass),
+			StorageClassName: storageClassName,
+			DataSourceRef:    SnapshotDataSourceRef(snapshotName),
 		},
-	}, pulumi.Parent(component))
+	}, pulumi.Parent(component), IgnoreDataSourceChanges())
 }
@@ -3,7 +3,9 @@ package utils
 import (
 	"testing"
 
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
 )
 
 type TestEnv struct {
@@ -103,13 +105,18 @@ func TestCreateEnvMap(t *testing.T) {
 	}
 
 	// Test automatic creation through reflection
-	result := CreateEnvMap(env)
+	result, secrets := CreateEnvMap(env)
 
 	// Should have 5 fields
 	if len(result) != 5 {
 		t.Errorf("Expected 5 environment variables, got %d", len(result))
 	}
 
+	// No fields are tagged as secret
+	if len(secrets) != 0 {
+		t.Errorf("Expected 0 secret environment variables, got %d", len(secrets))
+	}
+
 	// Check values
 	stringValue, ok := result["STRING_FIELD"]
 	if !ok || stringValue == nil {
@@ -117,6 +124,30 @@ func TestCreateEnvMap(t *testing.T) {
 	}
 }
 
+type TestSecretEnv struct {
+	PlainField  pulumi.StringInput `pulumi:"plainField"`
+	SecretField pulumi.StringInput `pulumi:"secretField" secret:"true"`
+	NotSecret   pulumi.StringInput `pulumi:"notSecret" secret:"false"`
+}
+
+func TestCreateEnvMapSplitsSecrets(t *testing.T) {
+	env := TestSecretEnv{
+		PlainField:  pulumi.String("plain-value"),
+		SecretField: pulumi.String("secret-value"),
+		NotSecret:   pulumi.String("not-secret-value"),
+	}
+
+	result, secrets := CreateEnvMap(env)
+
+	assert.Len(t, result, 2)
+	assert.Contains(t, result, "PLAIN_FIELD")
+	assert.Contains(t, result, "NOT_SECRET")
+	assert.NotContains(t, result, "SECRET_FIELD")
+
+	assert.Len(t, secrets, 1)
+	assert.Equal(t, pulumi.String("secret-value"), secrets["SECRET_FIELD"])
+}
+
 func TestEnvProviderInterface(t *testing.T) {
 	env := TestEnv{
 		StringField:    pulumi.String("string-value"),
@@ -141,3 +172,14 @@ func TestEnvProviderInterface(t *testing.T) {
 		t.Errorf("CUSTOM_FIELD not found or nil")
 	}
 }
+
+func TestSnapshotDataSourceRef(t *testing.T) {
+	assert.Nil(t, SnapshotDataSourceRef(""))
+
+	ref := SnapshotDataSourceRef("signet-node-data-20250101000000")
+	assert.Equal(t, &corev1.TypedObjectReferenceArgs{
+		ApiGroup: pulumi.String(VolumeSnapshotAPIGroup),
+		Kind:     pulumi.String(VolumeSnapshotKind),
+		Name:     pulumi.String("signet-node-data-20250101000000"),
+	}, ref)
+}
@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"fmt"
+
+	crd "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// External Secrets Operator constants
+const (
+	ExternalSecretAPIVersion          = "external-secrets.io/v1"
+	ExternalSecretKind                = "ExternalSecret"
+	ExternalSecretCreationPolicy      = "Owner"
+	SecretStoreKindSecretStore        = "SecretStore"
+	SecretStoreKindClusterSecretStore = "ClusterSecretStore"
+	DefaultSecretStoreKind            = SecretStoreKindClusterSecretStore
+	DefaultExternalSecretRefresh      = "1h"
+	ExternalSecretTemplateEngine      = "v2"
+)
+
+// ExternalSecretRef configures an External Secrets Operator ExternalSecret that syncs
+// sensitive env values from AWS Secrets Manager into a Kubernetes Secret.
+// When set on a component, the synced Secret replaces the Pulumi-managed env Secret,
+// so sensitive values never pass through Pulumi config.
+type ExternalSecretRef struct {
+	SecretStoreName string               // Name of the SecretStore or ClusterSecretStore backed by AWS Secrets Manager
+	SecretStoreKind string               // Optional: "SecretStore" or "ClusterSecretStore", defaults to "ClusterSecretStore"
+	RefreshInterval string               // Optional: defaults to "1h"
+	Data            []ExternalSecretData // Remote keys mapped to env var names
+}
+
+// ExternalSecretData maps a single remote secret value to an env var name
+type ExternalSecretData struct {
+	EnvName   string // Env var name written into the synced Secret, e.g. "BUILDER_KEY"
+	RemoteKey string // Secrets Manager secret name or ARN
+	Property  string // Optional: JSON property within the remote secret
+}
+
+// Validate validates the ExternalSecretRef
+func (ref *ExternalSecretRef) Validate() error {
+	if ref.SecretStoreName == "" {
+		return fmt.Errorf("secret store name is required")
+	}
+	if ref.SecretStoreKind != "" &&
+		ref.SecretStoreKind != SecretStoreKindSecretStore &&
+		ref.SecretStoreKind != SecretStoreKindClusterSecretStore {
+		return fmt.Errorf("secret store kind must be %q or %q", SecretStoreKindSecretStore, SecretStoreKindClusterSecretStore)
+	}
+	if len(ref.Data) == 0 {
+		return fmt.Errorf("at least one data entry is required")
+	}
+
+	seen := make(map[string]bool)
+	for i, data := range ref.Data {
+		if data.EnvName == "" {
+			return fmt.Errorf("data[%d]: env name is required", i)
+		}
+		if data.RemoteKey == "" {
+			return fmt.Errorf("data[%d]: remote key is required", i)
+		}
+		if seen[data.EnvName] {
+			return fmt.Errorf("data[%d]: duplicate env name %s", i, data.EnvName)
+		}
+		seen[data.EnvName] = true
+	}
+
+	return nil
+}
+
+// RequireEnvNames returns an error unless every name is synced by one of the data entries.
+// Components use it to make sure the secret env values they no longer take directly are covered
+func (ref *ExternalSecretRef) RequireEnvNames(names ...string) error {
+	synced := make(map[string]bool, len(ref.Data))
+	for _, data := range ref.Data {
+		synced[data.EnvName] = true
+	}
+	for _, name := range names {
+		if !synced[name] {
+			return fmt.Errorf("no data entry for env name %s", name)
+		}
+	}
+	return nil
+}
+
+// CreateExternalSecret creates an ExternalSecret custom resource that syncs the remote keys
+// described by ref into a Kubernetes Secret with the given name
+// The optional parent parameter can be provided to set the parent resource for the ExternalSecret
+func CreateExternalSecret(
+	ctx *pulumi.Context,
+	name string,
+	namespace pulumi.StringInput,
+	labels pulumi.StringMap,
+	ref ExternalSecretRef,
+	parent ...pulumi.Resource,
+) (*crd.CustomResource, error) {
+	storeKind := ref.SecretStoreKind
+	if storeKind == "" {
+		storeKind = DefaultSecretStoreKind
+	}
+
+	refreshInterval := ref.RefreshInterval
+	if refreshInterval == "" {
+		refreshInterval = DefaultExternalSecretRefresh
+	}
+
+	// Prepare options with parent if provided
+	var opts []pulumi.ResourceOption
+	if len(parent) > 0 && parent[0] != nil {
+		opts = append(opts, pulumi.Parent(parent[0]))
+	}
+
+	return crd.NewCustomResource(ctx, name, &crd.CustomResourceArgs{
+		ApiVersion: pulumi.String(ExternalSecretAPIVersion),
+		Kind:       pulumi.String(ExternalSecretKind),
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(name),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		OtherFields: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"refreshInterval": refreshInterval,
+				"secretStoreRef": map[string]interface{}{
+					"name": ref.SecretStoreName,
+					"kind": storeKind,
+				},
+				"target": map[string]interface{}{
+					"name":           name,
+					"creationPolicy": ExternalSecretCreationPolicy,
+				},
+				"data": externalSecretDataSpec(ref.Data),
+			},
+		},
+	}, opts...)
+}
+
+// externalSecretDataSpec converts data entries into the ExternalSecret spec.data format
+func externalSecretDataSpec(data []ExternalSecretData) []map[string]interface{} {
+	spec := make([]map[string]interface{}, 0, len(data))
+	for _, d := range data {
+		remoteRef := map[string]interface{}{
+			"key": d.RemoteKey,
+		}
+		if d.Property != "" {
+			remoteRef["property"] = d.Property
+		}
+		spec = append(spec, map[string]interface{}{
+			"secretKey": d.EnvName,
+			"remoteRef": remoteRef,
+		})
+	}
+	return spec
+}
+
+// CreateTemplatedExternalSecret creates an ExternalSecret custom resource that extracts every
+// property of the JSON remote secret remoteKey and renders the keys of the synced Secret from
+// templates, which use the External Secrets Operator template syntax, e.g. "{{ .password }}".
+// Unlike CreateExternalSecret the remote key may be an output, such as the ARN of a secret
+// created in the same deployment
+// The optional parent parameter can be provided to set the parent resource for the ExternalSecret
+func CreateTemplatedExternalSecret(
+	ctx *pulumi.Context,
+	name string,
+	namespace pulumi.StringInput,
+	labels pulumi.StringMap,
+	secretStoreName string,
+	secretStoreKind string,
+	remoteKey pulumi.StringInput,
+	templates pulumi.StringMap,
+	parent ...pulumi.Resource,
+) (*crd.CustomResource, error) {
+	if secretStoreKind == "" {
+		secretStoreKind = DefaultSecretStoreKind
+	}
+
+	var opts []pulumi.ResourceOption
+	if len(parent) > 0 && parent[0] != nil {
+		opts = append(opts, pulumi.Parent(parent[0]))
+	}
+
+	return crd.NewCustomResource(ctx, name, &crd.CustomResourceArgs{
+		ApiVersion: pulumi.String(ExternalSecretAPIVersion),
+		Kind:       pulumi.String(ExternalSecretKind),
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(name),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		OtherFields: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"refreshInterval": DefaultExternalSecretRefresh,
+				"secretStoreRef": map[string]interface{}{
+					"name": secretStoreName,
+					"kind": secretStoreKind,
+				},
+				"target": map[string]interface{}{
+					"name":           name,
+					"creationPolicy": ExternalSecretCreationPolicy,
+					"template": map[string]interface{}{
+						"engineVersion": ExternalSecretTemplateEngine,
+						"data":          templates,
+					},
+				},
+				"dataFrom": []map[string]interface{}{
+					{"extract": map[string]interface{}{"key": remoteKey}},
+				},
+			},
+		},
+	}, opts...)
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalSecretRefValidate(t *testing.T) {
+	validRef := ExternalSecretRef{
+		SecretStoreName: "aws-secrets-manager",
+		Data: []ExternalSecretData{
+			{EnvName: "BUILDER_KEY", RemoteKey: "signet/builder", Property: "builder_key"},
+			{EnvName: "AWS_SECRET_ACCESS_KEY", RemoteKey: "signet/aws"},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(ref *ExternalSecretRef)
+		wantErr string
+	}{
+		{
+			name:   "valid ref",
+			modify: func(ref *ExternalSecretRef) {},
+		},
+		{
+			name:   "valid namespaced store kind",
+			modify: func(ref *ExternalSecretRef) { ref.SecretStoreKind = SecretStoreKindSecretStore },
+		},
+		{
+			name:    "missing store name",
+			modify:  func(ref *ExternalSecretRef) { ref.SecretStoreName = "" },
+			wantErr: "secret store name is required",
+		},
+		{
+			name:    "invalid store kind",
+			modify:  func(ref *ExternalSecretRef) { ref.SecretStoreKind = "Vault" },
+			wantErr: "secret store kind must be",
+		},
+		{
+			name:    "no data",
+			modify:  func(ref *ExternalSecretRef) { ref.Data = nil },
+			wantErr: "at least one data entry is required",
+		},
+		{
+			name: "missing env name",
+			modify: func(ref *ExternalSecretRef) {
+				ref.Data = []ExternalSecretData{{RemoteKey: "signet/builder"}}
+			},
+			wantErr: "data[0]: env name is required",
+		},
+		{
+			name: "missing remote key",
+			modify: func(ref *ExternalSecretRef) {
+				ref.Data = []ExternalSecretData{{EnvName: "BUILDER_KEY"}}
+			},
+			wantErr: "data[0]: remote key is required",
+		},
+		{
+			name: "duplicate env name",
+			modify: func(ref *ExternalSecretRef) {
+				ref.Data = append(ref.Data, ExternalSecretData{EnvName: "BUILDER_KEY", RemoteKey: "other"})
+			},
+			wantErr: "data[2]: duplicate env name BUILDER_KEY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ref := validRef
+			ref.Data = append([]ExternalSecretData{}, validRef.Data...)
+			tc.modify(&ref)
+
+			err := ref.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestExternalSecretRefRequireEnvNames(t *testing.T) {
+	ref := ExternalSecretRef{
+		SecretStoreName: "aws-secrets-manager",
+		Data: []ExternalSecretData{
+			{EnvName: "BUILDER_KEY", RemoteKey: "signet/builder"},
+			{EnvName: "OAUTH_CLIENT_SECRET", RemoteKey: "signet/builder"},
+		},
+	}
+
+	assert.NoError(t, ref.RequireEnvNames())
+	assert.NoError(t, ref.RequireEnvNames("BUILDER_KEY", "OAUTH_CLIENT_SECRET"))
+
+	err := ref.RequireEnvNames("BUILDER_KEY", "AWS_SECRET_ACCESS_KEY")
+	assert.Error(t, err)
+	assert.Equal(t, "no data entry for env name AWS_SECRET_ACCESS_KEY", err.Error())
+}
+
+func TestExternalSecretDataSpec(t *testing.T) {
+	spec := externalSecretDataSpec([]ExternalSecretData{
+		{EnvName: "BUILDER_KEY", RemoteKey: "signet/builder", Property: "builder_key"},
+		{EnvName: "AWS_SECRET_ACCESS_KEY", RemoteKey: "signet/aws"},
+	})
+
+	assert.Len(t, spec, 2)
+
+	assert.Equal(t, "BUILDER_KEY", spec[0]["secretKey"])
+	assert.Equal(t, map[string]interface{}{
+		"key":      "signet/builder",
+		"property": "builder_key",
+	}, spec[0]["remoteRef"])
+
+	// Property is omitted when not set
+	assert.Equal(t, "AWS_SECRET_ACCESS_KEY", spec[1]["secretKey"])
+	assert.Equal(t, map[string]interface{}{
+		"key": "signet/aws",
+	}, spec[1]["remoteRef"])
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi-random/sdk/v4/go/random"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Engine API JWT constants
+const (
+	// JWTSecretKey is the key of the JWT in its Secret and the file name it is mounted as
+	JWTSecretKey = "jwt.hex"
+	// JWTSecretBytes is the length of the Engine API JWT secret
+	JWTSecretBytes = 32
+)
+
+// ValidateJWTSecret checks that secret is a hex encoded 32 byte Engine API JWT secret, with or
+// without a 0x prefix
+func ValidateJWTSecret(secret string) error {
+	digits := strings.TrimPrefix(secret, "0x")
+	if len(digits) != 2*JWTSecretBytes {
+		return fmt.Errorf("jwt secret must be %d hex characters, got %d", 2*JWTSecretBytes, len(digits))
+	}
+	if _, err := hex.DecodeString(digits); err != nil {
+		return fmt.Errorf("jwt secret must be hex encoded")
+	}
+	return nil
+}
+
+// NewSharedJWTSecret creates the Secret holding the Engine API JWT an execution client and a
+// consensus client both mount. Without a jwt a random one is generated with pulumi-random, which
+// keeps it in the stack state as a Pulumi secret, so later deployments reuse the same JWT.
+func NewSharedJWTSecret(ctx *pulumi.Context, name string, namespace pulumi.StringInput, partOf string, jwt string, opts ...pulumi.ResourceOption) (*corev1.Secret, error) {
+	var jwtValue pulumi.StringInput = SecretString(jwt)
+	if jwt == "" {
+		generated, err := random.NewRandomBytes(ctx, name, &random.RandomBytesArgs{
+			Length: pulumi.Int(JWTSecretBytes),
+		}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate jwt secret: %w", err)
+		}
+		jwtValue = generated.Hex
+	}
+
+	return corev1.NewSecret(ctx, name, &corev1.SecretArgs{
+		StringData: pulumi.StringMap{
+			JWTSecretKey: jwtValue,
+		},
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(name),
+			Namespace: namespace,
+			Labels:    CreateResourceLabels(partOf, name, partOf, nil),
+		},
+	}, opts...)
+}
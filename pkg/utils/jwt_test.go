@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJWTSecret(t *testing.T) {
+	valid := strings.Repeat("ab", 32)
+	assert.NoError(t, ValidateJWTSecret(valid))
+	assert.NoError(t, ValidateJWTSecret("0x"+valid))
+
+	err := ValidateJWTSecret("jwt-secret-token")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "jwt secret must be 64 hex characters, got 16")
+
+	err = ValidateJWTSecret(strings.Repeat("zz", 32))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "jwt secret must be hex encoded")
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// PodIndexEnvName exposes a StatefulSet pod's ordinal, read from the pod index label, so a
+// replica can address the replica with the same ordinal in another StatefulSet. Kubernetes
+// expands $(POD_INDEX) in container commands.
+const (
+	PodIndexEnvName   = "POD_INDEX"
+	PodIndexFieldPath = "metadata.labels['apps.kubernetes.io/pod-index']"
+)
+
+// PodIndexEnv returns the environment variable holding the pod's StatefulSet ordinal
+func PodIndexEnv() corev1.EnvVarArgs {
+	return corev1.EnvVarArgs{
+		Name: pulumi.String(PodIndexEnvName),
+		ValueFrom: &corev1.EnvVarSourceArgs{
+			FieldRef: &corev1.ObjectFieldSelectorArgs{
+				FieldPath: pulumi.String(PodIndexFieldPath),
+			},
+		},
+	}
+}
+
+// ResourceRequirements converts container resource requirements to their input type, returning
+// nil when none are given so the container keeps the cluster defaults
+func ResourceRequirements(resources *corev1.ResourceRequirements) corev1.ResourceRequirementsPtrInput {
+	if resources == nil {
+		return nil
+	}
+	return &corev1.ResourceRequirementsArgs{
+		Limits:   pulumi.ToStringMap(resources.Limits),
+		Requests: pulumi.ToStringMap(resources.Requests),
+	}
+}
+
+// ProbeTimeoutSeconds is how long a probe built by TCPProbe or HTTPProbe may take
+const ProbeTimeoutSeconds = 5
+
+// TCPProbe returns a probe opening a connection to port every periodSeconds, failing the
+// container after failureThreshold consecutive failures
+func TCPProbe(port, periodSeconds, failureThreshold int) *corev1.ProbeArgs {
+	return &corev1.ProbeArgs{
+		TcpSocket: &corev1.TCPSocketActionArgs{
+			Port: pulumi.Int(port),
+		},
+		PeriodSeconds:    pulumi.Int(periodSeconds),
+		TimeoutSeconds:   pulumi.Int(ProbeTimeoutSeconds),
+		FailureThreshold: pulumi.Int(failureThreshold),
+	}
+}
+
+// HTTPProbe returns a probe requesting path on port every periodSeconds, failing the container
+// after failureThreshold consecutive failures
+func HTTPProbe(path string, port, periodSeconds, failureThreshold int) *corev1.ProbeArgs {
+	return &corev1.ProbeArgs{
+		HttpGet: &corev1.HTTPGetActionArgs{
+			Path: pulumi.String(path),
+			Port: pulumi.Int(port),
+		},
+		PeriodSeconds:    pulumi.Int(periodSeconds),
+		TimeoutSeconds:   pulumi.Int(ProbeTimeoutSeconds),
+		FailureThreshold: pulumi.Int(failureThreshold),
+	}
+}
+
+// PreStopLifecycle returns a container lifecycle running command before the container is
+// stopped, returning nil when no command is given
+func PreStopLifecycle(command []string) corev1.LifecyclePtrInput {
+	if len(command) == 0 {
+		return nil
+	}
+	return &corev1.LifecycleArgs{
+		PreStop: &corev1.LifecycleHandlerArgs{
+			Exec: &corev1.ExecActionArgs{
+				Command: pulumi.ToStringArray(command),
+			},
+		},
+	}
+}
+
+// Readiness sidecar defaults
+const (
+	ReadinessSidecarName         = "sync-check"
+	DefaultReadinessSidecarImage = "busybox:1.36"
+	// ReadinessCheckTimeoutSeconds leaves room for a check script making several requests
+	ReadinessCheckTimeoutSeconds = 15
+	ReadinessCheckPeriodSeconds  = 10
+	// readinessSidecarIdle keeps the sidecar running until the pod stops
+	readinessSidecarIdle = "trap 'exit 0' TERM; while true; do sleep 3600 & wait $!; done"
+)
+
+// ReadinessSidecar returns a container that idles next to a client and holds the pod out of its
+// services while script, run as its readiness probe with env set, exits non-zero
+func ReadinessSidecar(image string, script string, env corev1.EnvVarArray) corev1.ContainerArgs {
+	if image == "" {
+		image = DefaultReadinessSidecarImage
+	}
+	return corev1.ContainerArgs{
+		Name:    pulumi.String(ReadinessSidecarName),
+		Image:   pulumi.String(image),
+		Command: pulumi.ToStringArray([]string{"/bin/sh", "-c", readinessSidecarIdle}),
+		Env:     env,
+		ReadinessProbe: &corev1.ProbeArgs{
+			Exec: &corev1.ExecActionArgs{
+				Command: pulumi.ToStringArray([]string{"/bin/sh", "-c", script}),
+			},
+			PeriodSeconds:    pulumi.Int(ReadinessCheckPeriodSeconds),
+			TimeoutSeconds:   pulumi.Int(ReadinessCheckTimeoutSeconds),
+			FailureThreshold: pulumi.Int(3),
+		},
+		Resources: &corev1.ResourceRequirementsArgs{
+			Requests: pulumi.StringMap{
+				"cpu":    pulumi.String("10m"),
+				"memory": pulumi.String("16Mi"),
+			},
+			Limits: pulumi.StringMap{
+				"memory": pulumi.String("32Mi"),
+			},
+		},
+	}
+}
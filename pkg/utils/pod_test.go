@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPodIndexEnv(t *testing.T) {
+	env := PodIndexEnv()
+	assert.Equal(t, pulumi.String(PodIndexEnvName), env.Name)
+	assert.Equal(t, pulumi.String(PodIndexFieldPath), env.ValueFrom.(*corev1.EnvVarSourceArgs).FieldRef.(*corev1.ObjectFieldSelectorArgs).FieldPath)
+}
+
+func TestResourceRequirements(t *testing.T) {
+	assert.Nil(t, ResourceRequirements(nil))
+
+	requirements := ResourceRequirements(&corev1.ResourceRequirements{
+		Limits:   map[string]string{"memory": "64Gi"},
+		Requests: map[string]string{"cpu": "4", "memory": "48Gi"},
+	}).(*corev1.ResourceRequirementsArgs)
+	assert.Equal(t, pulumi.StringMap{"memory": pulumi.String("64Gi")}, requirements.Limits)
+	assert.Equal(t, pulumi.StringMap{"cpu": pulumi.String("4"), "memory": pulumi.String("48Gi")}, requirements.Requests)
+}
+
+func TestProbes(t *testing.T) {
+	tcp := TCPProbe(8551, 30, 5)
+	assert.Equal(t, pulumi.Int(8551), tcp.TcpSocket.(*corev1.TCPSocketActionArgs).Port)
+	assert.Equal(t, pulumi.Int(30), tcp.PeriodSeconds)
+	assert.Equal(t, pulumi.Int(5), tcp.FailureThreshold)
+	assert.Nil(t, tcp.HttpGet)
+
+	http := HTTPProbe("/eth/v1/node/health", 5052, 10, 3)
+	httpGet := http.HttpGet.(*corev1.HTTPGetActionArgs)
+	assert.Equal(t, pulumi.String("/eth/v1/node/health"), httpGet.Path)
+	assert.Equal(t, pulumi.Int(5052), httpGet.Port)
+	assert.Equal(t, pulumi.Int(ProbeTimeoutSeconds), http.TimeoutSeconds)
+	assert.Nil(t, http.TcpSocket)
+}
+
+func TestPreStopLifecycle(t *testing.T) {
+	assert.Nil(t, PreStopLifecycle(nil))
+
+	lifecycle := PreStopLifecycle([]string{"sleep", "10"}).(*corev1.LifecycleArgs)
+	preStop := lifecycle.PreStop.(*corev1.LifecycleHandlerArgs)
+	assert.Equal(t, pulumi.ToStringArray([]string{"sleep", "10"}), preStop.Exec.(*corev1.ExecActionArgs).Command)
+}
+
+func TestReadinessSidecar(t *testing.T) {
+	env := corev1.EnvVarArray{corev1.EnvVarArgs{Name: pulumi.String("MAX_HEAD_LAG"), Value: pulumi.String("2")}}
+	sidecar := ReadinessSidecar("", "exit 0", env)
+	assert.Equal(t, pulumi.String(ReadinessSidecarName), sidecar.Name)
+	assert.Equal(t, pulumi.String(DefaultReadinessSidecarImage), sidecar.Image)
+	assert.Equal(t, env, sidecar.Env)
+
+	probe := sidecar.ReadinessProbe.(*corev1.ProbeArgs)
+	assert.Equal(t, pulumi.ToStringArray([]string{"/bin/sh", "-c", "exit 0"}), probe.Exec.(*corev1.ExecActionArgs).Command)
+
+	assert.Equal(t, pulumi.String("alpine:3.22"), ReadinessSidecar("alpine:3.22", "exit 0", nil).Image)
+}
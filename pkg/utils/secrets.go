@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// SecretString wraps a plain string in a StringOutput marked as a Pulumi secret
+// Secret outputs are encrypted in stack state and masked in previews, and outputs
+// derived from them through Sprintf or ApplyT remain secret
+func SecretString(value string) pulumi.StringOutput {
+	return pulumi.ToSecret(pulumi.String(value)).(pulumi.StringOutput)
+}
+
+// SecretStringMap wraps every value of a plain map as a Pulumi secret
+func SecretStringMap(values map[string]string) pulumi.StringMap {
+	result := pulumi.StringMap{}
+	for k, v := range values {
+		result[k] = SecretString(v)
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"fmt"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// HeadlessServiceSuffix is appended to a StatefulSet's name to name its governing Service
+const HeadlessServiceSuffix = "-headless"
+
+// StatefulSet rollout settings
+const (
+	// SkipAwaitAnnotation stops Pulumi from waiting for a resource to become ready
+	SkipAwaitAnnotation = "pulumi.com/skipAwait"
+	// PodManagementPolicyParallel starts and stops a StatefulSet's replicas all at once,
+	// instead of waiting for each to become ready before starting the next
+	PodManagementPolicyParallel = "Parallel"
+)
+
+// SkipAwaitAnnotations returns the annotations that stop Pulumi from waiting for a resource
+// to become ready, for workloads whose readiness can take hours
+func SkipAwaitAnnotations() pulumi.StringMap {
+	return pulumi.StringMap{
+		SkipAwaitAnnotation: pulumi.String("true"),
+	}
+}
+
+// VolumeClaimTemplate returns a StatefulSet volume claim template. The StatefulSet creates
+// one claim per replica from it, named "<name>-<statefulset>-<ordinal>", and mounts it as the
+// pod volume called name. When snapshotName is set, new claims are restored from that
+// VolumeSnapshot instead of starting empty.
+func VolumeClaimTemplate(
+	name string,
+	storageSize pulumi.StringInput,
+	storageClassName pulumi.StringInput,
+	snapshotName string,
+	labels pulumi.StringMap,
+) corev1.PersistentVolumeClaimTypeArgs {
+	return corev1.PersistentVolumeClaimTypeArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:   pulumi.String(name),
+			Labels: labels,
+		},
+		Spec: &corev1.PersistentVolumeClaimSpecArgs{
+			AccessModes: pulumi.StringArray{pulumi.String("ReadWriteOnce")},
+			Resources: &corev1.VolumeResourceRequirementsArgs{
+				Requests: pulumi.StringMap{
+					"storage": storageSize,
+				},
+			},
+			StorageClassName: storageClassName,
+			DataSourceRef:    SnapshotDataSourceRef(snapshotName),
+		},
+	}
+}
+
+// IgnoreClaimTemplateDataSourceChanges ignores changes to the data sources of a StatefulSet's
+// claim templates. Claim templates cannot be updated in place, so without this setting a
+// snapshot on an existing StatefulSet would replace it only to seed claims that already exist.
+func IgnoreClaimTemplateDataSourceChanges() pulumi.ResourceOption {
+	return pulumi.IgnoreChanges([]string{
+		"spec.volumeClaimTemplates[*].spec.dataSource",
+		"spec.volumeClaimTemplates[*].spec.dataSourceRef",
+	})
+}
+
+// StatefulSetClaimNames returns the names of the claims a StatefulSet creates from the named
+// claim template, one per replica
+func StatefulSetClaimNames(template string, statefulSetName pulumi.StringInput, replicas int) pulumi.StringArray {
+	names := make(pulumi.StringArray, 0, replicas)
+	for ordinal := 0; ordinal < replicas; ordinal++ {
+		names = append(names, pulumi.Sprintf("%s-%s-%d", template, statefulSetName, ordinal))
+	}
+	return names
+}
+
+// CreateHeadlessService creates the headless Service governing a StatefulSet, which gives each
+// replica a stable DNS name of the form "<pod>.<service>.<namespace>.svc". Addresses are
+// published before pods are ready so peers can find replicas that are still syncing.
+func CreateHeadlessService(
+	ctx *pulumi.Context,
+	name string,
+	namespace pulumi.StringInput,
+	selector pulumi.StringMap,
+	ports corev1.ServicePortArray,
+	labels pulumi.StringMap,
+	parent pulumi.Resource,
+) (*corev1.Service, error) {
+	service, err := corev1.NewService(ctx, name, &corev1.ServiceArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String(name),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: &corev1.ServiceSpecArgs{
+			ClusterIP:                pulumi.String("None"),
+			PublishNotReadyAddresses: pulumi.Bool(true),
+			Selector:                 selector,
+			Ports:                    ports,
+		},
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create headless service %s: %w", name, err)
+	}
+	return service, nil
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"testing"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeClaimTemplate(t *testing.T) {
+	template := VolumeClaimTemplate("data", pulumi.String("150Gi"), pulumi.String("gp3"), "", nil)
+	spec := template.Spec.(*corev1.PersistentVolumeClaimSpecArgs)
+	assert.Equal(t, pulumi.String("gp3"), spec.StorageClassName)
+	assert.Equal(t, pulumi.StringMap{"storage": pulumi.String("150Gi")}, spec.Resources.(*corev1.VolumeResourceRequirementsArgs).Requests)
+	assert.Nil(t, spec.DataSourceRef)
+
+	template = VolumeClaimTemplate("data", pulumi.String("150Gi"), pulumi.String("gp3"), "data-node-0-20250101000000", nil)
+	spec = template.Spec.(*corev1.PersistentVolumeClaimSpecArgs)
+	assert.Equal(t, SnapshotDataSourceRef("data-node-0-20250101000000"), spec.DataSourceRef)
+}
+
+func TestStatefulSetClaimNames(t *testing.T) {
+	names := StatefulSetClaimNames("data", pulumi.String("signet-node"), 3)
+	assert.Len(t, names, 3)
+
+	for ordinal, expected := range []string{"data-signet-node-0", "data-signet-node-1", "data-signet-node-2"} {
+		value, _ := awaitSecret(t, names[ordinal].ToStringOutput())
+		assert.Equal(t, expected, value)
+	}
+
+	assert.Empty(t, StatefulSetClaimNames("data", pulumi.String("signet-node"), 0))
+}